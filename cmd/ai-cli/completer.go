@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// completionCacheTTL is how long fetched resource names are reused
+const completionCacheTTL = 10 * time.Second
+
+// completionKinds maps resource kind keywords to the resource they complete
+var completionKinds = map[string]string{
+	"pod":         "pods",
+	"pods":        "pods",
+	"po":          "pods",
+	"deployment":  "deployments",
+	"deployments": "deployments",
+	"deploy":      "deployments",
+	"service":     "services",
+	"services":    "services",
+	"svc":         "services",
+}
+
+// cachedNames holds resource names fetched at a point in time
+type cachedNames struct {
+	names     []string
+	fetchedAt time.Time
+}
+
+// resourceCompleter completes resource names following a kind keyword
+type resourceCompleter struct {
+	clientset kubernetes.Interface
+	namespace string
+	mu        sync.Mutex
+	cache     map[string]cachedNames
+}
+
+// newResourceCompleter creates a completer for the cluster in kubeconfig; completion is disabled if it cannot be loaded
+func newResourceCompleter(kubeconfig string) *resourceCompleter {
+	completer := &resourceCompleter{
+		namespace: "default",
+		cache:     make(map[string]cachedNames),
+	}
+
+	kubeconfig = expandHome(kubeconfig)
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig != "" {
+		rules.ExplicitPath = kubeconfig
+	}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{})
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		logrus.Debugf("Resource name completion disabled: %v", err)
+		return completer
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		logrus.Debugf("Resource name completion disabled: %v", err)
+		return completer
+	}
+	if namespace, _, err := clientConfig.Namespace(); err == nil && namespace != "" {
+		completer.namespace = namespace
+	}
+
+	completer.clientset = clientset
+	return completer
+}
+
+// currentKubeContext returns the current context of the kubeconfig, or "" if it cannot be loaded
+func currentKubeContext(kubeconfig string) string {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig = expandHome(kubeconfig); kubeconfig != "" {
+		rules.ExplicitPath = kubeconfig
+	}
+	raw, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).RawConfig()
+	if err != nil {
+		logrus.Debugf("Failed to load kubeconfig context: %v", err)
+		return ""
+	}
+	return raw.CurrentContext
+}
+
+// expandHome replaces a leading ~ with the user's home directory
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// Complete returns the line with the last word completed and the candidates that matched
+func (c *resourceCompleter) Complete(line string) (string, []string) {
+	if c.clientset == nil {
+		return line, nil
+	}
+
+	fields := strings.Fields(line)
+	partial := ""
+	if !strings.HasSuffix(line, " ") && len(fields) > 0 {
+		partial = fields[len(fields)-1]
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) == 0 {
+		return line, nil
+	}
+
+	resource, ok := completionKinds[strings.ToLower(fields[len(fields)-1])]
+	if !ok {
+		return line, nil
+	}
+
+	names, err := c.names(resource)
+	if err != nil {
+		logrus.Debugf("Failed to fetch %s for completion: %v", resource, err)
+		return line, nil
+	}
+
+	var matches []string
+	for _, name := range names {
+		if strings.HasPrefix(name, partial) {
+			matches = append(matches, name)
+		}
+	}
+	if len(matches) == 0 {
+		return line, nil
+	}
+
+	completed := commonPrefix(matches)
+	if len(matches) == 1 {
+		completed += " "
+	}
+	return line[:len(line)-len(partial)] + completed, matches
+}
+
+// names returns the resource names in the namespace, using the cache when fresh
+func (c *resourceCompleter) names(resource string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.cache[resource]; ok && time.Since(cached.fetchedAt) < completionCacheTTL {
+		return cached.names, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var names []string
+	switch resource {
+	case "pods":
+		list, err := c.clientset.CoreV1().Pods(c.namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	case "deployments":
+		list, err := c.clientset.AppsV1().Deployments(c.namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	case "services":
+		list, err := c.clientset.CoreV1().Services(c.namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+	}
+
+	sort.Strings(names)
+	c.cache[resource] = cachedNames{names: names, fetchedAt: time.Now()}
+	return names, nil
+}
+
+// commonPrefix returns the longest prefix shared by all values
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
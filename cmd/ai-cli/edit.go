@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// editHeader starts the file opened in the editor, like kubectl edit
+const editHeader = `# Please edit the object below. Lines beginning with a '#' will be ignored,
+# and an empty file will abort the edit. If an error occurs while saving this file will be
+# reopened with the relevant failures.
+#
+`
+
+// parseEditArgs parses "<resource_type> <name> [-n namespace]"
+func parseEditArgs(args []string) (resourceType, name, namespace string, err error) {
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "-n" || arg == "--namespace":
+			if i+1 >= len(args) {
+				return "", "", "", fmt.Errorf("%s requires a namespace", arg)
+			}
+			i++
+			namespace = args[i]
+		case strings.HasPrefix(arg, "--namespace="):
+			namespace = strings.TrimPrefix(arg, "--namespace=")
+		default:
+			positional = append(positional, arg)
+		}
+	}
+	if len(positional) != 2 {
+		return "", "", "", fmt.Errorf("usage: edit <resource_type> <name> [-n namespace]")
+	}
+	return positional[0], positional[1], namespace, nil
+}
+
+// editResource fetches a resource from the MCP server, opens it in $EDITOR and applies the result through
+// apply_manifest, reopening the editor with the error until the apply succeeds or the edit is abandoned
+func editResource(serverURL, resourceType, name, namespace string) error {
+	args := map[string]interface{}{"resource_type": resourceType, "name": name}
+	if namespace != "" {
+		args["namespace"] = namespace
+	}
+	result, err := callServerTool(serverURL, "get_resource", args)
+	if err != nil {
+		return err
+	}
+	if len(result.Content) == 0 {
+		return fmt.Errorf("get_resource returned no manifest for %s/%s", resourceType, name)
+	}
+
+	file, err := os.CreateTemp("", "ai-cli-edit-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := file.Name()
+	file.Close()
+	defer os.Remove(path)
+
+	manifest := result.Content[0].Text
+	header := editHeader
+	for {
+		if err := os.WriteFile(path, []byte(header+manifest), 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		if err := runEditor(path); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		edited := stripEditComments(string(data))
+		if strings.TrimSpace(edited) == "" {
+			printer.Print("📝", "[NOTE]", "Edit cancelled, the file is empty\n")
+			return nil
+		}
+		if strings.TrimSpace(edited) == strings.TrimSpace(manifest) {
+			if header == editHeader {
+				printer.Print("📝", "[NOTE]", "Edit cancelled, no changes made\n")
+			} else {
+				printer.Print("📝", "[NOTE]", "Edit cancelled, no valid changes were saved\n")
+			}
+			return nil
+		}
+
+		manifest = edited
+		err = applyManifest(serverURL, manifest)
+		if err == nil {
+			return nil
+		}
+		printer.Print("❌", "[ERR]", "Error: %v\n", err)
+		header = editHeader + "# The edited manifest could not be applied:\n" + commentLines(err.Error()) + "#\n"
+	}
+}
+
+// runEditor opens path in $EDITOR, or vi when it is not set; EDITOR may include arguments such as "code --wait"
+func runEditor(path string) error {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", editor[0], err)
+	}
+	return nil
+}
+
+// stripEditComments removes the lines starting with '#'; indented lines are kept, since they can belong to block scalars
+func stripEditComments(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "#") {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// commentLines prefixes each line of text with "# "
+func commentLines(text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		b.WriteString("# ")
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
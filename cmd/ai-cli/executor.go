@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/llm"
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"github.com/mcp-servers/cli/pkg/nlp"
+)
+
+// readOnlyKubectlCommands are run without confirmation during the reasoning loop
+var readOnlyKubectlCommands = map[string]bool{
+	"get":           true,
+	"describe":      true,
+	"logs":          true,
+	"top":           true,
+	"version":       true,
+	"api-resources": true,
+}
+
+// kubectlExecutor runs tool calls by translating them to kubectl commands
+type kubectlExecutor struct {
+	kubeconfig      string
+	context         string
+	skipPermissions bool
+}
+
+// ExecuteTool translates a tool call to a kubectl command and runs it, refusing mutating commands unless permitted
+func (e *kubectlExecutor) ExecuteTool(ctx context.Context, toolCall llm.ToolCall) (string, error) {
+	command, err := nlp.TranslateToolCallToCommand(toolCall)
+	if err != nil {
+		return "", err
+	}
+	// These translations feed a manifest through a heredoc, which needs a shell; pass it as a file instead
+	switch toolCall.ToolName {
+	case "kubectl_validate_manifest":
+		manifest, _ := toolCall.Arguments["manifest"].(string)
+		file, err := writeManifestFile(manifest)
+		if err != nil {
+			return "", err
+		}
+		defer os.Remove(file)
+		command = nlp.ValidateManifestCommand(toolCall.Arguments, "'"+file+"'")
+	case "kubectl_apply_namespace_policy":
+		manifest, err := nlp.NamespacePolicyManifest(toolCall.Arguments)
+		if err != nil {
+			return "", err
+		}
+		if manifest != "" {
+			file, err := writeManifestFile(manifest)
+			if err != nil {
+				return "", err
+			}
+			defer os.Remove(file)
+			command = "kubectl apply -f '" + file + "'"
+		}
+	}
+
+	if strings.ContainsAny(command, "|;&$`") {
+		return fmt.Sprintf("Not executed: %q needs a shell. Ask the user to run it.", command), nil
+	}
+
+	args, err := splitCommand(command)
+	if err != nil {
+		return "", err
+	}
+	if len(args) < 2 || args[0] != "kubectl" {
+		return "", fmt.Errorf("not a kubectl command: %s", command)
+	}
+	canI := len(args) > 2 && args[1] == "auth" && args[2] == "can-i"
+	// The translated string may carry model-supplied flags, so a dry run is never read from it: the executor adds
+	// --dry-run=server after everything else, and kubectl uses the last --dry-run it is given
+	dryRun := dryRunRequested(toolCall)
+	if dryRun {
+		kept := args[:0:0]
+		for _, arg := range args {
+			if arg == "--" {
+				return fmt.Sprintf("Not executed: %q passes arguments after --, which a dry run cannot cover. Ask the user to run it.", command), nil
+			}
+			if arg != "--dry-run" && !strings.HasPrefix(arg, "--dry-run=") {
+				kept = append(kept, arg)
+			}
+		}
+		args = append(kept, "--dry-run=server")
+		command = strings.Join(args, " ")
+	}
+	if !readOnlyKubectlCommands[args[1]] && !canI && !dryRun && !e.skipPermissions {
+		return fmt.Sprintf("Not executed: %q modifies the cluster and requires confirmation. Ask the user to run it.", command), nil
+	}
+
+	printer.Print("⚙️", "[RUN]", "Running: %s\n", command)
+
+	if e.kubeconfig != "" {
+		args = append(args, "--kubeconfig", e.kubeconfig)
+	}
+	if e.context != "" {
+		args = append(args, "--context", e.context)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// kubectl auth can-i exits non-zero when the answer is "no"
+		if canI && strings.TrimSpace(stdout.String()) != "" {
+			return stdout.String(), nil
+		}
+		message := strings.TrimSpace(stderr.String())
+		if suggestion := mcp.RecoverySuggestion(kubectlFailureReason(message)); suggestion != "" {
+			message += "\nRecovery suggestion: " + suggestion
+		}
+		return "", fmt.Errorf("%s failed: %v: %s", command, err, message)
+	}
+
+	return stdout.String(), nil
+}
+
+// dryRunRequested reports whether a tool call only previews its changes, which the executor then enforces by
+// running it with --dry-run=server
+func dryRunRequested(toolCall llm.ToolCall) bool {
+	switch toolCall.ToolName {
+	case "kubectl_validate_manifest":
+		return true
+	case "kubectl_cleanup_orphaned_resources":
+		dryRun, _ := toolCall.Arguments["dry_run"].(bool)
+		return dryRun
+	}
+	return false
+}
+
+// writeManifestFile writes a manifest to a private temporary file
+func writeManifestFile(manifest string) (string, error) {
+	file, err := os.CreateTemp("", "ai-cli-manifest-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create manifest file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(manifest); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write manifest file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// kubectlFailureReason extracts the failure reason from kubectl output such as "Error from server (AlreadyExists): ..."
+func kubectlFailureReason(stderr string) string {
+	const prefix = "Error from server ("
+	start := strings.Index(stderr, prefix)
+	if start == -1 {
+		return ""
+	}
+	rest := stderr[start+len(prefix):]
+	end := strings.Index(rest, ")")
+	if end == -1 {
+		return ""
+	}
+
+	reason := rest[:end]
+	if reason == "NotFound" && strings.Contains(rest[end:], "namespaces \"") {
+		return mcp.ReasonNamespaceNotFound
+	}
+	return reason
+}
+
+// splitCommand splits a command line into arguments, honouring single and double quotes
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	var quote rune
+	inArg := false
+
+	for _, r := range command {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in command: %s", command)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
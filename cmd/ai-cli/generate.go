@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"github.com/mcp-servers/cli/pkg/nlp"
+)
+
+// generateManifest generates a manifest for the description and prints it
+func generateManifest(processor *nlp.Processor, description string) (string, error) {
+	printer.Print("📝", "[GEN]", "Generating manifest: %s\n", description)
+
+	manifest, err := processor.GenerateManifest(context.Background(), description)
+	if err != nil {
+		return "", err
+	}
+
+	fmt.Println("---")
+	fmt.Println(manifest)
+	return manifest, nil
+}
+
+// applyManifest sends a manifest to the MCP server's apply_manifest tool
+func applyManifest(serverURL, manifest string) error {
+	result, err := callServerTool(serverURL, "apply_manifest", map[string]interface{}{"manifest": manifest})
+	if err != nil {
+		return err
+	}
+	for _, content := range result.Content {
+		printer.Print("✅", "[OK]", "%s\n", content.Text)
+	}
+
+	return nil
+}
+
+// callServerTool calls a tool through the MCP server's REST endpoint
+func callServerTool(serverURL, tool string, args map[string]interface{}) (*mcp.ToolResult, error) {
+	if serverURL == "" {
+		return nil, fmt.Errorf("--mcp-server-url is required to call %s", tool)
+	}
+
+	body, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	client := &http.Client{Timeout: 60 * time.Second}
+	resp, err := client.Post(strings.TrimSuffix(serverURL, "/")+"/tools/"+tool, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", tool, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var result mcp.ToolResult
+	if resp.StatusCode != http.StatusOK {
+		if json.Unmarshal(data, &result) == nil && result.IsError {
+			return nil, fmt.Errorf("%s failed: %w", tool, result.Err())
+		}
+		var mcpErr mcp.Error
+		if json.Unmarshal(data, &mcpErr) == nil && mcpErr.Message != "" {
+			return nil, fmt.Errorf("%s failed: %s", tool, mcpErr.Message)
+		}
+		return nil, fmt.Errorf("%s failed with status %d", tool, resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &result, nil
+}
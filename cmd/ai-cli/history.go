@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Input history settings
+const (
+	defaultInputHistoryPath = "~/.config/mcp-servers/input_history.txt"
+	maxInputHistory         = 500
+)
+
+// historyKind separates CLI commands such as help from queries forwarded to the NLP processor
+type historyKind string
+
+const (
+	historyCommand historyKind = "cmd"
+	historyQuery   historyKind = "query"
+)
+
+// historyEntry is one line of input history
+type historyEntry struct {
+	kind historyKind
+	text string
+}
+
+// inputHistory is the persistent interactive input history, one "kind<TAB>text" entry per line
+type inputHistory struct {
+	path    string
+	entries []historyEntry
+}
+
+// loadInputHistory reads the history file; a missing or unreadable file starts an empty history
+func loadInputHistory(path string) *inputHistory {
+	h := &inputHistory{path: expandHome(path)}
+
+	file, err := os.Open(h.path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logrus.Warnf("Failed to read input history: %v", err)
+		}
+		return h
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		kind, text, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok || text == "" || (historyKind(kind) != historyCommand && historyKind(kind) != historyQuery) {
+			continue
+		}
+		h.entries = append(h.entries, historyEntry{kind: historyKind(kind), text: text})
+	}
+	h.trim()
+	return h
+}
+
+// classifyInput returns the history kind of an input line, or false for input that must not be saved
+func classifyInput(input string) (historyKind, bool) {
+	// The key would otherwise be written to disk in plain text
+	if strings.HasPrefix(input, "set-api-key") {
+		return "", false
+	}
+
+	word, _, _ := strings.Cut(input, " ")
+	switch word {
+	case "exit", "quit", "clear", "history", "help", "import-history", "generate", "edit":
+		return historyCommand, true
+	}
+	return historyQuery, true
+}
+
+// add appends an entry, skipping immediate repeats, and rewrites the history file
+func (h *inputHistory) add(kind historyKind, text string) {
+	if strings.ContainsAny(text, "\r\n") {
+		return
+	}
+	if n := len(h.entries); n > 0 && h.entries[n-1].text == text {
+		return
+	}
+	h.entries = append(h.entries, historyEntry{kind: kind, text: text})
+	h.trim()
+
+	if err := h.save(); err != nil {
+		logrus.Warnf("Failed to save input history: %v", err)
+	}
+}
+
+// trim keeps the most recent maxInputHistory entries
+func (h *inputHistory) trim() {
+	if len(h.entries) > maxInputHistory {
+		h.entries = append([]historyEntry(nil), h.entries[len(h.entries)-maxInputHistory:]...)
+	}
+}
+
+// save writes the history readable only by the current user, since queries can name internal systems
+func (h *inputHistory) save() error {
+	if err := os.MkdirAll(filepath.Dir(h.path), 0700); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	for _, entry := range h.entries {
+		b.WriteString(string(entry.kind))
+		b.WriteString("\t")
+		b.WriteString(entry.text)
+		b.WriteString("\n")
+	}
+	return os.WriteFile(h.path, []byte(b.String()), 0600)
+}
+
+// search returns the index of the newest query entry before index from that contains term, or -1
+func (h *inputHistory) search(term string, from int) int {
+	for i := min(from, len(h.entries)) - 1; i >= 0; i-- {
+		if h.entries[i].kind == historyQuery && strings.Contains(h.entries[i].text, term) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// errInterrupted is returned when the user presses Ctrl-C while editing a line
+var errInterrupted = errors.New("interrupted")
+
+// lineReader reads input lines from the user
+type lineReader interface {
+	ReadLine(prompt string) (string, error)
+
+	// AddHistory records an accepted input line for later recall
+	AddHistory(kind historyKind, line string)
+}
+
+// newLineReader returns a tab-completing editor with history when stdin is a terminal, otherwise a plain line scanner
+func newLineReader(completer *resourceCompleter, history *inputHistory) lineReader {
+	fd := int(os.Stdin.Fd())
+	if !isTerminal(fd) {
+		return &scannerReader{scanner: bufio.NewScanner(os.Stdin)}
+	}
+	return &lineEditor{
+		fd:        fd,
+		in:        bufio.NewReader(os.Stdin),
+		out:       os.Stdout,
+		completer: completer,
+		history:   history,
+	}
+}
+
+// scannerReader reads lines without editing support
+type scannerReader struct {
+	scanner *bufio.Scanner
+}
+
+// ReadLine prints the prompt and reads the next line
+func (r *scannerReader) ReadLine(prompt string) (string, error) {
+	fmt.Print(prompt)
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
+
+// AddHistory does nothing; piped input is not worth recalling
+func (r *scannerReader) AddHistory(kind historyKind, line string) {}
+
+// lineEditor is a minimal raw-mode line editor with tab completion, emacs-style movement keys,
+// history navigation and Ctrl-R reverse search
+type lineEditor struct {
+	fd        int
+	in        *bufio.Reader
+	out       io.Writer
+	completer *resourceCompleter
+	history   *inputHistory
+}
+
+// Keys returned by readKey for escape sequences
+const (
+	keyUp rune = -(iota + 1)
+	keyDown
+	keyLeft
+	keyRight
+	keyHome
+	keyEnd
+	keyDelete
+	keyUnknown
+)
+
+// AddHistory records an accepted input line in the persistent history
+func (e *lineEditor) AddHistory(kind historyKind, line string) {
+	if e.history != nil {
+		e.history.add(kind, line)
+	}
+}
+
+// ReadLine reads a line in raw mode
+func (e *lineEditor) ReadLine(prompt string) (string, error) {
+	restore, err := makeRaw(e.fd)
+	if err != nil {
+		return "", fmt.Errorf("failed to enable raw mode: %w", err)
+	}
+	defer restore()
+
+	var line []rune
+	cursor := 0
+	redraw := func() {
+		fmt.Fprintf(e.out, "\r\033[K%s%s", prompt, string(line))
+		if back := len(line) - cursor; back > 0 {
+			fmt.Fprintf(e.out, "\033[%dD", back)
+		}
+	}
+	setLine := func(text string) {
+		line = []rune(text)
+		cursor = len(line)
+		redraw()
+	}
+
+	// historyIndex is the entry being shown; len(entries) is the new line, kept in draft while browsing
+	historyIndex := e.historyLen()
+	draft := ""
+	redraw()
+
+	for {
+		r, err := e.readKey()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\n")
+			return string(line), nil
+		case 3: // Ctrl-C
+			fmt.Fprint(e.out, "^C\n")
+			return "", errInterrupted
+		case 4: // Ctrl-D
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\n")
+				return "", io.EOF
+			}
+			if cursor < len(line) {
+				line = append(line[:cursor], line[cursor+1:]...)
+				redraw()
+			}
+		case keyDelete:
+			if cursor < len(line) {
+				line = append(line[:cursor], line[cursor+1:]...)
+				redraw()
+			}
+		case 127, 8: // Backspace
+			if cursor > 0 {
+				line = append(line[:cursor-1], line[cursor:]...)
+				cursor--
+				redraw()
+			}
+		case 1, keyHome: // Ctrl-A
+			cursor = 0
+			redraw()
+		case 5, keyEnd: // Ctrl-E
+			cursor = len(line)
+			redraw()
+		case 2, keyLeft: // Ctrl-B
+			if cursor > 0 {
+				cursor--
+				redraw()
+			}
+		case 6, keyRight: // Ctrl-F
+			if cursor < len(line) {
+				cursor++
+				redraw()
+			}
+		case 11: // Ctrl-K deletes to the end of the line
+			line = line[:cursor]
+			redraw()
+		case 21: // Ctrl-U deletes to the start of the line
+			line = append([]rune(nil), line[cursor:]...)
+			cursor = 0
+			redraw()
+		case 23: // Ctrl-W deletes the word before the cursor
+			start := cursor
+			for start > 0 && unicode.IsSpace(line[start-1]) {
+				start--
+			}
+			for start > 0 && !unicode.IsSpace(line[start-1]) {
+				start--
+			}
+			line = append(line[:start], line[cursor:]...)
+			cursor = start
+			redraw()
+		case 16, keyUp: // Ctrl-P
+			if historyIndex > 0 {
+				if historyIndex == e.historyLen() {
+					draft = string(line)
+				}
+				historyIndex--
+				setLine(e.history.entries[historyIndex].text)
+			}
+		case 14, keyDown: // Ctrl-N
+			if historyIndex < e.historyLen() {
+				historyIndex++
+				if historyIndex == e.historyLen() {
+					setLine(draft)
+				} else {
+					setLine(e.history.entries[historyIndex].text)
+				}
+			}
+		case 18: // Ctrl-R
+			found, accepted, err := e.reverseSearch(string(line))
+			if err != nil {
+				return "", err
+			}
+			setLine(found)
+			if accepted {
+				fmt.Fprint(e.out, "\n")
+				return found, nil
+			}
+		case '\t':
+			if e.completer == nil || cursor != len(line) {
+				continue
+			}
+			completed, matches := e.completer.Complete(string(line))
+			if len(matches) > 1 {
+				fmt.Fprintf(e.out, "\n%s\n", strings.Join(matches, "  "))
+			}
+			setLine(completed)
+		default:
+			if r < 32 || r == utf8.RuneError {
+				continue
+			}
+			line = append(line[:cursor], append([]rune{r}, line[cursor:]...)...)
+			cursor++
+			if cursor == len(line) {
+				fmt.Fprint(e.out, string(r))
+			} else {
+				redraw()
+			}
+		}
+	}
+}
+
+// historyLen returns the number of history entries
+func (e *lineEditor) historyLen() int {
+	if e.history == nil {
+		return 0
+	}
+	return len(e.history.entries)
+}
+
+// reverseSearch runs an incremental search back through query history, like Ctrl-R in bash.
+// It returns the chosen line and whether Enter accepted it; Ctrl-G or Ctrl-C return the original line.
+func (e *lineEditor) reverseSearch(original string) (string, bool, error) {
+	if e.history == nil {
+		return original, false, nil
+	}
+
+	var term []rune
+	match := -1
+	redraw := func() {
+		text := ""
+		if match >= 0 {
+			text = e.history.entries[match].text
+		}
+		label := "reverse-i-search"
+		if match < 0 && len(term) > 0 {
+			label = "failing reverse-i-search"
+		}
+		fmt.Fprintf(e.out, "\r\033[K(%s)`%s': %s", label, string(term), text)
+	}
+	result := func() string {
+		if match < 0 {
+			return original
+		}
+		return e.history.entries[match].text
+	}
+	redraw()
+
+	for {
+		r, err := e.readKey()
+		if err != nil {
+			return "", false, err
+		}
+
+		switch {
+		case r == '\r' || r == '\n':
+			return result(), true, nil
+		case r == 3 || r == 7: // Ctrl-C, Ctrl-G
+			return original, false, nil
+		case r == 18: // Ctrl-R finds the next older match
+			if len(term) > 0 && match > 0 {
+				if older := e.history.search(string(term), match); older >= 0 {
+					match = older
+				}
+			}
+		case r == 127 || r == 8:
+			if len(term) > 0 {
+				term = term[:len(term)-1]
+				match = -1
+				if len(term) > 0 {
+					match = e.history.search(string(term), len(e.history.entries))
+				}
+			}
+		case r >= 32 && r != utf8.RuneError:
+			term = append(term, r)
+			from := len(e.history.entries)
+			if match >= 0 {
+				// Keep the current match while it still contains the longer term
+				from = match + 1
+			}
+			match = e.history.search(string(term), from)
+		default:
+			// Any other key ends the search and leaves the match on the line for editing
+			return result(), false, nil
+		}
+		redraw()
+	}
+}
+
+// readKey reads one keypress, decoding arrow, Home, End and Delete escape sequences
+func (e *lineEditor) readKey() (rune, error) {
+	r, _, err := e.in.ReadRune()
+	if err != nil || r != 27 {
+		return r, err
+	}
+
+	next, _, err := e.in.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	if next == 'O' {
+		// SS3 sequences sent by some terminals for Home and End
+		final, _, err := e.in.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		return escapeKey(final, ""), nil
+	}
+	if next != '[' {
+		return keyUnknown, nil
+	}
+
+	var params []rune
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		if r >= 0x40 && r <= 0x7e {
+			return escapeKey(r, string(params)), nil
+		}
+		params = append(params, r)
+	}
+}
+
+// escapeKey maps the final byte and parameters of an escape sequence to a key
+func escapeKey(final rune, params string) rune {
+	switch final {
+	case 'A':
+		return keyUp
+	case 'B':
+		return keyDown
+	case 'C':
+		return keyRight
+	case 'D':
+		return keyLeft
+	case 'H':
+		return keyHome
+	case 'F':
+		return keyEnd
+	case '~':
+		switch params {
+		case "1", "7":
+			return keyHome
+		case "4", "8":
+			return keyEnd
+		case "3":
+			return keyDelete
+		}
+	}
+	return keyUnknown
+}
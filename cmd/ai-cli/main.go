@@ -5,14 +5,22 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/mcp-servers/cli/internal/config"
+	"github.com/mcp-servers/cli/pkg/llm"
 	"github.com/mcp-servers/cli/pkg/nlp"
+	"github.com/mcp-servers/cli/pkg/output"
 	"github.com/sirupsen/logrus"
 )
 
+// printer writes status output, honouring --no-emoji
+var printer = output.NewPrinter(false)
+
 func main() {
 	var (
 		configPath  = flag.String("config", "", "Path to configuration file")
@@ -21,15 +29,41 @@ func main() {
 		quiet       = flag.Bool("quiet", false, "Suppress verbose output")
 		model       = flag.String("model", "", "Override LLM model")
 		provider    = flag.String("provider", "", "Override LLM provider")
+		authToken   = flag.String("webhook-auth-token", "", "Bearer token required by the webhook server")
+		mcpServer   = flag.String("mcp-server-url", "", "MCP server URL used to apply generated manifests")
+		agent       = flag.Bool("agent", false, "Execute tool calls with kubectl and reason over the results until done")
+		history     = flag.Bool("import-history", false, "Load recent kubectl commands from shell history as conversation context")
+		apply       = flag.Bool("apply", false, "Apply the manifest produced by the generate subcommand")
+		noEmoji     = flag.Bool("no-emoji", false, "Print plain ASCII prefixes instead of emoji")
+		stream      = flag.Bool("stream", false, "Stream answers token by token in interactive mode; tool calls are only generated without streaming")
+		noEnvExpand = flag.Bool("no-env-expand", false, "Read config files literally instead of expanding ${VAR} and ${VAR:-default}")
+		kubeContext = flag.String("kube-context", "", "Kubeconfig context to use (defaults to the current context)")
+		profile     = flag.String("profile", "", "Profile from ~/.config/mcp-servers/profiles.yaml to use (defaults to the current profile)")
 	)
 	flag.Parse()
+	printer = output.NewPrinter(*noEmoji)
+
+	// Managing schedules does not need an LLM provider
+	if flag.Arg(0) == "schedules" {
+		if err := runSchedulesCommand(flag.Args()[1:]); err != nil {
+			logrus.Fatalf("%v", err)
+		}
+		return
+	}
 
 	// Load configuration
-	llmConfig, err := config.LoadLLMConfig(*configPath)
+	llmConfig, err := config.LoadLLMConfigProfile(*configPath, *profile, !*noEnvExpand)
 	if err != nil {
 		logrus.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Connect to the profile's MCP server unless one was given explicitly
+	if *mcpServer == "" {
+		if selected, err := config.LoadProfile(*profile); err == nil && selected != nil {
+			*mcpServer = selected.MCPServerURL
+		}
+	}
+
 	// Override with command line flags
 	if *model != "" {
 		llmConfig.Model = *model
@@ -47,8 +81,51 @@ func main() {
 		logrus.Fatalf("Failed to create LLM provider: %v", err)
 	}
 
+	// Track response latency against the SLO target
+	sloTracker := llm.NewSLOTracker(llmProvider, llmConfig.SLOp99LatencyMs)
+	if llmConfig.MonitoringListenAddress != "" {
+		go serveMonitoring(llmConfig.MonitoringListenAddress, sloTracker)
+	}
+
 	// Create NLP processor
-	processor := nlp.NewProcessor(llmProvider)
+	processor := nlp.NewProcessor(sloTracker)
+	processor.SetMaxQueryLength(llmConfig.MaxQueryLength)
+	processor.SetQueryCacheTTL(llmConfig.QueryCacheTTL)
+	processor.SetMaxIterations(llmConfig.MaxIterations)
+	processor.SetMaxTokens(llmConfig.MaxTokens)
+
+	// Record queries and responses for offline analysis
+	if llmConfig.TracePath != "" {
+		tracer, err := nlp.NewTraceLogger(llmConfig.TracePath)
+		if err != nil {
+			logrus.Warnf("Tracing disabled: %v", err)
+		} else {
+			defer tracer.Close()
+			processor.SetTraceLogger(tracer)
+		}
+	}
+
+	// Add the system prompt configured for the active kubeconfig context
+	activeContext := *kubeContext
+	if activeContext == "" {
+		activeContext = currentKubeContext(llmConfig.Kubeconfig)
+	}
+	if prompt, ok := llmConfig.ContextPrompts[activeContext]; ok {
+		logrus.Debugf("Using system prompt for context %q", activeContext)
+		processor.SetSystemPrompt(prompt)
+	}
+
+	if *agent {
+		processor.SetToolExecutor(&kubectlExecutor{
+			kubeconfig:      expandHome(llmConfig.Kubeconfig),
+			context:         *kubeContext,
+			skipPermissions: llmConfig.SkipPermissions,
+		})
+	}
+
+	if *history {
+		importHistory(processor, "")
+	}
 
 	// Set up logging
 	if llmConfig.Quiet {
@@ -57,32 +134,57 @@ func main() {
 		logrus.SetLevel(logrus.InfoLevel)
 	}
 
+	// Re-read the API key from the environment on SIGHUP
+	watchAPIKeyRotation(sloTracker)
+
 	// Display current configuration
 	if !llmConfig.Quiet {
-		fmt.Printf("🤖 AI CLI - Kubernetes Assistant\n")
+		printer.Print("🤖", "[AI]", "AI CLI - Kubernetes Assistant\n")
 		fmt.Printf("Provider: %s\n", llmProvider.GetProvider())
 		fmt.Printf("Model: %s\n", llmProvider.GetModel())
 		fmt.Printf("Configuration: %s\n\n", *configPath)
 	}
 
-	// Process single query or run interactively
-	if *query != "" {
+	// Process single query, serve webhooks, or run interactively
+	if flag.Arg(0) == "webhook" {
+		if err := runWebhook(processor, llmConfig.UIListenAddress, *authToken); err != nil {
+			logrus.Fatalf("Webhook server failed: %v", err)
+		}
+	} else if flag.Arg(0) == "schedule" {
+		if err := runScheduleCommand(processor, *agent, flag.Args()[1:]); err != nil {
+			logrus.Fatalf("Scheduler failed: %v", err)
+		}
+	} else if flag.Arg(0) == "generate" {
+		manifest, err := generateManifest(processor, strings.Join(flag.Args()[1:], " "))
+		if err != nil {
+			logrus.Fatalf("Failed to generate manifest: %v", err)
+		}
+		if *apply {
+			if err := applyManifest(*mcpServer, manifest); err != nil {
+				logrus.Fatalf("Failed to apply manifest: %v", err)
+			}
+		}
+	} else if *query != "" {
 		if err := processQuery(processor, *query); err != nil {
 			logrus.Fatalf("Failed to process query: %v", err)
 		}
 	} else if *interactive {
-		runInteractive(processor)
+		runInteractive(processor, sloTracker, newResourceCompleter(llmConfig.Kubeconfig), *mcpServer, *stream)
 	} else {
 		fmt.Println("Usage:")
 		fmt.Println("  ./ai-cli --query 'list all pods'")
 		fmt.Println("  ./ai-cli --interactive")
+		fmt.Println("  ./ai-cli --webhook-auth-token TOKEN webhook")
+		fmt.Println("  ./ai-cli [--apply --mcp-server-url URL] generate 'a redis deployment with 2 replicas'")
+		fmt.Println("  ./ai-cli --agent schedule --name failed-pods --query 'list failed pods' --interval 5m --notify slack")
+		fmt.Println("  ./ai-cli schedules list | schedules stop <name>")
 		fmt.Println("  ./ai-cli --help")
 	}
 }
 
 // processQuery processes a single query
 func processQuery(processor *nlp.Processor, query string) error {
-	fmt.Printf("🔍 Processing: %s\n", query)
+	printer.Print("🔍", "[QUERY]", "Processing: %s\n", query)
 
 	ctx := context.Background()
 	response, err := processor.ProcessQuery(ctx, query)
@@ -91,65 +193,232 @@ func processQuery(processor *nlp.Processor, query string) error {
 	}
 
 	// Display response
-	fmt.Printf("🤖 AI Response: %s\n", response.Content)
+	printer.Print("🤖", "[AI]", "AI Response: %s\n", response.Content)
+	printToolCalls(processor, response)
+	return nil
+}
+
+// streamQuery processes a single query, printing the answer as it is generated
+func streamQuery(processor *nlp.Processor, query string) error {
+	printer.Print("🔍", "[QUERY]", "Processing: %s\n", query)
+	printer.Print("🤖", "[AI]", "AI Response: ")
+
+	type result struct {
+		response *llm.Response
+		err      error
+	}
+	tokens := make(chan string)
+	done := make(chan result, 1)
+	go func() {
+		response, err := processor.ProcessQueryStream(context.Background(), query, tokens)
+		done <- result{response, err}
+	}()
+
+	w := bufio.NewWriter(os.Stdout)
+	for token := range tokens {
+		w.WriteString(token)
+		w.Flush()
+	}
+	fmt.Println()
+
+	res := <-done
+	if res.err != nil {
+		return fmt.Errorf("failed to process query: %w", res.err)
+	}
+	printToolCalls(processor, res.response)
+	return nil
+}
+
+// printToolCalls reports reasoning steps and lists the commands for a response's tool calls
+func printToolCalls(processor *nlp.Processor, response *llm.Response) {
+	if iteration, ok := response.Metadata["iteration"].(int); ok && iteration > 1 {
+		printer.Print("🔁", "[DONE]", "Completed in %d reasoning steps\n", iteration)
+	}
 
 	// Process tool calls
 	if len(response.ToolCalls) > 0 {
-		fmt.Println("\n🔧 Tool Calls:")
+		fmt.Println()
+		printer.Print("🔧", "[TOOLS]", "Tool Calls:\n")
 		for i, toolCall := range response.ToolCalls {
+			if toolCall.ToolName == "generate_manifest" {
+				description, _ := toolCall.Arguments["description"].(string)
+				if _, err := generateManifest(processor, description); err != nil {
+					fmt.Printf("  %d. %s Error: %v\n", i+1, printer.Prefix("❌", "[ERR]"), err)
+				}
+				continue
+			}
 			command, err := nlp.TranslateToolCallToCommand(toolCall)
 			if err != nil {
-				fmt.Printf("  %d. ❌ Error: %v\n", i+1, err)
+				fmt.Printf("  %d. %s Error: %v\n", i+1, printer.Prefix("❌", "[ERR]"), err)
 				continue
 			}
 			fmt.Printf("  %d. %s\n", i+1, command)
 		}
 	}
+}
+
+// importHistory loads kubectl commands from shell history, defaulting to ~/.zsh_history or ~/.bash_history
+func importHistory(processor *nlp.Processor, path string) {
+	if path == "" {
+		var err error
+		if path, err = nlp.DefaultShellHistoryPath(); err != nil {
+			printer.Print("❌", "[ERR]", "Error: %v\n", err)
+			return
+		}
+	}
+
+	count, err := processor.ImportShellHistory(path, nlp.DefaultHistoryImportLimit)
+	if err != nil {
+		printer.Print("❌", "[ERR]", "Error: %v\n", err)
+		return
+	}
+	printer.Print("📥", "[IMPORT]", "Imported %d kubectl command(s) from %s\n", count, path)
+}
+
+// serveMonitoring serves the SLO report and latency histogram
+func serveMonitoring(addr string, tracker *llm.SLOTracker) {
+	mux := http.NewServeMux()
+	mux.Handle("/slo", tracker)
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		tracker.Histogram().WritePrometheus(w, "llm_response_time_seconds")
+	})
+
+	logrus.Infof("Monitoring listener on %s", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		logrus.Errorf("Monitoring listener failed: %v", err)
+	}
+}
+
+// watchAPIKeyRotation rotates the provider's API key from LLM_API_KEY whenever SIGHUP is received
+func watchAPIKeyRotation(provider llm.Provider) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	go func() {
+		for range sigCh {
+			newKey := os.Getenv("LLM_API_KEY")
+			if newKey == "" {
+				logrus.Warn("Received SIGHUP but LLM_API_KEY is not set, keeping current API key")
+				continue
+			}
+			if err := rotateAPIKey(provider, newKey); err != nil {
+				logrus.Errorf("Failed to rotate API key: %v", err)
+			}
+		}
+	}()
+}
+
+// rotateAPIKey swaps the API key of a provider that supports rotation
+func rotateAPIKey(provider llm.Provider, newKey string) error {
+	rotator, ok := provider.(llm.KeyRotator)
+	if !ok {
+		return fmt.Errorf("provider %s does not support API key rotation", provider.GetProvider())
+	}
 
+	if err := rotator.RotateAPIKey(newKey); err != nil {
+		return err
+	}
+
+	logrus.Infof("Rotated API key for provider %s", provider.GetProvider())
 	return nil
 }
 
 // runInteractive runs the CLI in interactive mode
-func runInteractive(processor *nlp.Processor) {
-	fmt.Println("🚀 Interactive Mode - Type 'exit' to quit, 'clear' to clear history")
+func runInteractive(processor *nlp.Processor, provider llm.Provider, completer *resourceCompleter, mcpServerURL string, stream bool) {
+	printer.Print("🚀", "[START]", "Interactive Mode - Type 'exit' to quit, 'clear' to clear history\n")
 	fmt.Println("Example queries:")
 	fmt.Println("  - list all pods in default namespace")
 	fmt.Println("  - create a deployment called myapp using nginx:latest")
 	fmt.Println("  - scale deployment myapp to 5 replicas")
 	fmt.Println("  - delete pod nginx-deployment-abc123")
+	fmt.Println("Press Tab after pod, deployment or service to complete resource names")
+	fmt.Println("Use Up/Down to browse input history and Ctrl+R to search past queries")
 	fmt.Println()
 
-	scanner := bufio.NewScanner(os.Stdin)
+	reader := newLineReader(completer, loadInputHistory(defaultInputHistoryPath))
 	for {
-		fmt.Print("🤖 > ")
-		if !scanner.Scan() {
+		line, err := reader.ReadLine(printer.Prefix("🤖", "[AI]") + " > ")
+		if err == errInterrupted {
+			continue
+		}
+		if err != nil {
 			break
 		}
 
-		input := strings.TrimSpace(scanner.Text())
+		input := strings.TrimSpace(line)
 		if input == "" {
 			continue
 		}
+		if kind, ok := classifyInput(input); ok {
+			reader.AddHistory(kind, input)
+		}
 
 		// Handle special commands
+		if strings.HasPrefix(input, "set-api-key") {
+			newKey := strings.TrimSpace(strings.TrimPrefix(input, "set-api-key"))
+			if newKey == "" {
+				fmt.Println("Usage: set-api-key <key>")
+			} else if err := rotateAPIKey(provider, newKey); err != nil {
+				printer.Print("❌", "[ERR]", "Error: %v\n", err)
+			} else {
+				printer.Print("🔑", "[KEY]", "API key updated\n")
+			}
+			continue
+		}
+
+		if input == "import-history" || strings.HasPrefix(input, "import-history ") {
+			importHistory(processor, strings.TrimSpace(strings.TrimPrefix(input, "import-history")))
+			continue
+		}
+
+		if input == "edit" || strings.HasPrefix(input, "edit ") {
+			resourceType, name, namespace, err := parseEditArgs(strings.Fields(input)[1:])
+			if err == nil {
+				err = editResource(mcpServerURL, resourceType, name, namespace)
+			}
+			if err != nil {
+				printer.Print("❌", "[ERR]", "Error: %v\n", err)
+			}
+			fmt.Println()
+			continue
+		}
+
+		if strings.HasPrefix(input, "generate ") {
+			manifest, err := generateManifest(processor, strings.TrimPrefix(input, "generate "))
+			if err != nil {
+				printer.Print("❌", "[ERR]", "Error: %v\n", err)
+			} else if mcpServerURL != "" {
+				answer, _ := reader.ReadLine("Apply this manifest? [y/N] ")
+				if strings.EqualFold(strings.TrimSpace(answer), "y") {
+					if err := applyManifest(mcpServerURL, manifest); err != nil {
+						printer.Print("❌", "[ERR]", "Error: %v\n", err)
+					}
+				}
+			}
+			fmt.Println()
+			continue
+		}
+
 		switch input {
 		case "exit", "quit":
-			fmt.Println("👋 Goodbye!")
+			printer.Print("👋", "[BYE]", "Goodbye!\n")
 			return
 		case "clear":
 			processor.ClearHistory()
-			fmt.Println("🧹 History cleared")
+			processor.ClearQueryCache()
+			printer.Print("🧹", "[CLEAR]", "History and query cache cleared\n")
 			continue
 		case "history":
 			history := processor.GetHistory()
 			if len(history) == 0 {
-				fmt.Println("📝 No conversation history")
+				printer.Print("📝", "[NOTE]", "No conversation history\n")
 			} else {
-				fmt.Println("📝 Conversation History:")
+				printer.Print("📝", "[NOTE]", "Conversation History:\n")
 				for _, msg := range history {
-					role := "👤"
+					role := printer.Prefix("👤", "[USER]")
 					if msg.Role == "assistant" {
-						role = "🤖"
+						role = printer.Prefix("🤖", "[AI]")
 					}
 					fmt.Printf("  %s %s: %s\n", role, msg.Role, msg.Content)
 				}
@@ -158,8 +427,12 @@ func runInteractive(processor *nlp.Processor) {
 		case "help":
 			fmt.Println("Available commands:")
 			fmt.Println("  exit/quit - Exit the application")
-			fmt.Println("  clear - Clear conversation history")
+			fmt.Println("  clear - Clear conversation history and query cache")
 			fmt.Println("  history - Show conversation history")
+			fmt.Println("  set-api-key <key> - Replace the LLM API key without restarting")
+			fmt.Println("  import-history [file] - Load recent kubectl commands from shell history")
+			fmt.Println("  generate <description> - Generate a Kubernetes manifest (and apply it when --mcp-server-url is set)")
+			fmt.Println("  edit <resource_type> <name> [-n namespace] - Edit a live resource in $EDITOR and apply it (requires --mcp-server-url)")
 			fmt.Println("  help - Show this help")
 			fmt.Println()
 			fmt.Println("Or ask natural language questions about Kubernetes!")
@@ -167,8 +440,14 @@ func runInteractive(processor *nlp.Processor) {
 		}
 
 		// Process the query
-		if err := processQuery(processor, input); err != nil {
-			fmt.Printf("❌ Error: %v\n", err)
+		process := processQuery
+		if stream {
+			process = streamQuery
+		}
+		if err := process(processor, input); err != nil {
+			printer.Print("❌", "[ERR]", "Error: %v\n", err)
+		} else if processor.TokenBudgetLow() {
+			printer.Print("⚠️", "[WARN]", "Warning: approaching token limit (%d tokens left); the conversation will be summarized when it runs out\n", processor.GetRemainingBudget())
 		}
 		fmt.Println()
 	}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/nlp"
+	"github.com/sirupsen/logrus"
+)
+
+// Scheduler settings
+const (
+	defaultSchedulesPath = "~/.config/mcp-servers/schedules.json"
+
+	// minScheduleInterval keeps schedules from running faster than the query cache expires
+	minScheduleInterval = time.Minute
+
+	// scheduleReloadInterval is how often a running scheduler picks up schedules added or stopped elsewhere
+	scheduleReloadInterval = 15 * time.Second
+)
+
+// schedule is a natural language query run on an interval
+type schedule struct {
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	Interval  string    `json:"interval"`
+	Notify    string    `json:"notify"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// loadSchedules reads the saved schedules; a missing file means there are none
+func loadSchedules(path string) ([]schedule, error) {
+	data, err := os.ReadFile(expandHome(path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedules: %w", err)
+	}
+
+	var schedules []schedule
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		return nil, fmt.Errorf("failed to parse schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// saveSchedules writes the schedules, readable only by the current user
+func saveSchedules(path string, schedules []schedule) error {
+	path = expandHome(path)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create schedules directory: %w", err)
+	}
+
+	if schedules == nil {
+		schedules = []schedule{}
+	}
+	data, err := json.MarshalIndent(schedules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedules: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write schedules: %w", err)
+	}
+	return nil
+}
+
+// runScheduleCommand handles "schedule [flags]": it saves the schedule described by the flags, if any,
+// then runs every saved schedule until interrupted. Schedules need the --agent executor: without it a query only
+// produces the model's text and never looks at the cluster.
+func runScheduleCommand(processor *nlp.Processor, agent bool, args []string) error {
+	fs := flag.NewFlagSet("schedule", flag.ContinueOnError)
+	name := fs.String("name", "", "Name of the schedule")
+	query := fs.String("query", "", "Natural language query to run")
+	interval := fs.Duration("interval", 5*time.Minute, "How often to run the query")
+	notify := fs.String("notify", "stdout", "Where to report changed results: stdout or slack (posts to SLACK_WEBHOOK_URL)")
+	file := fs.String("file", defaultSchedulesPath, "Schedules file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !agent {
+		return fmt.Errorf("schedules run kubectl through the agent; rerun with --agent")
+	}
+
+	if *query != "" {
+		if *name == "" {
+			return fmt.Errorf("--name is required when adding a schedule")
+		}
+		if *interval < minScheduleInterval {
+			return fmt.Errorf("--interval must be at least %s", minScheduleInterval)
+		}
+		if _, err := newNotifier(*notify); err != nil {
+			return err
+		}
+
+		schedules, err := loadSchedules(*file)
+		if err != nil {
+			return err
+		}
+		for _, existing := range schedules {
+			if existing.Name == *name {
+				return fmt.Errorf("schedule %q already exists; stop it first", *name)
+			}
+		}
+		schedules = append(schedules, schedule{
+			Name:      *name,
+			Query:     *query,
+			Interval:  interval.String(),
+			Notify:    *notify,
+			CreatedAt: time.Now().UTC(),
+		})
+		if err := saveSchedules(*file, schedules); err != nil {
+			return err
+		}
+		printer.Print("⏰", "[SCHED]", "Saved schedule %s: %q every %s\n", *name, *query, *interval)
+	}
+
+	return newScheduler(processor, *file).run()
+}
+
+// runSchedulesCommand handles "schedules list" and "schedules stop <name>"
+func runSchedulesCommand(args []string) error {
+	fs := flag.NewFlagSet("schedules", flag.ContinueOnError)
+	file := fs.String("file", defaultSchedulesPath, "Schedules file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch fs.Arg(0) {
+	case "list":
+		schedules, err := loadSchedules(*file)
+		if err != nil {
+			return err
+		}
+		if len(schedules) == 0 {
+			fmt.Println("No schedules.")
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tINTERVAL\tNOTIFY\tCREATED\tQUERY\t")
+		for _, s := range schedules {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t\n", s.Name, s.Interval, s.Notify, s.CreatedAt.Local().Format("2006-01-02 15:04"), s.Query)
+		}
+		return w.Flush()
+	case "stop":
+		name := fs.Arg(1)
+		if name == "" {
+			return fmt.Errorf("usage: schedules stop <name>")
+		}
+		schedules, err := loadSchedules(*file)
+		if err != nil {
+			return err
+		}
+		for i, s := range schedules {
+			if s.Name == name {
+				if err := saveSchedules(*file, append(schedules[:i], schedules[i+1:]...)); err != nil {
+					return err
+				}
+				printer.Print("🛑", "[STOP]", "Stopped schedule %s\n", name)
+				return nil
+			}
+		}
+		return fmt.Errorf("schedule %q not found", name)
+	default:
+		return fmt.Errorf("usage: schedules list | schedules stop <name>")
+	}
+}
+
+// scheduler runs saved schedules, each in its own goroutine, until interrupted
+type scheduler struct {
+	processor *nlp.Processor
+	file      string
+
+	// mu serializes queries since the processor keeps shared conversation history
+	mu      sync.Mutex
+	running map[string]runningSchedule
+}
+
+// runningSchedule is a schedule with a goroutine running it
+type runningSchedule struct {
+	schedule schedule
+	cancel   context.CancelFunc
+}
+
+func newScheduler(processor *nlp.Processor, file string) *scheduler {
+	return &scheduler{
+		processor: processor,
+		file:      file,
+		running:   make(map[string]runningSchedule),
+	}
+}
+
+// run starts the saved schedules and keeps them in sync with the schedules file until SIGINT or SIGTERM
+func (s *scheduler) run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := s.reload(ctx); err != nil {
+		return err
+	}
+	printer.Print("⏰", "[SCHED]", "Running %d schedule(s); press Ctrl+C to exit\n", len(s.running))
+
+	ticker := time.NewTicker(scheduleReloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.reload(ctx); err != nil {
+				logrus.Warnf("Failed to reload schedules: %v", err)
+			}
+		}
+	}
+}
+
+// reload starts schedules added to the file and cancels those removed or changed
+func (s *scheduler) reload(ctx context.Context) error {
+	schedules, err := loadSchedules(s.file)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]schedule, len(schedules))
+	for _, sched := range schedules {
+		wanted[sched.Name] = sched
+	}
+	for name, r := range s.running {
+		if sched, ok := wanted[name]; !ok || sched != r.schedule {
+			r.cancel()
+			delete(s.running, name)
+			logrus.Infof("Stopped schedule %s", name)
+		}
+	}
+
+	for name, sched := range wanted {
+		if _, ok := s.running[name]; ok {
+			continue
+		}
+		interval, err := time.ParseDuration(sched.Interval)
+		if err != nil || interval < minScheduleInterval {
+			logrus.Warnf("Skipping schedule %s: invalid interval %q", name, sched.Interval)
+			continue
+		}
+		notifier, err := newNotifier(sched.Notify)
+		if err != nil {
+			logrus.Warnf("Skipping schedule %s: %v", name, err)
+			continue
+		}
+
+		runCtx, cancel := context.WithCancel(ctx)
+		s.running[name] = runningSchedule{schedule: sched, cancel: cancel}
+		go s.loop(runCtx, sched, interval, notifier)
+	}
+	return nil
+}
+
+// loop runs a schedule's query immediately and then every interval, notifying when the result changes
+func (s *scheduler) loop(ctx context.Context, sched schedule, interval time.Duration, notify notifier) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previous string
+	first := true
+	for {
+		result, err := s.runQuery(ctx, sched.Query)
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			logrus.Warnf("Schedule %s failed: %v", sched.Name, err)
+		case first:
+			logrus.Infof("Schedule %s recorded its first result", sched.Name)
+			previous, first = result, false
+		case result != previous:
+			if err := notify(sched, result); err != nil {
+				logrus.Warnf("Schedule %s: failed to send notification: %v", sched.Name, err)
+			}
+			previous = result
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runQuery answers a query from a clean conversation so earlier runs do not influence the result
+func (s *scheduler) runQuery(ctx context.Context, query string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.processor.ClearHistory()
+	s.processor.ClearQueryCache()
+	response, err := s.processor.ProcessQuery(ctx, query)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	result.WriteString(strings.TrimSpace(response.Content))
+	for _, toolCall := range response.ToolCalls {
+		if command, err := nlp.TranslateToolCallToCommand(toolCall); err == nil {
+			result.WriteString("\n$ " + command)
+		}
+	}
+	return result.String(), nil
+}
+
+// notifier reports a changed schedule result
+type notifier func(sched schedule, result string) error
+
+// newNotifier returns the notifier for a --notify value
+func newNotifier(kind string) (notifier, error) {
+	switch kind {
+	case "", "stdout":
+		return func(sched schedule, result string) error {
+			printer.Print("🔔", "[CHANGED]", "%s (%s): %s\n%s\n", sched.Name, time.Now().Format("15:04:05"), sched.Query, result)
+			return nil
+		}, nil
+	case "slack":
+		if os.Getenv("SLACK_WEBHOOK_URL") == "" {
+			return nil, fmt.Errorf("--notify slack requires SLACK_WEBHOOK_URL to be set")
+		}
+		return notifySlack, nil
+	default:
+		return nil, fmt.Errorf("unsupported notifier %q: use stdout or slack", kind)
+	}
+}
+
+// notifySlack posts the result to the Slack incoming webhook in SLACK_WEBHOOK_URL
+func notifySlack(sched schedule, result string) error {
+	body, err := json.Marshal(map[string]string{
+		"text": fmt.Sprintf("*%s* result changed for `%s`:\n```%s```", sched.Name, sched.Query, result),
+	})
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(os.Getenv("SLACK_WEBHOOK_URL"), "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+//go:build linux
+
+package main
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// isTerminal reports whether fd refers to a terminal
+func isTerminal(fd int) bool {
+	var termios syscall.Termios
+	return ioctlTermios(fd, syscall.TCGETS, &termios) == nil
+}
+
+// makeRaw disables echo, canonical mode and signal keys on fd and returns a function restoring the previous state
+func makeRaw(fd int) (func(), error) {
+	var old syscall.Termios
+	if err := ioctlTermios(fd, syscall.TCGETS, &old); err != nil {
+		return nil, err
+	}
+
+	raw := old
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctlTermios(fd, syscall.TCSETS, &raw); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		ioctlTermios(fd, syscall.TCSETS, &old)
+	}, nil
+}
+
+func ioctlTermios(fd int, request uintptr, termios *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), request, uintptr(unsafe.Pointer(termios)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
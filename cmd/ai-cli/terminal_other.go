@@ -0,0 +1,15 @@
+//go:build !linux
+
+package main
+
+import "errors"
+
+// isTerminal reports false on platforms without raw mode support, so input falls back to line scanning
+func isTerminal(fd int) bool {
+	return false
+}
+
+// makeRaw is not supported on this platform
+func makeRaw(fd int) (func(), error) {
+	return nil, errors.New("raw terminal mode is not supported on this platform")
+}
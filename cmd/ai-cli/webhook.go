@@ -0,0 +1,131 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/nlp"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// webhookReadTimeout bounds reading a request, so slow clients cannot hold connections open
+	webhookReadTimeout = 30 * time.Second
+	// webhookWriteTimeout bounds a whole query, which waits on the LLM before it responds
+	webhookWriteTimeout = 5 * time.Minute
+)
+
+// webhookRequest is the body accepted by the /query endpoint
+type webhookRequest struct {
+	Query string `json:"query"`
+}
+
+// webhookResponse is the body returned by the /query endpoint
+type webhookResponse struct {
+	Response string   `json:"response"`
+	Commands []string `json:"commands"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// webhookServer exposes the NLP processor over HTTP
+type webhookServer struct {
+	processor *nlp.Processor
+	authToken string
+
+	// mu serializes queries since the processor keeps conversation history, which is cleared before each query so
+	// callers never see each other's queries
+	mu sync.Mutex
+}
+
+// runWebhook starts an HTTP server that processes natural language queries
+func runWebhook(processor *nlp.Processor, addr, authToken string) error {
+	ws := &webhookServer{
+		processor: processor,
+		authToken: authToken,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/query", ws.handleQuery)
+
+	if authToken == "" {
+		logrus.Warn("Webhook server started without --webhook-auth-token, requests are not authenticated")
+	}
+	logrus.Infof("Starting webhook server on %s", addr)
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: webhookReadTimeout,
+		ReadTimeout:       webhookReadTimeout,
+		WriteTimeout:      webhookWriteTimeout,
+	}
+	return server.ListenAndServe()
+}
+
+// handleQuery handles POST /query requests
+func (ws *webhookServer) handleQuery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !ws.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		http.Error(w, "query is required", http.StatusBadRequest)
+		return
+	}
+
+	ws.mu.Lock()
+	ws.processor.ClearHistory()
+	response, err := ws.processor.ProcessQuery(r.Context(), req.Query)
+	ws.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		logrus.Errorf("Webhook query failed: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(webhookResponse{Error: err.Error()})
+		return
+	}
+
+	commands := []string{}
+	for _, toolCall := range response.ToolCalls {
+		command, err := nlp.TranslateToolCallToCommand(toolCall)
+		if err != nil {
+			logrus.Warnf("Failed to translate tool call %s: %v", toolCall.ToolName, err)
+			continue
+		}
+		commands = append(commands, command)
+	}
+
+	json.NewEncoder(w).Encode(webhookResponse{
+		Response: response.Content,
+		Commands: commands,
+	})
+}
+
+// authorized checks the Bearer token when one is configured
+func (ws *webhookServer) authorized(r *http.Request) bool {
+	if ws.authToken == "" {
+		return true
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(ws.authToken)) == 1
+}
@@ -1,17 +1,34 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/mcp-servers/cli/internal/config"
+	"github.com/mcp-servers/cli/pkg/mcp"
 	"github.com/mcp-servers/cli/servers/kubernetes"
+	exampleplugin "github.com/mcp-servers/cli/servers/kubernetes/plugins/example"
 )
 
 func main() {
 	var (
-		addr       = flag.String("addr", ":8080", "Server address to listen on")
-		kubeconfig = flag.String("kubeconfig", "", "Path to kubeconfig file (optional)")
+		addr         = flag.String("addr", ":8080", "Server address to listen on")
+		kubeconfig   = flag.String("kubeconfig", "", "Path to kubeconfig file (optional)")
+		drainTimeout = flag.Duration("drain-timeout", kubernetes.DefaultDrainTimeout, "Time to wait for in-flight requests during shutdown")
+		kubectlPath  = flag.String("kubectl-path", "kubectl", "Path to the kubectl binary used by exec_kubectl")
+		kmsEndpoint  = flag.String("kms-endpoint", os.Getenv("KMS_ENDPOINT"), "KMS endpoint used by seal_secret and unseal_secret (defaults to kms_endpoint from --llm-config)")
+		example      = flag.Bool("example-plugin", false, "Register the sample example_cluster_version plugin tool")
+		prometheus   = flag.String("prometheus-endpoint", os.Getenv("PROMETHEUS_ENDPOINT"), "Default Prometheus URL used by query_prometheus and list_prometheus_metrics")
+		scaleDown    = flag.Duration("auto-scale-down", 0, "Scale to zero deployments whose pods stay idle (under 5m CPU) for this long (0 disables; needs metrics-server)")
+		scaleDownSel = flag.String("auto-scale-down-selector", "", "Label selector naming the deployments --auto-scale-down may scale (required with it; kube- namespaces are never scaled)")
+		compression  = flag.Int("compression-threshold", mcp.DefaultCompressionThreshold, "Resource size in bytes above which content is gzip-compressed (0 disables)")
+		sampleEvery  = flag.Duration("sample-interval", kubernetes.DefaultSampleInterval, "Spacing of metrics-server readings used by get_resource_trend when no Prometheus endpoint is configured")
+		llmConfig    = flag.String("llm-config", "", "Path to an LLM config file; enables explain_error and sets the health_score_namespace weights and KMS endpoint")
 	)
 	flag.Parse()
 
@@ -20,9 +37,64 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to create server: %v", err)
 	}
+	server.SetDrainTimeout(*drainTimeout)
+	server.SetKubectlPath(*kubectlPath)
+	server.SetPrometheusEndpoint(*prometheus)
+	server.SetCompressionThreshold(*compression)
+	server.SetSampleInterval(*sampleEvery)
+	if *llmConfig != "" {
+		cfg, err := config.LoadLLMConfig(*llmConfig)
+		if err != nil {
+			log.Fatalf("Failed to load LLM config: %v", err)
+		}
+		provider, err := cfg.CreateLLMProvider()
+		if err != nil {
+			log.Fatalf("Failed to create LLM provider: %v", err)
+		}
+		server.SetLLMProvider(provider)
+		weights := cfg.HealthScoreWeights
+		server.SetHealthScoreWeights(kubernetes.HealthScoreWeights{
+			Pods:        weights.Pods,
+			Deployments: weights.Deployments,
+			Events:      weights.Events,
+			Quota:       weights.Quota,
+		})
+		if *kmsEndpoint == "" {
+			*kmsEndpoint = cfg.KMSEndpoint
+		}
+	}
+	server.SetKMSEndpoint(*kmsEndpoint)
+	if *example {
+		if err := server.RegisterPlugin(exampleplugin.NewClusterVersionPlugin(server.Discovery())); err != nil {
+			log.Fatalf("Failed to register plugin: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if *scaleDown > 0 {
+		if err := kubernetes.ValidateAutoScaleDownSelector(*scaleDownSel); err != nil {
+			log.Fatalf("Invalid --auto-scale-down-selector: %v", err)
+		}
+		go server.RunAutoScaleDown(ctx, *scaleDown, *scaleDownSel)
+	}
+
+	// Drain in-flight requests on SIGTERM or SIGINT
+	stopped := make(chan struct{})
+	go func() {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
+		sig := <-sigCh
+		fmt.Printf("Received %s, shutting down\n", sig)
+		cancel()
+		if err := server.Stop(); err != nil {
+			log.Printf("Shutdown error: %v", err)
+		}
+		close(stopped)
+	}()
 
 	fmt.Printf("Starting Kubernetes MCP server on %s\n", *addr)
 	if err := server.Start(*addr); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
+	<-stopped
 }
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/mcp-servers/cli/internal/auth"
+	"github.com/mcp-servers/cli/internal/config"
+)
+
+// MCPClientAuth supplies the Authorization header sent with every request to the server
+type MCPClientAuth interface {
+	GetAuthHeader() (string, error)
+}
+
+// refreshableAuth is implemented by authenticators whose credentials can be renewed after the server rejects them
+type refreshableAuth interface {
+	Invalidate()
+}
+
+type bearerTokenAuth struct {
+	token string
+}
+
+// BearerTokenAuth authenticates with a static bearer token
+func BearerTokenAuth(token string) MCPClientAuth {
+	return bearerTokenAuth{token: token}
+}
+
+func (a bearerTokenAuth) GetAuthHeader() (string, error) {
+	return "Bearer " + a.token, nil
+}
+
+type basicAuth struct {
+	user     string
+	password string
+}
+
+// BasicAuth authenticates with HTTP basic authentication
+func BasicAuth(user, password string) MCPClientAuth {
+	return basicAuth{user: user, password: password}
+}
+
+func (a basicAuth) GetAuthHeader() (string, error) {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(a.user+":"+a.password)), nil
+}
+
+type oidcAuth struct {
+	credentials *auth.ClientCredentials
+}
+
+// OIDCAuth authenticates with access tokens from issuer's client credentials grant.
+// Tokens are cached until they expire and requested again when the server answers 401.
+func OIDCAuth(issuer, clientID, clientSecret string) MCPClientAuth {
+	return &oidcAuth{credentials: auth.NewClientCredentials(issuer, clientID, clientSecret)}
+}
+
+func (a *oidcAuth) GetAuthHeader() (string, error) {
+	token, err := a.credentials.Token(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("failed to get OIDC token: %w", err)
+	}
+	return "Bearer " + token, nil
+}
+
+func (a *oidcAuth) Invalidate() {
+	a.credentials.Invalidate()
+}
+
+// NewMCPClientWithAuth creates an MCP client that authenticates every request with auth
+func NewMCPClientWithAuth(serverURL string, auth MCPClientAuth) *MCPClient {
+	client := NewMCPClient(serverURL)
+	client.auth = auth
+	return client
+}
+
+// authFromConfig builds the authenticator for an auth configuration; it returns nil when no authentication is configured
+func authFromConfig(cfg config.AuthConfig) (MCPClientAuth, error) {
+	switch cfg.Type {
+	case "", "none":
+		return nil, nil
+	case "basic":
+		if cfg.Username == "" {
+			return nil, fmt.Errorf("basic authentication requires a username")
+		}
+		return BasicAuth(cfg.Username, cfg.Password), nil
+	case "token", "oauth2":
+		if cfg.Token == "" {
+			return nil, fmt.Errorf("%s authentication requires a token", cfg.Type)
+		}
+		return BearerTokenAuth(cfg.Token), nil
+	case "oidc":
+		if cfg.Issuer == "" || cfg.ClientID == "" || cfg.ClientSecret == "" {
+			return nil, fmt.Errorf("oidc authentication requires an issuer, client ID and client secret")
+		}
+		return OIDCAuth(cfg.Issuer, cfg.ClientID, cfg.ClientSecret), nil
+	default:
+		return nil, fmt.Errorf("unsupported auth type %q: use none, basic, token, oauth2 or oidc", cfg.Type)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BatchApply applies each manifest file in a local directory with a separate apply_manifest call, reporting progress per file
+func (c *MCPClient) BatchApply(dir, namespace string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				files = append(files, entry.Name())
+			}
+		}
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no .yaml, .yml or .json files in %s", dir)
+	}
+
+	printer.Print("🤖", "[AI]", "AI Agent: Applying %d manifest file(s) from %s...\n", len(files), dir)
+
+	failed := 0
+	for i, file := range files {
+		progress := fmt.Sprintf("[%d/%d] %s", i+1, len(files), file)
+		if err := c.applyManifestFile(filepath.Join(dir, file), namespace); err != nil {
+			failed++
+			printer.Print("❌", "[ERR]", "%s: %v\n", progress, err)
+			continue
+		}
+		printer.Print("✅", "[OK]", "%s\n", progress)
+	}
+
+	fmt.Printf("\nTotal: %d, succeeded: %d, failed: %d\n", len(files), len(files)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d of %d manifest file(s) failed to apply", failed, len(files))
+	}
+	return nil
+}
+
+// applyManifestFile uploads one manifest file's content through the apply_manifest tool
+func (c *MCPClient) applyManifestFile(path, namespace string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	args := map[string]interface{}{"manifest": string(data)}
+	if namespace != "" {
+		args["namespace"] = namespace
+	}
+	_, err = c.invokeTool("apply_manifest", args)
+	return err
+}
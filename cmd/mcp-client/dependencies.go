@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// dependencyGraph mirrors the get_resource_dependencies tool output
+type dependencyGraph struct {
+	Nodes []struct {
+		Kind      string `json:"kind"`
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"nodes"`
+	Edges []struct {
+		From string `json:"from"`
+		To   string `json:"to"`
+		Type string `json:"type"`
+	} `json:"edges"`
+}
+
+// ShowDependencies prints the resources related to a deployment or pod as an ASCII tree
+func (c *MCPClient) ShowDependencies(resourceType, name, namespace string) error {
+	var graph dependencyGraph
+	err := c.callToolJSON("get_resource_dependencies", map[string]interface{}{
+		"resource_type": resourceType,
+		"name":          name,
+		"namespace":     namespace,
+	}, &graph)
+	if err != nil {
+		return err
+	}
+	if len(graph.Nodes) == 0 {
+		return fmt.Errorf("no resources found")
+	}
+
+	fmt.Print(renderDependencyTree(&graph))
+	return nil
+}
+
+// renderDependencyTree renders the graph as a tree rooted at its first node
+func renderDependencyTree(graph *dependencyGraph) string {
+	type child struct {
+		id       string
+		edgeType string
+	}
+	children := make(map[string][]child)
+	for _, edge := range graph.Edges {
+		children[edge.From] = append(children[edge.From], child{id: edge.To, edgeType: edge.Type})
+	}
+
+	root := graph.Nodes[0].Kind + "/" + graph.Nodes[0].Name
+	var b strings.Builder
+	b.WriteString(root + "\n")
+
+	visited := map[string]bool{root: true}
+	var walk func(id, prefix string)
+	walk = func(id, prefix string) {
+		for i, c := range children[id] {
+			branch, indent := "├── ", "│   "
+			if i == len(children[id])-1 {
+				branch, indent = "└── ", "    "
+			}
+			fmt.Fprintf(&b, "%s%s%s (%s)\n", prefix, branch, c.id, c.edgeType)
+			if !visited[c.id] {
+				visited[c.id] = true
+				walk(c.id, prefix+indent)
+			}
+		}
+	}
+	walk(root, "")
+
+	return b.String()
+}
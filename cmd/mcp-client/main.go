@@ -1,33 +1,82 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
+	"github.com/mcp-servers/cli/internal/config"
 	"github.com/mcp-servers/cli/pkg/mcp"
+	"github.com/mcp-servers/cli/pkg/output"
 )
 
+// printer writes status output, honouring --no-emoji
+var printer = output.NewPrinter(false)
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: mcp-client <server-url> [command]")
-		fmt.Println("Commands:")
-		fmt.Println("  list-pods                    - List all pods")
-		fmt.Println("  list-services                - List all services")
-		fmt.Println("  list-deployments             - List all deployments")
-		fmt.Println("  create-deployment <name> <image> - Create a deployment")
-		fmt.Println("  scale-deployment <name> <replicas> - Scale a deployment")
-		fmt.Println("  delete-pod <name>            - Delete a pod")
-		fmt.Println("  natural-language <query>     - Natural language query")
-		os.Exit(1)
+	wait := flag.Duration("wait", 60*time.Second, "How long to wait for a scaled deployment to become available")
+	noEmoji := flag.Bool("no-emoji", false, "Print plain ASCII prefixes instead of emoji")
+	flag.StringVar(&resultOutput.path, "output-file", "", "Write tool result content to this file instead of stdout ('-' for stdout)")
+	flag.StringVar(&resultOutput.path, "out", "", "Shorthand for --output-file")
+	flag.BoolVar(&resultOutput.overwrite, "yes", false, "Overwrite an existing output file without asking")
+	authConfig := config.AuthConfig{
+		Token:        os.Getenv("MCP_AUTH_TOKEN"),
+		Password:     os.Getenv("MCP_AUTH_PASSWORD"),
+		ClientSecret: os.Getenv("MCP_AUTH_CLIENT_SECRET"),
+	}
+	flag.StringVar(&authConfig.Type, "auth", "none", "Authentication: none, basic, token or oidc (secrets are read from MCP_AUTH_TOKEN, MCP_AUTH_PASSWORD and MCP_AUTH_CLIENT_SECRET)")
+	flag.StringVar(&authConfig.Username, "username", "", "Username for basic authentication")
+	flag.StringVar(&authConfig.Issuer, "oidc-issuer", "", "OIDC issuer URL for oidc authentication")
+	flag.StringVar(&authConfig.ClientID, "oidc-client-id", "", "OIDC client ID for oidc authentication")
+	initRetries := flag.Int("init-retry-count", defaultInitRetries, "How many times to retry the initialization handshake after a transient failure")
+	initRetryDelay := flag.Duration("init-retry-delay", defaultInitRetryDelay, "How long to wait between initialization retries")
+	profile := flag.String("profile", "", "Connect to the mcp_server_url of this profile instead of taking <server-url> as the first argument")
+	flag.Usage = printUsage
+	flag.Parse()
+	printer = output.NewPrinter(*noEmoji)
+
+	positional := flag.Args()
+	var serverURL string
+	if *profile != "" {
+		selected, err := config.LoadProfile(*profile)
+		if err != nil {
+			fmt.Printf("Failed to load profile: %v\n", err)
+			os.Exit(1)
+		}
+		if selected.MCPServerURL == "" {
+			fmt.Printf("Profile %s has no mcp_server_url\n", *profile)
+			os.Exit(1)
+		}
+		serverURL = selected.MCPServerURL
+	} else {
+		if len(positional) < 1 {
+			printUsage()
+			os.Exit(1)
+		}
+		serverURL, positional = positional[0], positional[1:]
 	}
 
-	serverURL := os.Args[1]
-	client := NewMCPClient(serverURL)
+	clientAuth, err := authFromConfig(authConfig)
+	if err != nil {
+		fmt.Printf("Invalid authentication settings: %v\n", err)
+		os.Exit(1)
+	}
+	client := NewMCPClientWithAuth(serverURL, clientAuth)
+	client.SetInitRetry(*initRetries, *initRetryDelay)
 
 	// Initialize connection
 	if err := client.Initialize(); err != nil {
@@ -35,82 +84,268 @@ func main() {
 		os.Exit(1)
 	}
 
-	if len(os.Args) < 3 {
+	if len(positional) < 1 {
 		fmt.Println("No command specified. Use 'help' for available commands.")
 		os.Exit(1)
 	}
 
-	command := os.Args[2]
-	args := os.Args[3:]
+	command := positional[0]
+	args := positional[1:]
+
+	if command == "interactive" {
+		runInteractive(client, *wait)
+		return
+	}
+	if command == "events" {
+		watchEvents(client)
+		return
+	}
+
+	if err := runCommand(client, command, args, *wait); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
 
+// runCommand dispatches a single client command
+func runCommand(client *MCPClient, command string, args []string, wait time.Duration) error {
 	switch command {
+	case "version-check":
+		fmt.Printf("Client protocol version: %s\n", mcp.ProtocolVersion)
+		fmt.Printf("Server protocol version: %s\n", client.GetNegotiatedVersion())
+		return nil
 	case "list-pods":
-		if err := client.ListPods(); err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
+		return client.ListPods()
 	case "list-services":
-		if err := client.ListServices(); err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
+		return client.ListServices()
 	case "list-deployments":
-		if err := client.ListDeployments(); err != nil {
-			fmt.Printf("Error: %v\n", err)
-		}
+		return client.ListDeployments()
 	case "create-deployment":
 		if len(args) < 2 {
-			fmt.Println("Usage: create-deployment <name> <image>")
-			os.Exit(1)
-		}
-		if err := client.CreateDeployment(args[0], args[1]); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			return fmt.Errorf("usage: create-deployment <name> <image>")
 		}
+		return client.CreateDeployment(args[0], args[1])
 	case "scale-deployment":
 		if len(args) < 2 {
-			fmt.Println("Usage: scale-deployment <name> <replicas>")
-			os.Exit(1)
-		}
-		if err := client.ScaleDeployment(args[0], args[1]); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			return fmt.Errorf("usage: scale-deployment <name> <replicas>")
 		}
+		return client.ScaleDeployment(args[0], args[1], wait)
 	case "delete-pod":
 		if len(args) < 1 {
-			fmt.Println("Usage: delete-pod <name>")
-			os.Exit(1)
-		}
-		if err := client.DeletePod(args[0]); err != nil {
-			fmt.Printf("Error: %v\n", err)
+			return fmt.Errorf("usage: delete-pod <name>")
 		}
+		return client.DeletePod(args[0])
 	case "natural-language":
 		if len(args) < 1 {
-			fmt.Println("Usage: natural-language <query>")
-			os.Exit(1)
+			return fmt.Errorf("usage: natural-language <query>")
+		}
+		return client.NaturalLanguageQuery(strings.Join(args, " "), wait)
+	case "call-tool":
+		if len(args) < 1 {
+			return fmt.Errorf("usage: call-tool <name> [json-arguments]")
+		}
+		return client.CallTool(args[0], strings.Join(args[1:], " "))
+	case "batch-apply":
+		if len(args) < 1 {
+			return fmt.Errorf("usage: batch-apply <directory> [namespace]")
+		}
+		namespace := ""
+		if len(args) > 1 {
+			namespace = args[1]
 		}
-		query := strings.Join(args, " ")
-		if err := client.NaturalLanguageQuery(query); err != nil {
-			fmt.Printf("Error: %v\n", err)
+		return client.BatchApply(args[0], namespace)
+	case "dependencies":
+		if len(args) < 2 {
+			return fmt.Errorf("usage: dependencies <deployment|pod> <name> [namespace]")
+		}
+		namespace := "default"
+		if len(args) > 2 {
+			namespace = args[2]
+		}
+		return client.ShowDependencies(args[0], args[1], namespace)
+	case "bench":
+		requests, concurrency := 200, 10
+		var err error
+		if len(args) > 0 {
+			if requests, err = strconv.Atoi(args[0]); err != nil {
+				return fmt.Errorf("invalid request count: %s", args[0])
+			}
 		}
+		if len(args) > 1 {
+			if concurrency, err = strconv.Atoi(args[1]); err != nil {
+				return fmt.Errorf("invalid concurrency: %s", args[1])
+			}
+		}
+		return runBenchmark(client, requests, concurrency)
 	default:
-		fmt.Printf("Unknown command: %s\n", command)
-		os.Exit(1)
+		return fmt.Errorf("unknown command: %s", command)
+	}
+}
+
+// runInteractive reads commands from stdin until exit
+func runInteractive(client *MCPClient, wait time.Duration) {
+	printer.Print("🚀", "[START]", "Interactive Mode - Type 'help' for commands, 'exit' to quit\n")
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("mcp> ")
+		if !scanner.Scan() {
+			break
+		}
+
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "exit", "quit":
+			printer.Print("👋", "[BYE]", "Goodbye!\n")
+			return
+		case "help":
+			printUsage()
+		case "stats":
+			printStats(client.GetStats())
+		case "reset-stats":
+			client.ResetStats()
+			printer.Print("🧹", "[CLEAR]", "Stats reset\n")
+		default:
+			if err := runCommand(client, fields[0], fields[1:], wait); err != nil {
+				printer.Print("❌", "[ERR]", "Error: %v\n", err)
+			}
+		}
+		fmt.Println()
 	}
 }
 
+// watchEvents prints messages from the server's event stream until interrupted
+func watchEvents(client *MCPClient) {
+	printer.Print("📡", "[EVENTS]", "Listening for events from %s (Ctrl-C to stop)\n", client.serverURL)
+
+	subscription := NewMCPClientSSE(client, func(msg *mcp.Message) {
+		fmt.Printf("%s %s %s\n", msg.Timestamp.Format(time.RFC3339), msg.Type, string(msg.Data))
+	})
+	defer subscription.Close()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	<-interrupt
+}
+
+// printStats prints the client counters as a table
+func printStats(stats Stats) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COUNTER\tVALUE\t")
+	fmt.Fprintln(w, "-------\t-----\t")
+	fmt.Fprintf(w, "Total requests\t%d\t\n", stats.TotalRequests)
+	fmt.Fprintf(w, "Total errors\t%d\t\n", stats.TotalErrors)
+	fmt.Fprintf(w, "Bytes written\t%d\t\n", stats.TotalBytesWritten)
+	fmt.Fprintf(w, "Bytes read\t%d\t\n", stats.TotalBytesRead)
+	w.Flush()
+}
+
+// printUsage prints the command line usage
+func printUsage() {
+	fmt.Println("Usage: mcp-client [flags] <server-url> [command]")
+	fmt.Println("       mcp-client [flags] --profile <name> [command]")
+	fmt.Println("Commands:")
+	fmt.Println("  list-pods                    - List all pods")
+	fmt.Println("  list-services                - List all services")
+	fmt.Println("  list-deployments             - List all deployments")
+	fmt.Println("  create-deployment <name> <image> - Create a deployment")
+	fmt.Println("  scale-deployment <name> <replicas> - Scale a deployment")
+	fmt.Println("  delete-pod <name>            - Delete a pod")
+	fmt.Println("  natural-language <query>     - Natural language query")
+	fmt.Println("  call-tool <name> [json-arguments] - Call any server tool, e.g. call-tool get_cluster_version")
+	fmt.Println("  batch-apply <directory> [namespace] - Apply each .yaml, .yml and .json file in a local directory")
+	fmt.Println("  dependencies <deployment|pod> <name> [namespace] - Show related resources as a tree")
+	fmt.Println("  bench [requests] [concurrency] - Compare ping throughput of default and pooled clients")
+	fmt.Println("  events                       - Print server events as they arrive until interrupted")
+	fmt.Println("  version-check                - Print the client and server MCP protocol versions")
+	fmt.Println("  interactive                  - Read commands from stdin (adds 'stats' and 'reset-stats')")
+	fmt.Println("Flags:")
+	flag.PrintDefaults()
+}
+
 // MCPClient represents an MCP client
 type MCPClient struct {
 	serverURL string
 	client    *http.Client
+	auth      MCPClientAuth
+	counters  clientCounters
+
+	negotiatedVersion string
+	initRetries       int
+	initRetryDelay    time.Duration
+}
+
+// Initialization retry defaults, overridden with --init-retry-count and --init-retry-delay
+const (
+	defaultInitRetries    = 3
+	defaultInitRetryDelay = time.Second
+)
+
+// errServiceUnavailable marks a 503 response, which is worth retrying
+var errServiceUnavailable = errors.New("server unavailable")
+
+// clientCounters holds the live request counters, updated atomically by sendMessage
+type clientCounters struct {
+	totalRequests     atomic.Int64
+	totalErrors       atomic.Int64
+	totalBytesRead    atomic.Int64
+	totalBytesWritten atomic.Int64
+}
+
+// Stats is a point-in-time snapshot of the client counters
+type Stats struct {
+	TotalRequests     int64
+	TotalErrors       int64
+	TotalBytesRead    int64
+	TotalBytesWritten int64
 }
 
 // NewMCPClient creates a new MCP client
 func NewMCPClient(serverURL string) *MCPClient {
 	return &MCPClient{
-		serverURL: serverURL,
-		client:    &http.Client{},
+		serverURL:      serverURL,
+		client:         &http.Client{},
+		initRetries:    defaultInitRetries,
+		initRetryDelay: defaultInitRetryDelay,
 	}
 }
 
-// Initialize initializes the connection to the MCP server
+// SetInitRetry sets how many times Initialize retries after a transient failure and how long it waits in between
+func (c *MCPClient) SetInitRetry(retries int, delay time.Duration) {
+	c.initRetries = max(retries, 0)
+	c.initRetryDelay = delay
+}
+
+// Initialize initializes the connection to the MCP server, retrying transient failures such as
+// refused connections and 503 responses
 func (c *MCPClient) Initialize() error {
+	err := c.initialize()
+	for attempt := 1; attempt <= c.initRetries && isTransient(err); attempt++ {
+		printer.Print("⚠️", "[WARN]", "Initialization failed (%v); retrying in %s (%d/%d)\n", err, c.initRetryDelay, attempt, c.initRetries)
+		time.Sleep(c.initRetryDelay)
+		err = c.initialize()
+	}
+	return err
+}
+
+// isTransient reports whether an error is a connection failure or server unavailability that may clear up on retry
+func isTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, errServiceUnavailable) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// initialize performs a single initialization handshake
+func (c *MCPClient) initialize() error {
 	req := mcp.InitializeRequest{
 		ProtocolVersion: mcp.ProtocolVersion,
 		Capabilities: mcp.ClientCapabilities{
@@ -128,13 +363,41 @@ func (c *MCPClient) Initialize() error {
 		return err
 	}
 
-	_, err = c.sendMessage(msg)
-	return err
+	response, err := c.sendMessage(msg)
+	if err != nil {
+		return err
+	}
+	if response.Type == mcp.MessageTypeError {
+		var mcpErr mcp.Error
+		if err := response.UnmarshalData(&mcpErr); err != nil {
+			return err
+		}
+		return fmt.Errorf("%s", mcpErr.Message)
+	}
+
+	var init mcp.InitializationResponse
+	if err := response.UnmarshalData(&init); err != nil {
+		return fmt.Errorf("invalid initialization response: %w", err)
+	}
+	warning, err := mcp.CheckProtocolVersion(init.ProtocolVersion)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		printer.Print("⚠️", "[WARN]", "%s\n", warning)
+	}
+	c.negotiatedVersion = init.ProtocolVersion
+	return nil
+}
+
+// GetNegotiatedVersion returns the protocol version the server reported during Initialize
+func (c *MCPClient) GetNegotiatedVersion() string {
+	return c.negotiatedVersion
 }
 
 // ListPods lists all pods in the cluster
 func (c *MCPClient) ListPods() error {
-	fmt.Println("🤖 AI Agent: I'll get the list of pods for you...")
+	printer.Print("🤖", "[AI]", "AI Agent: I'll get the list of pods for you...\n")
 
 	// First, list available resources
 	msg, err := mcp.NewMessage(mcp.MessageTypeListResources, "list-resources-1", nil)
@@ -169,11 +432,11 @@ func (c *MCPClient) ListPods() error {
 		return err
 	}
 
-	fmt.Println("✅ Here are the pods in your cluster:")
+	printer.Print("✅", "[OK]", "Here are the pods in your cluster:\n")
 	if pods, ok := podsData["pods"].([]interface{}); ok {
 		for _, pod := range pods {
 			if podMap, ok := pod.(map[string]interface{}); ok {
-				fmt.Printf("  📦 %s/%s (%s) - Age: %s\n",
+				fmt.Printf("  %s %s/%s (%s) - Age: %s\n", printer.Prefix("📦", "[POD]"),
 					podMap["namespace"], podMap["name"], podMap["status"], podMap["age"])
 			}
 		}
@@ -184,7 +447,7 @@ func (c *MCPClient) ListPods() error {
 
 // ListServices lists all services in the cluster
 func (c *MCPClient) ListServices() error {
-	fmt.Println("🤖 AI Agent: I'll get the list of services for you...")
+	printer.Print("🤖", "[AI]", "AI Agent: I'll get the list of services for you...\n")
 
 	readReq := map[string]string{"uri": "kubernetes://services"}
 	readMsg, err := mcp.NewMessage(mcp.MessageTypeReadResource, "read-services-1", readReq)
@@ -207,11 +470,11 @@ func (c *MCPClient) ListServices() error {
 		return err
 	}
 
-	fmt.Println("✅ Here are the services in your cluster:")
+	printer.Print("✅", "[OK]", "Here are the services in your cluster:\n")
 	if services, ok := servicesData["services"].([]interface{}); ok {
 		for _, service := range services {
 			if serviceMap, ok := service.(map[string]interface{}); ok {
-				fmt.Printf("  🔗 %s/%s (%s) - IP: %s\n",
+				fmt.Printf("  %s %s/%s (%s) - IP: %s\n", printer.Prefix("🔗", "[SVC]"),
 					serviceMap["namespace"], serviceMap["name"], serviceMap["type"], serviceMap["clusterIP"])
 			}
 		}
@@ -222,7 +485,7 @@ func (c *MCPClient) ListServices() error {
 
 // ListDeployments lists all deployments in the cluster
 func (c *MCPClient) ListDeployments() error {
-	fmt.Println("🤖 AI Agent: I'll get the list of deployments for you...")
+	printer.Print("🤖", "[AI]", "AI Agent: I'll get the list of deployments for you...\n")
 
 	readReq := map[string]string{"uri": "kubernetes://deployments"}
 	readMsg, err := mcp.NewMessage(mcp.MessageTypeReadResource, "read-deployments-1", readReq)
@@ -245,11 +508,11 @@ func (c *MCPClient) ListDeployments() error {
 		return err
 	}
 
-	fmt.Println("✅ Here are the deployments in your cluster:")
+	printer.Print("✅", "[OK]", "Here are the deployments in your cluster:\n")
 	if deployments, ok := deploymentsData["deployments"].([]interface{}); ok {
 		for _, deployment := range deployments {
 			if deploymentMap, ok := deployment.(map[string]interface{}); ok {
-				fmt.Printf("  🚀 %s/%s - Replicas: %v/%v\n",
+				fmt.Printf("  %s %s/%s - Replicas: %v/%v\n", printer.Prefix("🚀", "[DEPLOY]"),
 					deploymentMap["namespace"], deploymentMap["name"],
 					deploymentMap["available"], deploymentMap["replicas"])
 			}
@@ -261,7 +524,7 @@ func (c *MCPClient) ListDeployments() error {
 
 // CreateDeployment creates a new deployment
 func (c *MCPClient) CreateDeployment(name, image string) error {
-	fmt.Printf("🤖 AI Agent: I'll create a deployment named '%s' with image '%s'...\n", name, image)
+	printer.Print("🤖", "[AI]", "AI Agent: I'll create a deployment named '%s' with image '%s'...\n", name, image)
 
 	// First, list available tools
 	msg, err := mcp.NewMessage(mcp.MessageTypeListTools, "list-tools-1", nil)
@@ -299,26 +562,51 @@ func (c *MCPClient) CreateDeployment(name, image string) error {
 	if err := callResp.UnmarshalData(&result); err != nil {
 		return err
 	}
+	if err := result.Err(); err != nil {
+		return err
+	}
 
-	for _, content := range result.Content {
-		if content.Type == "text" {
-			fmt.Printf("✅ %s\n", content.Text)
-		}
+	if err := emitToolResult(&result); err != nil {
+		return err
 	}
 
 	return nil
 }
 
-// ScaleDeployment scales a deployment
-func (c *MCPClient) ScaleDeployment(name, replicas string) error {
-	fmt.Printf("🤖 AI Agent: I'll scale deployment '%s' to %s replicas...\n", name, replicas)
+// deploymentState holds the replica counts reported by the get_deployment tool
+type deploymentState struct {
+	Replicas  int32 `json:"replicas"`
+	Available int32 `json:"available"`
+	Ready     int32 `json:"ready"`
+}
+
+// ScaleDeployment scales a deployment, showing a before/after table and waiting for the target to be reached
+func (c *MCPClient) ScaleDeployment(name, replicas string, wait time.Duration) error {
+	printer.Print("🤖", "[AI]", "AI Agent: I'll scale deployment '%s' to %s replicas...\n", name, replicas)
+
+	target, err := strconv.Atoi(replicas)
+	if err != nil || target < 0 {
+		return fmt.Errorf("invalid replica count: %s", replicas)
+	}
+
+	before, err := c.getDeploymentState(name, "default")
+	if err != nil {
+		return fmt.Errorf("failed to get current state: %w", err)
+	}
+
+	targetReplicas := int32(target)
+	printScaleTable(before, &deploymentState{
+		Replicas:  targetReplicas,
+		Available: targetReplicas,
+		Ready:     targetReplicas,
+	})
 
 	toolCall := mcp.ToolCall{
 		Name: "scale_deployment",
 		Arguments: map[string]interface{}{
 			"name":      name,
 			"namespace": "default",
-			"replicas":  replicas,
+			"replicas":  target,
 		},
 	}
 
@@ -336,19 +624,119 @@ func (c *MCPClient) ScaleDeployment(name, replicas string) error {
 	if err := callResp.UnmarshalData(&result); err != nil {
 		return err
 	}
+	if err := result.Err(); err != nil {
+		return err
+	}
+
+	if err := emitToolResult(&result); err != nil {
+		return err
+	}
+
+	after, err := c.waitForScale(name, "default", targetReplicas, wait)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Final state:")
+	printScaleTable(before, after)
+	if after.Available != targetReplicas {
+		printer.Print("⚠️", "[WARN]", "Deployment did not reach %d available replicas within %s\n", targetReplicas, wait)
+	}
+
+	return nil
+}
+
+// getDeploymentState fetches the replica counts of a deployment via the get_deployment tool
+func (c *MCPClient) getDeploymentState(name, namespace string) (*deploymentState, error) {
+	var state deploymentState
+	err := c.callToolJSON("get_deployment", map[string]interface{}{
+		"name":      name,
+		"namespace": namespace,
+	}, &state)
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// callToolJSON calls a tool whose first text content is JSON and decodes it into out
+func (c *MCPClient) callToolJSON(name string, args map[string]interface{}, out interface{}) error {
+	toolCall := mcp.ToolCall{
+		Name:      name,
+		Arguments: args,
+	}
+
+	callMsg, err := mcp.NewMessage(mcp.MessageTypeCallTool, name+"-1", toolCall)
+	if err != nil {
+		return err
+	}
 
-	for _, content := range result.Content {
-		if content.Type == "text" {
-			fmt.Printf("✅ %s\n", content.Text)
+	callResp, err := c.sendMessage(callMsg)
+	if err != nil {
+		return err
+	}
+	if callResp.Type == mcp.MessageTypeError {
+		var mcpErr mcp.Error
+		if err := callResp.UnmarshalData(&mcpErr); err != nil {
+			return err
 		}
+		return fmt.Errorf("%s", mcpErr.Message)
+	}
+
+	var result mcp.ToolResult
+	if err := callResp.UnmarshalData(&result); err != nil {
+		return err
+	}
+	if err := result.Err(); err != nil {
+		return err
+	}
+	if len(result.Content) == 0 {
+		return fmt.Errorf("empty response from %s", name)
+	}
+
+	if err := json.Unmarshal([]byte(result.Content[0].Text), out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", name, err)
 	}
 
 	return nil
 }
 
+// waitForScale polls a deployment until its available replicas match the target or the timeout expires
+func (c *MCPClient) waitForScale(name, namespace string, target int32, timeout time.Duration) (*deploymentState, error) {
+	start := time.Now()
+	deadline := start.Add(timeout)
+
+	for {
+		state, err := c.getDeploymentState(name, namespace)
+		if err != nil {
+			fmt.Println()
+			return nil, err
+		}
+
+		fmt.Printf("\r⏳ Waiting for rollout: %d/%d available, %d ready (%s elapsed)   ",
+			state.Available, target, state.Ready, time.Since(start).Round(time.Second))
+
+		if state.Available == target || time.Now().After(deadline) {
+			fmt.Println()
+			return state, nil
+		}
+
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// printScaleTable prints a before/after comparison of deployment replica counts
+func printScaleTable(before, after *deploymentState) {
+	fmt.Println("| Field     | Before | After |")
+	fmt.Println("|-----------|--------|-------|")
+	fmt.Printf("| replicas  | %6d | %5d |\n", before.Replicas, after.Replicas)
+	fmt.Printf("| available | %6d | %5d |\n", before.Available, after.Available)
+	fmt.Printf("| ready     | %6d | %5d |\n", before.Ready, after.Ready)
+}
+
 // DeletePod deletes a pod
 func (c *MCPClient) DeletePod(name string) error {
-	fmt.Printf("🤖 AI Agent: I'll delete pod '%s'...\n", name)
+	printer.Print("🤖", "[AI]", "AI Agent: I'll delete pod '%s'...\n", name)
 
 	toolCall := mcp.ToolCall{
 		Name: "delete_pod",
@@ -372,19 +760,82 @@ func (c *MCPClient) DeletePod(name string) error {
 	if err := callResp.UnmarshalData(&result); err != nil {
 		return err
 	}
+	if err := result.Err(); err != nil {
+		return err
+	}
 
-	for _, content := range result.Content {
-		if content.Type == "text" {
-			fmt.Printf("✅ %s\n", content.Text)
-		}
+	if err := emitToolResult(&result); err != nil {
+		return err
 	}
 
 	return nil
 }
 
+// CallTool calls a tool by name with JSON-encoded arguments and emits its result
+func (c *MCPClient) CallTool(name, rawArgs string) error {
+	arguments := map[string]interface{}{}
+	if rawArgs != "" {
+		if err := json.Unmarshal([]byte(rawArgs), &arguments); err != nil {
+			return fmt.Errorf("invalid tool arguments: %w", err)
+		}
+	}
+	return c.callTool(name, arguments)
+}
+
+// callTool calls a tool and emits its result
+func (c *MCPClient) callTool(name string, arguments map[string]interface{}) error {
+	result, err := c.invokeTool(name, arguments)
+	if err != nil {
+		return err
+	}
+	return emitToolResult(result)
+}
+
+// invokeTool calls a tool and returns its result, turning protocol and tool errors into errors
+func (c *MCPClient) invokeTool(name string, arguments map[string]interface{}) (*mcp.ToolResult, error) {
+	callMsg, err := mcp.NewMessage(mcp.MessageTypeCallTool, "call-tool-1", mcp.ToolCall{Name: name, Arguments: arguments})
+	if err != nil {
+		return nil, err
+	}
+
+	callResp, err := c.sendMessage(callMsg)
+	if err != nil {
+		return nil, err
+	}
+	if callResp.Type == mcp.MessageTypeError {
+		var mcpErr mcp.Error
+		if err := callResp.UnmarshalData(&mcpErr); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s", mcpErr.Message)
+	}
+
+	var result mcp.ToolResult
+	if err := callResp.UnmarshalData(&result); err != nil {
+		return nil, err
+	}
+	if err := result.Err(); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// wordAfter returns the word following the first of keywords found in query, or "" if there is none
+func wordAfter(query string, keywords ...string) string {
+	parts := strings.Fields(query)
+	for _, keyword := range keywords {
+		for i, part := range parts {
+			if part == keyword && i+1 < len(parts) {
+				return parts[i+1]
+			}
+		}
+	}
+	return ""
+}
+
 // NaturalLanguageQuery handles natural language queries
-func (c *MCPClient) NaturalLanguageQuery(query string) error {
-	fmt.Printf("🤖 AI Agent: Processing your query: '%s'\n", query)
+func (c *MCPClient) NaturalLanguageQuery(query string, wait time.Duration) error {
+	printer.Print("🤖", "[AI]", "AI Agent: Processing your query: '%s'\n", query)
 
 	// Simple natural language processing
 	query = strings.ToLower(query)
@@ -409,7 +860,17 @@ func (c *MCPClient) NaturalLanguageQuery(query string) error {
 				return c.CreateDeployment(name, image)
 			}
 		}
-		fmt.Println("❌ Please specify deployment name and image")
+		printer.Print("❌", "[ERR]", "Please specify deployment name and image\n")
+	case strings.Contains(query, "wake up"):
+		if name := wordAfter(query, "deployment"); name != "" {
+			return c.callTool("wake_up_deployment", map[string]interface{}{"name": name, "namespace": "default"})
+		}
+		printer.Print("❌", "[ERR]", "Please specify the deployment to wake up\n")
+	case strings.Contains(query, "pause") || strings.Contains(query, "stop deployment") || strings.Contains(query, "scale to zero"):
+		if name := wordAfter(query, "deployment"); name != "" {
+			return c.callTool("scale_to_zero", map[string]interface{}{"name": name, "namespace": "default"})
+		}
+		printer.Print("❌", "[ERR]", "Please specify the deployment to pause\n")
 	case strings.Contains(query, "scale") && strings.Contains(query, "deployment"):
 		parts := strings.Fields(query)
 		for i, part := range parts {
@@ -419,10 +880,10 @@ func (c *MCPClient) NaturalLanguageQuery(query string) error {
 				if i+2 < len(parts) {
 					replicas = parts[i+2]
 				}
-				return c.ScaleDeployment(name, replicas)
+				return c.ScaleDeployment(name, replicas, wait)
 			}
 		}
-		fmt.Println("❌ Please specify deployment name and replicas")
+		printer.Print("❌", "[ERR]", "Please specify deployment name and replicas\n")
 	case strings.Contains(query, "delete") && strings.Contains(query, "pod"):
 		parts := strings.Fields(query)
 		for i, part := range parts {
@@ -430,34 +891,103 @@ func (c *MCPClient) NaturalLanguageQuery(query string) error {
 				return c.DeletePod(parts[i+1])
 			}
 		}
-		fmt.Println("❌ Please specify pod name to delete")
+		printer.Print("❌", "[ERR]", "Please specify pod name to delete\n")
 	default:
-		fmt.Println("❌ I don't understand that query. Try:")
+		printer.Print("❌", "[ERR]", "I don't understand that query. Try:\n")
 		fmt.Println("  - 'list pods'")
 		fmt.Println("  - 'list services'")
 		fmt.Println("  - 'list deployments'")
 		fmt.Println("  - 'create deployment myapp nginx:latest'")
 		fmt.Println("  - 'scale deployment myapp 5'")
 		fmt.Println("  - 'delete pod mypod'")
+		fmt.Println("  - 'pause deployment myapp'")
+		fmt.Println("  - 'wake up deployment myapp'")
 	}
 
 	return nil
 }
 
+// GetStats returns a snapshot of the client counters
+func (c *MCPClient) GetStats() Stats {
+	return Stats{
+		TotalRequests:     c.counters.totalRequests.Load(),
+		TotalErrors:       c.counters.totalErrors.Load(),
+		TotalBytesRead:    c.counters.totalBytesRead.Load(),
+		TotalBytesWritten: c.counters.totalBytesWritten.Load(),
+	}
+}
+
+// ResetStats zeroes the client counters
+func (c *MCPClient) ResetStats() {
+	c.counters.totalRequests.Store(0)
+	c.counters.totalErrors.Store(0)
+	c.counters.totalBytesRead.Store(0)
+	c.counters.totalBytesWritten.Store(0)
+}
+
 // sendMessage sends a message to the MCP server
 func (c *MCPClient) sendMessage(msg *mcp.Message) (*mcp.Message, error) {
+	response, err := c.doSendMessage(msg)
+	if err == nil && response.Type == mcp.MessageTypeReadResource {
+		err = decompressResource(response)
+	}
+	c.counters.totalRequests.Add(1)
+	if err != nil || response.Type == mcp.MessageTypeError {
+		c.counters.totalErrors.Add(1)
+	}
+	return response, err
+}
+
+// decompressResource replaces a gzip-encoded resource in a readResource response with its decompressed form
+func decompressResource(response *mcp.Message) error {
+	var resource mcp.Resource
+	if err := response.UnmarshalData(&resource); err != nil {
+		return err
+	}
+	if resource.Metadata[mcp.MetadataContentEncoding] == "" {
+		return nil
+	}
+	if err := resource.Decompress(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+	response.Data = data
+	return nil
+}
+
+// doSendMessage performs the HTTP round trip for sendMessage
+func (c *MCPClient) doSendMessage(msg *mcp.Message) (*mcp.Message, error) {
 	data, err := json.Marshal(msg)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.client.Post(c.serverURL+"/mcp", "application/json", bytes.NewBuffer(data))
+	resp, err := c.post(data)
 	if err != nil {
 		return nil, err
 	}
+	if refresher, ok := c.auth.(refreshableAuth); ok && resp.StatusCode == http.StatusUnauthorized {
+		// The token may have been revoked or expired early; retry once with a fresh one
+		resp.Body.Close()
+		refresher.Invalidate()
+		if resp, err = c.post(data); err != nil {
+			return nil, err
+		}
+	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("server rejected the credentials: %s", resp.Status)
+	}
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		return nil, fmt.Errorf("%w: %s", errServiceUnavailable, resp.Status)
+	}
 
 	body, err := io.ReadAll(resp.Body)
+	c.counters.totalBytesRead.Add(int64(len(body)))
 	if err != nil {
 		return nil, err
 	}
@@ -469,3 +999,35 @@ func (c *MCPClient) sendMessage(msg *mcp.Message) (*mcp.Message, error) {
 
 	return &response, nil
 }
+
+// post sends an encoded message to the server's /mcp endpoint with the client's Authorization header
+func (c *MCPClient) post(data []byte) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, c.serverURL+"/mcp", bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := c.authorize(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	c.counters.totalBytesWritten.Add(int64(len(data)))
+	return resp, nil
+}
+
+// authorize sets the client's Authorization header on req, if it has authentication configured
+func (c *MCPClient) authorize(req *http.Request) error {
+	if c.auth == nil {
+		return nil
+	}
+	header, err := c.auth.GetAuthHeader()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", header)
+	return nil
+}
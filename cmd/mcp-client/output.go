@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+)
+
+// resultOutput is where tool results are written, set from --output-file and --yes
+var resultOutput = &resultWriter{}
+
+// resultWriter saves tool result content to a file, or to stdout for "-"
+type resultWriter struct {
+	path      string
+	overwrite bool
+}
+
+// emitToolResult prints the text content of a tool result, or writes it to the output file when one is set
+func emitToolResult(result *mcp.ToolResult) error {
+	if resultOutput.path == "" {
+		for _, content := range result.Content {
+			if isTextContent(content.Type) {
+				printer.Print("✅", "[OK]", "%s\n", content.Text)
+			}
+		}
+		return nil
+	}
+	return resultOutput.write(result)
+}
+
+// write writes the raw result content, readable only by the user as results may hold secrets, asking before replacing an existing file unless overwrite is set
+func (w *resultWriter) write(result *mcp.ToolResult) error {
+	data, err := resultBytes(result)
+	if err != nil {
+		return err
+	}
+
+	if w.path == "-" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+
+	if _, err := os.Stat(w.path); err == nil && !w.overwrite {
+		if !confirmOverwrite(w.path) {
+			return fmt.Errorf("not overwriting %s (use --yes to skip this prompt)", w.path)
+		}
+	}
+
+	if err := os.WriteFile(w.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", w.path, err)
+	}
+	printer.Print("💾", "[SAVED]", "Wrote %d bytes to %s\n", len(data), w.path)
+	return nil
+}
+
+// resultBytes joins the content of a tool result: text as UTF-8, embedded base64 data as raw bytes
+func resultBytes(result *mcp.ToolResult) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, content := range result.Content {
+		switch {
+		case isTextContent(content.Type):
+			buf.WriteString(content.Text)
+			if !strings.HasSuffix(content.Text, "\n") {
+				buf.WriteByte('\n')
+			}
+		case content.Embedded != nil:
+			var encoded string
+			if err := json.Unmarshal(content.Embedded.Data, &encoded); err != nil {
+				// Structured data is written as the JSON it was sent as
+				buf.Write(content.Embedded.Data)
+				continue
+			}
+			raw, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s content: %w", content.Embedded.MimeType, err)
+			}
+			buf.Write(raw)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// isTextContent reports whether a content type is text, either plain "text" or a MIME type such as "text/x-yaml"
+func isTextContent(contentType string) bool {
+	return contentType == "text" || strings.HasPrefix(contentType, "text/")
+}
+
+// confirmOverwrite asks on stdin whether to replace an existing file
+func confirmOverwrite(path string) bool {
+	fmt.Printf("%s already exists. Overwrite? [y/N] ", path)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
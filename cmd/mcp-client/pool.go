@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+)
+
+// Default transport limits for pooled clients
+const (
+	defaultPoolMaxIdleConns        = 100
+	defaultPoolMaxIdleConnsPerHost = 100
+	defaultPoolIdleConnTimeout     = 90 * time.Second
+)
+
+// MCPClientPool hands out MCPClients that share one HTTP transport and the authentication of the client it was created from.
+// Idle clients are kept most recently used first; when the pool is full the least recently used client is dropped.
+type MCPClientPool struct {
+	serverURL string
+	auth      MCPClientAuth
+	maxSize   int
+	transport *http.Transport
+
+	mu   sync.Mutex
+	idle []*MCPClient
+}
+
+// NewMCPClientPool creates a pool keeping up to maxSize idle clients for base's server. The pooled clients use
+// base's authentication and a copy of its transport, so TLS and proxy settings carry over.
+func NewMCPClientPool(base *MCPClient, maxSize int) *MCPClientPool {
+	transport, ok := base.client.Transport.(*http.Transport)
+	if !ok {
+		transport = http.DefaultTransport.(*http.Transport)
+	}
+	transport = transport.Clone()
+	transport.MaxIdleConns = defaultPoolMaxIdleConns
+	transport.MaxIdleConnsPerHost = defaultPoolMaxIdleConnsPerHost
+	transport.IdleConnTimeout = defaultPoolIdleConnTimeout
+
+	return &MCPClientPool{
+		serverURL: base.serverURL,
+		auth:      base.auth,
+		maxSize:   maxSize,
+		transport: transport,
+	}
+}
+
+// SetIdleConnLimits configures the shared transport's idle connection limits
+func (p *MCPClientPool) SetIdleConnLimits(maxIdleConns, maxIdleConnsPerHost int, idleConnTimeout time.Duration) {
+	p.transport.MaxIdleConns = maxIdleConns
+	p.transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	p.transport.IdleConnTimeout = idleConnTimeout
+}
+
+// Get returns the most recently used idle client, or a new one sharing the pool's transport
+func (p *MCPClientPool) Get() *MCPClient {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n := len(p.idle); n > 0 {
+		client := p.idle[n-1]
+		p.idle = p.idle[:n-1]
+		return client
+	}
+
+	return &MCPClient{
+		serverURL: p.serverURL,
+		client:    &http.Client{Transport: p.transport},
+		auth:      p.auth,
+	}
+}
+
+// Put returns a client to the pool, evicting the least recently used client when full
+func (p *MCPClientPool) Put(client *MCPClient) {
+	if client == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.idle) >= p.maxSize {
+		p.idle = p.idle[1:]
+	}
+	p.idle = append(p.idle, client)
+}
+
+// Ping sends a ping message and waits for the pong
+func (c *MCPClient) Ping() error {
+	msg, err := mcp.NewMessage(mcp.MessageTypePing, "ping", nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.sendMessage(msg)
+	if err != nil {
+		return err
+	}
+	if response.Type != mcp.MessageTypePong {
+		return fmt.Errorf("unexpected response type: %s", response.Type)
+	}
+	return nil
+}
+
+// runBenchmark compares ping throughput of default clients against pooled clients
+func runBenchmark(base *MCPClient, requests, concurrency int) error {
+	if requests <= 0 || concurrency <= 0 {
+		return fmt.Errorf("requests and concurrency must be positive")
+	}
+
+	pool := NewMCPClientPool(base, concurrency)
+	strategies := []struct {
+		name    string
+		acquire func() *MCPClient
+		release func(*MCPClient)
+	}{
+		{"default client per request", func() *MCPClient { return NewMCPClientWithAuth(base.serverURL, base.auth) }, func(*MCPClient) {}},
+		{"pooled clients", pool.Get, pool.Put},
+	}
+
+	fmt.Printf("Benchmarking %d pings with concurrency %d\n", requests, concurrency)
+	for _, strategy := range strategies {
+		var remaining, failures atomic.Int64
+		remaining.Store(int64(requests))
+
+		start := time.Now()
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for remaining.Add(-1) >= 0 {
+					client := strategy.acquire()
+					if err := client.Ping(); err != nil {
+						failures.Add(1)
+					}
+					strategy.release(client)
+				}
+			}()
+		}
+		wg.Wait()
+		elapsed := time.Since(start)
+
+		fmt.Printf("  %-28s %8.1f req/s  (%s, %d errors)\n",
+			strategy.name, float64(requests)/elapsed.Seconds(), elapsed.Round(time.Millisecond), failures.Load())
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+)
+
+// sseReconnectDelay is how long the event subscriber waits before reconnecting after the stream ends
+const sseReconnectDelay = 2 * time.Second
+
+// sseSubscriber receives MCP messages from the server's /mcp/events stream
+type sseSubscriber struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewMCPClientSSE subscribes to the client's /mcp/events stream, calling handler for each message until closed.
+// The stream uses the client's HTTP client and authentication, and reconnects when it drops.
+func NewMCPClientSSE(client *MCPClient, handler func(*mcp.Message)) io.Closer {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &sseSubscriber{cancel: cancel, done: make(chan struct{})}
+
+	go func() {
+		defer close(s.done)
+		for {
+			if err := client.readEvents(ctx, handler); err != nil && ctx.Err() == nil {
+				printer.Print("⚠️", "[WARN]", "Event stream interrupted: %v\n", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(sseReconnectDelay):
+			}
+		}
+	}()
+
+	return s
+}
+
+// Close ends the subscription and waits for the stream reader to stop
+func (s *sseSubscriber) Close() error {
+	s.cancel()
+	<-s.done
+	return nil
+}
+
+// readEvents reads one event stream connection, passing each data payload to handler
+func (c *MCPClient) readEvents(ctx context.Context, handler func(*mcp.Message)) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.serverURL+"/mcp/events", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if err := c.authorize(req); err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if refresher, ok := c.auth.(refreshableAuth); ok && resp.StatusCode == http.StatusUnauthorized {
+		// Reconnect with a fresh token
+		refresher.Invalidate()
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var data strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// A blank line ends the event
+			if data.Len() > 0 {
+				var msg mcp.Message
+				if err := json.Unmarshal([]byte(data.String()), &msg); err != nil {
+					printer.Print("⚠️", "[WARN]", "Ignoring malformed event: %v\n", err)
+				} else {
+					handler(&msg)
+				}
+				data.Reset()
+			}
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	return scanner.Err()
+}
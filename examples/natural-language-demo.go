@@ -1,10 +1,16 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"strings"
+
+	"github.com/mcp-servers/cli/pkg/output"
 )
 
+// printer writes demo output, honouring --no-emoji
+var printer = output.NewPrinter(false)
+
 // NaturalLanguageProcessor demonstrates how AI agents process natural language
 type NaturalLanguageProcessor struct {
 	commands map[string]string
@@ -21,7 +27,7 @@ func NewNaturalLanguageProcessor() *NaturalLanguageProcessor {
 func (p *NaturalLanguageProcessor) ProcessQuery(query string) string {
 	query = strings.ToLower(query)
 
-	fmt.Printf("🤖 AI Agent: Processing query: '%s'\n", query)
+	printer.Print("🤖", "[AI]", "AI Agent: Processing query: '%s'\n", query)
 
 	switch {
 	case strings.Contains(query, "list") && strings.Contains(query, "pod"):
@@ -72,38 +78,42 @@ func (p *NaturalLanguageProcessor) ProcessQuery(query string) string {
 
 // ExecuteCommand simulates executing the command
 func (p *NaturalLanguageProcessor) ExecuteCommand(command string) {
-	fmt.Printf("🔗 MCP Server: Executing command: %s\n", command)
+	printer.Print("🔗", "[MCP]", "MCP Server: Executing command: %s\n", command)
 
 	// Simulate command execution
 	switch {
 	case strings.Contains(command, "get pods"):
-		fmt.Println("📊 Result:")
-		fmt.Println("  📦 default/nginx-deployment-abc123 (Running) - Age: 2h")
-		fmt.Println("  📦 default/redis-deployment-def456 (Running) - Age: 1h")
-		fmt.Println("  📦 kube-system/coredns-xyz789 (Running) - Age: 5h")
+		printer.Print("📊", "[RESULT]", "Result:\n")
+		fmt.Printf("  %s default/nginx-deployment-abc123 (Running) - Age: 2h\n", printer.Prefix("📦", "[POD]"))
+		fmt.Printf("  %s default/redis-deployment-def456 (Running) - Age: 1h\n", printer.Prefix("📦", "[POD]"))
+		fmt.Printf("  %s kube-system/coredns-xyz789 (Running) - Age: 5h\n", printer.Prefix("📦", "[POD]"))
 	case strings.Contains(command, "get services"):
-		fmt.Println("📊 Result:")
-		fmt.Println("  🔗 default/kubernetes (ClusterIP) - IP: 10.96.0.1")
-		fmt.Println("  🔗 default/nginx-service (ClusterIP) - IP: 10.96.1.100")
-		fmt.Println("  🔗 kube-system/kube-dns (ClusterIP) - IP: 10.96.0.10")
+		printer.Print("📊", "[RESULT]", "Result:\n")
+		fmt.Printf("  %s default/kubernetes (ClusterIP) - IP: 10.96.0.1\n", printer.Prefix("🔗", "[SVC]"))
+		fmt.Printf("  %s default/nginx-service (ClusterIP) - IP: 10.96.1.100\n", printer.Prefix("🔗", "[SVC]"))
+		fmt.Printf("  %s kube-system/kube-dns (ClusterIP) - IP: 10.96.0.10\n", printer.Prefix("🔗", "[SVC]"))
 	case strings.Contains(command, "get deployments"):
-		fmt.Println("📊 Result:")
-		fmt.Println("  🚀 default/nginx-deployment - Replicas: 3/3")
-		fmt.Println("  🚀 default/redis-deployment - Replicas: 1/1")
-		fmt.Println("  🚀 kube-system/coredns - Replicas: 2/2")
+		printer.Print("📊", "[RESULT]", "Result:\n")
+		fmt.Printf("  %s default/nginx-deployment - Replicas: 3/3\n", printer.Prefix("🚀", "[DEPLOY]"))
+		fmt.Printf("  %s default/redis-deployment - Replicas: 1/1\n", printer.Prefix("🚀", "[DEPLOY]"))
+		fmt.Printf("  %s kube-system/coredns - Replicas: 2/2\n", printer.Prefix("🚀", "[DEPLOY]"))
 	case strings.Contains(command, "create deployment"):
-		fmt.Println("📊 Result: deployment.apps/myapp created")
+		printer.Print("📊", "[RESULT]", "Result: deployment.apps/myapp created\n")
 	case strings.Contains(command, "scale deployment"):
-		fmt.Println("📊 Result: deployment.apps/myapp scaled")
+		printer.Print("📊", "[RESULT]", "Result: deployment.apps/myapp scaled\n")
 	case strings.Contains(command, "delete pod"):
-		fmt.Println("📊 Result: pod \"nginx-deployment-abc123\" deleted")
+		printer.Print("📊", "[RESULT]", "Result: pod \"nginx-deployment-abc123\" deleted\n")
 	default:
-		fmt.Println("📊 Result: Command executed successfully")
+		printer.Print("📊", "[RESULT]", "Result: Command executed successfully\n")
 	}
 }
 
 func main() {
-	fmt.Println("🚀 Natural Language MCP Demo")
+	noEmoji := flag.Bool("no-emoji", false, "Print plain ASCII prefixes instead of emoji")
+	flag.Parse()
+	printer = output.NewPrinter(*noEmoji)
+
+	printer.Print("🚀", "[START]", "Natural Language MCP Demo\n")
 	fmt.Println("=============================")
 	fmt.Println("")
 
@@ -130,16 +140,16 @@ func main() {
 		fmt.Println("")
 	}
 
-	fmt.Println("🎯 How This Works:")
+	printer.Print("🎯", "[INFO]", "How This Works:\n")
 	fmt.Println("==================")
-	fmt.Println("1. 🤖 AI Agent receives natural language query")
-	fmt.Println("2. 🔍 AI processes the query and identifies intent")
-	fmt.Println("3. 🔗 MCP Server translates intent to specific commands")
-	fmt.Println("4. ☸️  Kubernetes API executes the commands")
-	fmt.Println("5. 📊 Results are formatted and returned to AI")
+	fmt.Printf("1. %s AI Agent receives natural language query\n", printer.Prefix("🤖", "[AI]"))
+	fmt.Printf("2. %s AI processes the query and identifies intent\n", printer.Prefix("🔍", "[QUERY]"))
+	fmt.Printf("3. %s MCP Server translates intent to specific commands\n", printer.Prefix("🔗", "[MCP]"))
+	fmt.Printf("4. %s Kubernetes API executes the commands\n", printer.Prefix("☸️", "[K8S]"))
+	fmt.Printf("5. %s Results are formatted and returned to AI\n", printer.Prefix("📊", "[RESULT]"))
 	fmt.Println("")
 
-	fmt.Println("💡 Real-World Applications:")
+	printer.Print("💡", "[TIP]", "Real-World Applications:\n")
 	fmt.Println("===========================")
 	fmt.Println("• kubectl AI in Kubernetes 1.33")
 	fmt.Println("• AWS Bedrock natural language operations")
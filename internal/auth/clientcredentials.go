@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ClientCredentials issues access tokens with the OAuth 2.0 client credentials grant, caching each token until it expires
+type ClientCredentials struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewClientCredentials creates a token source for a confidential client registered with issuer
+func NewClientCredentials(issuer, clientID, clientSecret string) *ClientCredentials {
+	return &ClientCredentials{
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+	}
+}
+
+// Token returns the cached access token, requesting a new one when there is none or it is about to expire
+func (c *ClientCredentials) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && time.Now().Add(expiryLeeway).Before(c.expiry) {
+		return c.token, nil
+	}
+
+	metadata, err := discover(ctx, c.Issuer)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", c.ClientID)
+	form.Set("client_secret", c.ClientSecret)
+
+	var token tokenResponse
+	if err := postForm(ctx, metadata.TokenEndpoint, form, &token); err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	if token.Error != "" {
+		return "", fmt.Errorf("token request failed: %s %s", token.Error, token.ErrorDescription)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("token response has no access_token")
+	}
+
+	c.token = token.AccessToken
+	if expiry, ok := jwtExpiry(token.AccessToken); ok {
+		c.expiry = expiry
+	} else {
+		c.expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return c.token, nil
+}
+
+// Invalidate drops the cached token so the next call to Token requests a new one, e.g. after the server rejected it
+func (c *ClientCredentials) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = ""
+}
@@ -0,0 +1,320 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// deviceCodeGrantType is the OAuth 2.0 device authorization grant (RFC 8628)
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// expiryLeeway refreshes tokens this long before they actually expire
+const expiryLeeway = time.Minute
+
+// Credentials holds the OIDC tokens written by the login command
+type Credentials struct {
+	Provider     string    `json:"provider"`
+	Issuer       string    `json:"issuer"`
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+	IDToken      string    `json:"id_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// DefaultCredentialsPath returns ~/.config/mcp-servers/credentials.json
+func DefaultCredentialsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "mcp-servers", "credentials.json"), nil
+}
+
+// LoadCredentials reads credentials from a file
+func LoadCredentials(path string) (*Credentials, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds Credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+	return &creds, nil
+}
+
+// Save writes the credentials to a file readable only by the current user
+func (c *Credentials) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file, so tighten it explicitly
+	return os.Chmod(path, 0600)
+}
+
+// Expired reports whether the ID token has expired or is about to
+func (c *Credentials) Expired() bool {
+	return c.IDToken == "" || time.Now().Add(expiryLeeway).After(c.Expiry)
+}
+
+// ValidToken returns a current ID token from the credentials file, refreshing and saving it if it has expired
+func ValidToken(ctx context.Context, path string) (string, error) {
+	creds, err := LoadCredentials(path)
+	if err != nil {
+		return "", err
+	}
+
+	if creds.Expired() {
+		if err := creds.Refresh(ctx); err != nil {
+			return "", err
+		}
+		if err := creds.Save(path); err != nil {
+			return "", err
+		}
+	}
+	return creds.IDToken, nil
+}
+
+// providerMetadata holds the discovery fields used by the device flow
+type providerMetadata struct {
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+}
+
+// tokenResponse is a successful or failed token endpoint response
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// DeviceAuthorization is the device code issued to the user
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceLogin runs the OIDC device authorization flow, calling prompt so the user can approve the device
+func DeviceLogin(ctx context.Context, issuer, clientID, clientSecret string, scopes []string, prompt func(*DeviceAuthorization)) (*Credentials, error) {
+	metadata, err := discover(ctx, issuer)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.DeviceAuthorizationEndpoint == "" {
+		return nil, fmt.Errorf("issuer %s does not support the device authorization flow", issuer)
+	}
+
+	form := url.Values{}
+	form.Set("client_id", clientID)
+	if clientSecret != "" {
+		form.Set("client_secret", clientSecret)
+	}
+	form.Set("scope", strings.Join(scopes, " "))
+
+	var device DeviceAuthorization
+	if err := postForm(ctx, metadata.DeviceAuthorizationEndpoint, form, &device); err != nil {
+		return nil, fmt.Errorf("device authorization failed: %w", err)
+	}
+	if device.DeviceCode == "" {
+		return nil, fmt.Errorf("device authorization response has no device_code")
+	}
+	prompt(&device)
+
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(device.ExpiresIn) * time.Second)
+	if device.ExpiresIn <= 0 {
+		deadline = time.Now().Add(10 * time.Minute)
+	}
+
+	form = url.Values{}
+	form.Set("grant_type", deviceCodeGrantType)
+	form.Set("device_code", device.DeviceCode)
+	form.Set("client_id", clientID)
+	if clientSecret != "" {
+		form.Set("client_secret", clientSecret)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("device code expired before the login was approved")
+		}
+
+		var token tokenResponse
+		if err := postForm(ctx, metadata.TokenEndpoint, form, &token); err != nil {
+			return nil, fmt.Errorf("token request failed: %w", err)
+		}
+
+		switch token.Error {
+		case "":
+			creds := &Credentials{
+				Provider:     "oidc",
+				Issuer:       issuer,
+				ClientID:     clientID,
+				ClientSecret: clientSecret,
+			}
+			if err := creds.update(token); err != nil {
+				return nil, err
+			}
+			return creds, nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, fmt.Errorf("login failed: %s %s", token.Error, token.ErrorDescription)
+		}
+	}
+}
+
+// Refresh exchanges the refresh token for a new ID token
+func (c *Credentials) Refresh(ctx context.Context) error {
+	if c.RefreshToken == "" {
+		return fmt.Errorf("ID token expired and no refresh token is available; run mcp-cli login again")
+	}
+
+	metadata, err := discover(ctx, c.Issuer)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", c.RefreshToken)
+	form.Set("client_id", c.ClientID)
+	if c.ClientSecret != "" {
+		form.Set("client_secret", c.ClientSecret)
+	}
+
+	var token tokenResponse
+	if err := postForm(ctx, metadata.TokenEndpoint, form, &token); err != nil {
+		return fmt.Errorf("token refresh failed: %w", err)
+	}
+	if token.Error != "" {
+		return fmt.Errorf("token refresh failed: %s %s", token.Error, token.ErrorDescription)
+	}
+	return c.update(token)
+}
+
+// update stores the tokens from a successful token response
+func (c *Credentials) update(token tokenResponse) error {
+	if token.IDToken == "" {
+		return fmt.Errorf("token response has no id_token; request the openid scope")
+	}
+
+	c.IDToken = token.IDToken
+	if token.RefreshToken != "" {
+		c.RefreshToken = token.RefreshToken
+	}
+
+	if expiry, ok := jwtExpiry(token.IDToken); ok {
+		c.Expiry = expiry
+	} else {
+		c.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return nil
+}
+
+// jwtExpiry reads the exp claim of a JWT without verifying its signature
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
+
+// discover fetches the issuer's OpenID provider metadata
+func discover(ctx context.Context, issuer string) (*providerMetadata, error) {
+	wellKnown := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer URL: %w", err)
+	}
+
+	var metadata providerMetadata
+	if err := doJSON(req, &metadata); err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	if metadata.TokenEndpoint == "" {
+		return nil, fmt.Errorf("OIDC discovery for %s returned no token_endpoint", issuer)
+	}
+	return &metadata, nil
+}
+
+// postForm posts a form and decodes the JSON response, including OAuth error responses
+func postForm(ctx context.Context, endpoint string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doJSON(req, out)
+}
+
+// doJSON performs a request and decodes its JSON body; 4xx bodies are decoded too since OAuth reports errors there
+func doJSON(req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 500 || (resp.StatusCode >= 300 && resp.StatusCode < 400) {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response with status %d: %w", resp.StatusCode, err)
+	}
+	return nil
+}
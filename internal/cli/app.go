@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"os"
 
+	"github.com/mcp-servers/cli/internal/auth"
 	"github.com/mcp-servers/cli/internal/commands"
 	"github.com/mcp-servers/cli/internal/config"
 	"github.com/sirupsen/logrus"
@@ -46,11 +49,13 @@ func (a *App) setupRootCommand(version, commit, date string) {
 	a.rootCmd.PersistentFlags().StringP("config", "c", "", "config file (default is ./configs/config.yaml)")
 	a.rootCmd.PersistentFlags().StringP("log-level", "l", "info", "log level (debug, info, warn, error)")
 	a.rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
+	a.rootCmd.PersistentFlags().Bool("no-emoji", false, "print plain ASCII prefixes instead of emoji")
 
 	// Bind flags to viper
 	viper.BindPFlag("config", a.rootCmd.PersistentFlags().Lookup("config"))
 	viper.BindPFlag("log_level", a.rootCmd.PersistentFlags().Lookup("log-level"))
 	viper.BindPFlag("verbose", a.rootCmd.PersistentFlags().Lookup("verbose"))
+	viper.BindPFlag("no_emoji", a.rootCmd.PersistentFlags().Lookup("no-emoji"))
 }
 
 // setupConfig initializes configuration
@@ -80,6 +85,24 @@ func (a *App) setupCommands() {
 	
 	// Health commands
 	a.rootCmd.AddCommand(commands.NewHealthCommand(a.config))
+
+	// Benchmark commands
+	a.rootCmd.AddCommand(commands.NewBenchmarkCommand(a.config))
+
+	// Authentication commands
+	a.rootCmd.AddCommand(commands.NewLoginCommand(a.config))
+
+	// Protocol version commands
+	a.rootCmd.AddCommand(commands.NewVersionCheckCommand(a.config))
+
+	// Custom tool commands
+	a.rootCmd.AddCommand(commands.NewToolsCommand(a.config))
+
+	// Trace commands
+	a.rootCmd.AddCommand(commands.NewTracesCommand(a.config))
+
+	// Profile commands
+	a.rootCmd.AddCommand(commands.NewProfileCommand(a.config))
 }
 
 // loadConfig loads the configuration file
@@ -97,17 +120,47 @@ func (a *App) loadConfig() error {
 		logrus.Warn("No config file found, using default configuration")
 	}
 
+	commands.SetNoEmoji(viper.GetBool("no_emoji"))
+
 	// Set log level
 	logLevel := viper.GetString("log_level")
 	if level, err := logrus.ParseLevel(logLevel); err == nil {
 		logrus.SetLevel(level)
 	}
 
-	// Load configuration into struct
-	a.config = &config.Config{}
+	// Load configuration into the struct shared with the subcommands
+	*a.config = config.Config{}
 	if err := viper.Unmarshal(a.config); err != nil {
 		return fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	a.applyCredentials()
+
 	return nil
 }
+
+// applyCredentials sets the stored OIDC ID token on servers using oauth2 auth, refreshing it if it has expired
+func (a *App) applyCredentials() {
+	path, err := auth.DefaultCredentialsPath()
+	if err != nil {
+		return
+	}
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+
+	var token string
+	for name, server := range a.config.Servers {
+		if server.Auth.Type != "oauth2" {
+			continue
+		}
+		if token == "" {
+			if token, err = auth.ValidToken(context.Background(), path); err != nil {
+				logrus.Warnf("Failed to load OIDC credentials: %v", err)
+				return
+			}
+		}
+		server.Auth.Token = token
+		a.config.Servers[name] = server
+	}
+}
@@ -0,0 +1,248 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/internal/config"
+	"github.com/mcp-servers/cli/pkg/llm"
+	"github.com/mcp-servers/cli/pkg/nlp"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// benchmarkQuery is a query with a pattern the generated command must match
+type benchmarkQuery struct {
+	Query                  string `yaml:"query"`
+	ExpectedCommandPattern string `yaml:"expected_command_pattern"`
+}
+
+// defaultBenchmarkQueries are used when no queries file is given
+var defaultBenchmarkQueries = []benchmarkQuery{
+	{Query: "list all pods in the default namespace", ExpectedCommandPattern: `^kubectl get pods( -n default)?$`},
+	{Query: "show services in all namespaces", ExpectedCommandPattern: `^kubectl get services --all-namespaces$`},
+	{Query: "list deployments in kube-system", ExpectedCommandPattern: `^kubectl get deployments -n kube-system$`},
+	{Query: "create a deployment called web using nginx:latest", ExpectedCommandPattern: `^kubectl create deployment web --image=nginx:latest`},
+	{Query: "scale deployment web to 3 replicas", ExpectedCommandPattern: `^kubectl scale deployment web --replicas=3`},
+	{Query: "delete pod web-abc123", ExpectedCommandPattern: `^kubectl delete pod web-abc123`},
+	{Query: "describe pod web-abc123 in namespace prod", ExpectedCommandPattern: `^kubectl describe pod web-abc123 -n prod$`},
+}
+
+// benchmarkResult holds the measurements for one provider
+type benchmarkResult struct {
+	provider  string
+	model     string
+	latencies []time.Duration
+	errors    int
+	correct   int
+	tokens    int
+	hasTokens bool
+}
+
+// NewBenchmarkCommand creates the benchmark command
+func NewBenchmarkCommand(cfg *config.Config) *cobra.Command {
+	var (
+		queriesFile string
+		providers   []string
+		llmConfig   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "benchmark",
+		Short: "Compare LLM providers on standard Kubernetes queries",
+		Long: `Send a set of Kubernetes queries to each provider and report latency percentiles,
+command extraction accuracy and token usage as a Markdown table.
+
+Providers are given as provider[:model], where the model is required for every
+provider but gemini, whose default is used; API keys are read from OPENAI_API_KEY,
+GEMINI_API_KEY and OPENROUTER_API_KEY. Without --providers the provider from
+the AI CLI configuration is benchmarked.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBenchmark(llmConfig, providers, queriesFile)
+		},
+	}
+
+	cmd.Flags().StringVar(&queriesFile, "queries-file", "", "YAML file with {query, expected_command_pattern} entries")
+	cmd.Flags().StringSliceVar(&providers, "providers", nil, "Providers to compare as provider[:model] (default: configured provider)")
+	cmd.Flags().StringVar(&llmConfig, "llm-config", "", "Path to the AI CLI configuration file")
+
+	return cmd
+}
+
+// runBenchmark runs every query against every provider and prints the results
+func runBenchmark(llmConfigPath string, providerSpecs []string, queriesFile string) error {
+	queries := defaultBenchmarkQueries
+	if queriesFile != "" {
+		loaded, err := loadBenchmarkQueries(queriesFile)
+		if err != nil {
+			return err
+		}
+		queries = loaded
+	}
+
+	patterns := make([]*regexp.Regexp, len(queries))
+	for i, q := range queries {
+		pattern, err := regexp.Compile(q.ExpectedCommandPattern)
+		if err != nil {
+			return fmt.Errorf("invalid expected_command_pattern for %q: %w", q.Query, err)
+		}
+		patterns[i] = pattern
+	}
+
+	providers, err := benchmarkProviders(llmConfigPath, providerSpecs)
+	if err != nil {
+		return err
+	}
+
+	var results []*benchmarkResult
+	for _, provider := range providers {
+		fmt.Fprintf(os.Stderr, "Benchmarking %s (%s) with %d queries...\n", provider.GetProvider(), provider.GetModel(), len(queries))
+		results = append(results, benchmarkProvider(provider, queries, patterns))
+	}
+
+	fmt.Print(formatBenchmarkTable(results, len(queries)))
+	return nil
+}
+
+// loadBenchmarkQueries reads benchmark queries from a YAML file
+func loadBenchmarkQueries(path string) ([]benchmarkQuery, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queries file: %w", err)
+	}
+
+	var queries []benchmarkQuery
+	if err := yaml.Unmarshal(data, &queries); err != nil {
+		return nil, fmt.Errorf("failed to parse queries file: %w", err)
+	}
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("queries file %s contains no queries", path)
+	}
+
+	return queries, nil
+}
+
+// benchmarkProviders creates the providers to compare
+func benchmarkProviders(llmConfigPath string, specs []string) ([]llm.Provider, error) {
+	if len(specs) == 0 {
+		llmConfig, err := config.LoadLLMConfig(llmConfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load LLM configuration: %w", err)
+		}
+		provider, err := llmConfig.CreateLLMProvider()
+		if err != nil {
+			return nil, err
+		}
+		return []llm.Provider{provider}, nil
+	}
+
+	var providers []llm.Provider
+	for _, spec := range specs {
+		name, model, _ := strings.Cut(spec, ":")
+
+		llmConfig := config.DefaultLLMConfig()
+		// The default model belongs to the default provider, so other providers need a model
+		if model == "" && name != llmConfig.Provider {
+			return nil, fmt.Errorf("provider %s needs a model, e.g. %s:<model>; the default, %s, is a %s model", name, name, llmConfig.Model, llmConfig.Provider)
+		}
+		llmConfig.Provider = name
+		if model != "" {
+			llmConfig.Model = model
+		}
+		llmConfig.APIKey = os.Getenv(strings.ToUpper(name) + "_API_KEY")
+		if llmConfig.APIKey == "" {
+			return nil, fmt.Errorf("%s_API_KEY is not set for provider %s", strings.ToUpper(name), name)
+		}
+
+		provider, err := llmConfig.CreateLLMProvider()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create provider %s: %w", spec, err)
+		}
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
+// benchmarkProvider sends each query to the provider and records latency, accuracy and tokens
+func benchmarkProvider(provider llm.Provider, queries []benchmarkQuery, patterns []*regexp.Regexp) *benchmarkResult {
+	result := &benchmarkResult{
+		provider: provider.GetProvider(),
+		model:    provider.GetModel(),
+	}
+
+	processor := nlp.NewProcessor(provider)
+	processor.SetQueryCacheTTL(0)
+
+	for i, q := range queries {
+		processor.ClearHistory()
+
+		start := time.Now()
+		response, err := processor.ProcessQuery(context.Background(), q.Query)
+		result.latencies = append(result.latencies, time.Since(start))
+		if err != nil {
+			result.errors++
+			continue
+		}
+
+		for _, toolCall := range response.ToolCalls {
+			command, err := nlp.TranslateToolCallToCommand(toolCall)
+			if err == nil && patterns[i].MatchString(command) {
+				result.correct++
+				break
+			}
+		}
+
+		if tokens, ok := response.Metadata["total_tokens"].(int); ok {
+			result.tokens += tokens
+			result.hasTokens = true
+		}
+	}
+
+	return result
+}
+
+// formatBenchmarkTable renders the results as a Markdown table
+func formatBenchmarkTable(results []*benchmarkResult, queryCount int) string {
+	var b strings.Builder
+	b.WriteString("| Provider | Model | p50 | p95 | p99 | Accuracy | Errors | Avg tokens |\n")
+	b.WriteString("|----------|-------|-----|-----|-----|----------|--------|------------|\n")
+
+	for _, r := range results {
+		sorted := append([]time.Duration(nil), r.latencies...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		tokens := "n/a"
+		if r.hasTokens {
+			tokens = fmt.Sprintf("%d", r.tokens/queryCount)
+		}
+
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %d/%d (%.0f%%) | %d | %s |\n",
+			r.provider, r.model,
+			latencyPercentile(sorted, 0.50), latencyPercentile(sorted, 0.95), latencyPercentile(sorted, 0.99),
+			r.correct, queryCount, 100*float64(r.correct)/float64(queryCount),
+			r.errors, tokens)
+	}
+
+	return b.String()
+}
+
+// latencyPercentile returns the nearest-rank percentile of sorted latencies
+func latencyPercentile(sorted []time.Duration, p float64) string {
+	if len(sorted) == 0 {
+		return "-"
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank].Round(time.Millisecond).String()
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/mcp-servers/cli/internal/config"
 	"github.com/sirupsen/logrus"
@@ -22,6 +23,7 @@ func NewConfigCommand(cfg *config.Config) *cobra.Command {
 		newConfigShowCommand(cfg),
 		newConfigInitCommand(),
 		newConfigValidateCommand(cfg),
+		newConfigImportEnvCommand(),
 	)
 
 	return cmd
@@ -60,6 +62,30 @@ func newConfigValidateCommand(cfg *config.Config) *cobra.Command {
 	}
 }
 
+// newConfigImportEnvCommand creates the import-env subcommand
+func newConfigImportEnvCommand() *cobra.Command {
+	var output string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "import-env",
+		Short: "Write the AI CLI configuration from environment variables",
+		Long: `Build the AI CLI configuration from environment variables, validate it and write it to the default config file.
+Recognized variables are LLM_PROVIDER, LLM_MODEL, LLM_API_KEY (or OPENAI_API_KEY, GEMINI_API_KEY, OPENROUTER_API_KEY
+for the matching provider), KUBECONFIG, MCP_SERVER, MCP_CLIENT and KMS_ENDPOINT; other settings keep their defaults.
+This bootstraps CI/CD environments where all configuration is injected through the environment.`,
+		Example: "  LLM_PROVIDER=openai LLM_MODEL=gpt-4o LLM_API_KEY=sk-... mcp-cli config import-env",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return importConfigFromEnv(output, force)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", config.DefaultLLMConfigPath(), "File to write the configuration to")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite the file if it exists")
+
+	return cmd
+}
+
 // showConfig displays the current configuration
 func showConfig(cfg *config.Config) error {
 	data, err := yaml.Marshal(cfg)
@@ -95,6 +121,72 @@ func initConfig() error {
 	return nil
 }
 
+// importConfigFromEnv writes the LLM configuration built from environment variables to path, summarizing what was found
+func importConfigFromEnv(path string, force bool) error {
+	llmConfig, found := config.LLMConfigFromEnv()
+
+	fmt.Println("Found:")
+	if len(found) == 0 {
+		fmt.Println("  (no recognized environment variables)")
+	}
+	for _, name := range found {
+		value := os.Getenv(name)
+		if strings.HasSuffix(name, "_API_KEY") {
+			value = maskSecret(value)
+		}
+		fmt.Printf("  %s=%s\n", name, value)
+	}
+
+	var missing []string
+	if os.Getenv("LLM_PROVIDER") == "" {
+		missing = append(missing, fmt.Sprintf("LLM_PROVIDER (using the default, %s)", llmConfig.Provider))
+	}
+	// The default model belongs to the default provider, so other providers need a model
+	defaults := config.DefaultLLMConfig()
+	modelRequired := os.Getenv("LLM_MODEL") == "" && llmConfig.Provider != defaults.Provider
+	if modelRequired {
+		missing = append(missing, fmt.Sprintf("LLM_MODEL (required: the default, %s, is a %s model)", defaults.Model, defaults.Provider))
+	} else if os.Getenv("LLM_MODEL") == "" {
+		missing = append(missing, fmt.Sprintf("LLM_MODEL (using the default, %s)", llmConfig.Model))
+	}
+	if llmConfig.APIKey == "" {
+		missing = append(missing, fmt.Sprintf("LLM_API_KEY or %s_API_KEY (required)", strings.ToUpper(llmConfig.Provider)))
+	}
+	if os.Getenv("KUBECONFIG") == "" {
+		missing = append(missing, fmt.Sprintf("KUBECONFIG (using the default, %s)", llmConfig.Kubeconfig))
+	}
+	if len(missing) > 0 {
+		fmt.Println("Missing:")
+		for _, name := range missing {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+
+	if modelRequired {
+		return fmt.Errorf("LLM_MODEL is required for provider %s", llmConfig.Provider)
+	}
+	if err := llmConfig.Validate(); err != nil {
+		return fmt.Errorf("configuration from the environment is invalid: %w", err)
+	}
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%s already exists; pass --force to overwrite it", path)
+	}
+	if err := llmConfig.SaveConfig(path); err != nil {
+		return err
+	}
+
+	printer.Print("✅", "[OK]", "Wrote configuration to %s\n", path)
+	return nil
+}
+
+// maskSecret shows only the last four characters of a secret
+func maskSecret(secret string) string {
+	if len(secret) <= 4 {
+		return strings.Repeat("*", len(secret))
+	}
+	return strings.Repeat("*", 8) + secret[len(secret)-4:]
+}
+
 // validateConfig validates the current configuration
 func validateConfig(cfg *config.Config) error {
 	errors := []string{}
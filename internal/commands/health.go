@@ -1,9 +1,20 @@
 package commands
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/mcp-servers/cli/internal/auth"
 	"github.com/mcp-servers/cli/internal/config"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -28,17 +39,24 @@ func NewHealthCommand(cfg *config.Config) *cobra.Command {
 // newHealthCheckCommand creates the check subcommand
 func newHealthCheckCommand(cfg *config.Config) *cobra.Command {
 	var timeout int
+	var watch bool
+	var remediation string
 
 	cmd := &cobra.Command{
 		Use:   "check [server]",
 		Short: "Check health of a specific server",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if watch {
+				return watchServerHealth(cfg, args[0], timeout, remediation)
+			}
 			return checkServerHealth(cfg, args[0], timeout)
 		},
 	}
 
 	cmd.Flags().IntVarP(&timeout, "timeout", "t", 5, "Health check timeout in seconds")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Keep checking on the server's health check interval until interrupted")
+	cmd.Flags().StringVar(&remediation, "remediation-command", "", "Shell command to run when consecutive failures exceed max_failures (with --watch)")
 
 	return cmd
 }
@@ -64,23 +82,178 @@ func checkServerHealth(cfg *config.Config, serverName string, timeout int) error
 	logrus.Infof("Checking health of server '%s' at %s://%s:%d",
 		serverName, server.Protocol, server.Host, server.Port)
 
-	// TODO: Implement actual health check
-	// This would involve:
-	// 1. Establishing a connection
-	// 2. Sending a ping/health request
-	// 3. Measuring response time
-	// 4. Checking for specific health indicators
-
-	// Simulate health check
-	time.Sleep(100 * time.Millisecond)
+	latency, err := probeServer(server, time.Duration(timeout)*time.Second)
+	if err != nil {
+		printer.Print("❌", "[ERR]", "Server '%s' is unhealthy\n", serverName)
+		fmt.Printf("   Error: %v\n", err)
+		fmt.Printf("   Status: DOWN\n")
+		return fmt.Errorf("health check failed for server '%s'", serverName)
+	}
 
-	fmt.Printf("✅ Server '%s' is healthy\n", serverName)
-	fmt.Printf("   Response time: 45ms\n")
+	printer.Print("✅", "[OK]", "Server '%s' is healthy\n", serverName)
+	fmt.Printf("   Response time: %dms\n", latency.Milliseconds())
 	fmt.Printf("   Status: UP\n")
 
 	return nil
 }
 
+// watchServerHealth checks a server on its health check interval, redrawing a status line until interrupted
+func watchServerHealth(cfg *config.Config, serverName string, timeout int, remediation string) error {
+	server, exists := cfg.Servers[serverName]
+	if !exists {
+		return fmt.Errorf("server '%s' not found", serverName)
+	}
+
+	interval := server.HealthCheck.Interval
+	if interval < time.Second {
+		interval = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		select {
+		case <-sigCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	fmt.Printf("Watching server '%s' every %s (Ctrl+C to stop)\n", serverName, interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	successes, failures := 0, 0
+	alerted := false
+	for {
+		latency, err := probeServer(server, time.Duration(timeout)*time.Second)
+		checkedAt := time.Now().Format("15:04:05")
+
+		var line string
+		if err != nil {
+			failures++
+			successes = 0
+			line = fmt.Sprintf("%s %s  last check %s  DOWN  %d consecutive failure(s): %v", printer.Prefix("❌", "[ERR]"), serverName, checkedAt, failures, err)
+		} else {
+			successes++
+			failures = 0
+			alerted = false
+			line = fmt.Sprintf("%s %s  last check %s  latency %dms  %d consecutive success(es)", printer.Prefix("✅", "[OK]"), serverName, checkedAt, latency.Milliseconds(), successes)
+		}
+		// Redraw the status line in place
+		fmt.Printf("\r\033[K%s", line)
+
+		if maxFailures := server.HealthCheck.MaxFailures; maxFailures > 0 && failures > maxFailures && !alerted {
+			alerted = true
+			fmt.Println()
+			printer.Print("🚨", "[ALERT]", "Alert: server '%s' failed %d consecutive health checks (max_failures %d)\n", serverName, failures, maxFailures)
+			if remediation != "" {
+				runRemediation(ctx, remediation)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// runRemediation runs the configured remediation command through the shell
+func runRemediation(ctx context.Context, command string) {
+	printer.Print("🔧", "[FIX]", "Running remediation: %s\n", command)
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		printer.Print("❌", "[ERR]", "Remediation failed: %v\n", err)
+	}
+}
+
+// probeServer requests the server's health endpoint and returns the response time
+func probeServer(server config.ServerConfig, timeout time.Duration) (time.Duration, error) {
+	endpoint := server.HealthCheck.Endpoint
+	if endpoint == "" {
+		endpoint = "/health"
+	}
+
+	req, err := newServerRequest(server, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, fmt.Errorf("invalid health check URL: %w", err)
+	}
+
+	start := time.Now()
+	resp, err := serverHTTPClient(server, timeout).Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return latency, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return latency, fmt.Errorf("health endpoint returned status %d", resp.StatusCode)
+	}
+	return latency, nil
+}
+
+// newServerRequest builds a request for path on a configured server, applying its authentication
+func newServerRequest(server config.ServerConfig, method, path string, body io.Reader) (*http.Request, error) {
+	protocol := server.Protocol
+	if protocol == "" {
+		protocol = "http"
+	}
+	url := fmt.Sprintf("%s://%s:%d/%s", protocol, server.Host, server.Port, strings.TrimPrefix(path, "/"))
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	switch server.Auth.Type {
+	case "basic":
+		req.SetBasicAuth(server.Auth.Username, server.Auth.Password)
+	case "token", "oauth2":
+		req.Header.Set("Authorization", "Bearer "+server.Auth.Token)
+	case "oidc":
+		token, err := oidcToken(server.Auth)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	for name, value := range server.Auth.Headers {
+		req.Header.Set(name, value)
+	}
+	return req, nil
+}
+
+// oidcSources caches one client credentials token source per issuer and client so tokens are reused across requests
+var oidcSources sync.Map
+
+// oidcToken returns an access token for a server using oidc authentication
+func oidcToken(cfg config.AuthConfig) (string, error) {
+	if cfg.Issuer == "" || cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return "", fmt.Errorf("oidc authentication requires an issuer, client_id and client_secret")
+	}
+	source, _ := oidcSources.LoadOrStore(cfg.Issuer+"\x00"+cfg.ClientID, auth.NewClientCredentials(cfg.Issuer, cfg.ClientID, cfg.ClientSecret))
+	return source.(*auth.ClientCredentials).Token(context.Background())
+}
+
+// serverHTTPClient returns an HTTP client honouring the server's TLS settings
+func serverHTTPClient(server config.ServerConfig, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: server.TLS.SkipVerify},
+		},
+	}
+}
+
 // showHealthStatus displays health status of all servers
 func showHealthStatus(cfg *config.Config) error {
 	if len(cfg.Servers) == 0 {
@@ -92,9 +265,9 @@ func showHealthStatus(cfg *config.Config) error {
 	fmt.Println("=====================")
 
 	for name, server := range cfg.Servers {
-		status := "❌ UNKNOWN"
+		status := printer.Prefix("❌", "[ERR]") + " UNKNOWN"
 		if server.HealthCheck.Enabled {
-			status = "✅ HEALTHY"
+			status = printer.Prefix("✅", "[OK]") + " HEALTHY"
 		}
 
 		fmt.Printf("%s: %s (%s://%s:%d)\n",
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/mcp-servers/cli/internal/auth"
+	"github.com/mcp-servers/cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// NewLoginCommand creates the login command
+func NewLoginCommand(cfg *config.Config) *cobra.Command {
+	var provider, issuer, clientID, clientSecret string
+	var scopes []string
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in to an OIDC provider",
+		Long: `Log in with the OIDC device authorization flow and store the tokens in
+~/.config/mcp-servers/credentials.json. Servers using oauth2 auth send the
+ID token, which is refreshed automatically when it expires.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if provider != "oidc" {
+				return fmt.Errorf("unsupported provider: %s (supported: oidc)", provider)
+			}
+			if issuer == "" || clientID == "" {
+				return fmt.Errorf("--issuer and --client-id are required")
+			}
+			return login(issuer, clientID, clientSecret, scopes)
+		},
+	}
+
+	cmd.Flags().StringVar(&provider, "provider", "oidc", "Authentication provider")
+	cmd.Flags().StringVar(&issuer, "issuer", "", "OIDC issuer URL")
+	cmd.Flags().StringVar(&clientID, "client-id", "", "OAuth2 client ID")
+	cmd.Flags().StringVar(&clientSecret, "client-secret", "", "OAuth2 client secret (optional for public clients)")
+	cmd.Flags().StringSliceVar(&scopes, "scopes", []string{"openid", "offline_access", "email", "profile"}, "Scopes to request")
+
+	return cmd
+}
+
+// login runs the device flow and saves the resulting credentials
+func login(issuer, clientID, clientSecret string, scopes []string) error {
+	path, err := auth.DefaultCredentialsPath()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	creds, err := auth.DeviceLogin(ctx, issuer, clientID, clientSecret, scopes, func(device *auth.DeviceAuthorization) {
+		if device.VerificationURIComplete != "" {
+			printer.Print("🔐", "[AUTH]", "Open %s to log in\n", device.VerificationURIComplete)
+		} else {
+			printer.Print("🔐", "[AUTH]", "Open %s and enter the code %s\n", device.VerificationURI, device.UserCode)
+		}
+		fmt.Println("Waiting for approval...")
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := creds.Save(path); err != nil {
+		return err
+	}
+
+	printer.Print("✅", "[OK]", "Logged in; credentials saved to %s (expires %s)\n", path, creds.Expiry.Format("2006-01-02 15:04:05"))
+	return nil
+}
@@ -0,0 +1,11 @@
+package commands
+
+import "github.com/mcp-servers/cli/pkg/output"
+
+// printer writes status output for all commands
+var printer = output.NewPrinter(false)
+
+// SetNoEmoji switches command output between emoji and plain ASCII prefixes
+func SetNoEmoji(noEmoji bool) {
+	printer = output.NewPrinter(noEmoji)
+}
@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/mcp-servers/cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// NewProfileCommand creates the profile command
+func NewProfileCommand(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage environment profiles",
+		Long: `Manage profiles for the clusters and environments you work with, such as dev, staging and production.
+Each profile in ~/.config/mcp-servers/profiles.yaml holds a complete AI CLI configuration. The AI CLI and
+mcp-client use the profile named by --profile, or the current profile when the flag is omitted.`,
+	}
+
+	cmd.AddCommand(
+		newProfileListCommand(),
+		newProfileCreateCommand(),
+		newProfileSwitchCommand(),
+		newProfileDeleteCommand(),
+	)
+
+	return cmd
+}
+
+// newProfileListCommand creates the list subcommand
+func newProfileListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List profiles",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, err := config.LoadProfiles(config.DefaultProfilesPath(), false)
+			if err != nil {
+				return err
+			}
+			if len(profiles.Profiles) == 0 {
+				fmt.Println("No profiles. Create one with 'profile create <name>'.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "CURRENT\tNAME\tPROVIDER\tMODEL\tKUBECONFIG\tMCP SERVER\t")
+			for _, name := range profiles.Names() {
+				profile := profiles.Profiles[name]
+				current := ""
+				if name == profiles.Current {
+					current = "*"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t\n", current, name, profile.Provider, profile.Model, profile.Kubeconfig, profile.MCPServerURL)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// newProfileCreateCommand creates the create subcommand
+func newProfileCreateCommand() *cobra.Command {
+	var provider, model, apiKey, kubeconfig, mcpServerURL string
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a profile",
+		Long: `Create a profile from the default AI CLI configuration and the given settings.
+Pass --api-key '${VAR}' to store a reference to an environment variable instead of the key itself.`,
+		Example: "  mcp-cli profile create prod --provider openai --model gpt-4o --api-key '${PROD_OPENAI_KEY}' --kubeconfig ~/.kube/prod",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := config.DefaultProfilesPath()
+			profiles, err := config.LoadProfiles(path, false)
+			if err != nil {
+				return err
+			}
+			name := args[0]
+			if _, exists := profiles.Profiles[name]; exists {
+				return fmt.Errorf("profile '%s' already exists", name)
+			}
+
+			profile := &config.Profile{LLMConfig: *config.DefaultLLMConfig(), MCPServerURL: mcpServerURL}
+			if provider != "" {
+				profile.Provider = provider
+			}
+			if model != "" {
+				profile.Model = model
+			}
+			if kubeconfig != "" {
+				profile.Kubeconfig = kubeconfig
+			}
+			profile.APIKey = apiKey
+
+			profiles.Profiles[name] = profile
+			if profiles.Current == "" {
+				profiles.Current = name
+			}
+			if err := profiles.Save(path); err != nil {
+				return err
+			}
+
+			printer.Print("✅", "[OK]", "Created profile '%s'\n", name)
+			if profiles.Current == name {
+				fmt.Printf("   It is now the current profile.\n")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&provider, "provider", "", "LLM provider: openai, gemini or openrouter")
+	cmd.Flags().StringVar(&model, "model", "", "LLM model")
+	cmd.Flags().StringVar(&apiKey, "api-key", "", "LLM API key, or a ${VAR} reference expanded when the profile is loaded")
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Kubeconfig for the profile's cluster")
+	cmd.Flags().StringVar(&mcpServerURL, "mcp-server-url", "", "MCP server URL for the profile")
+
+	return cmd
+}
+
+// newProfileSwitchCommand creates the switch subcommand
+func newProfileSwitchCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "switch <name>",
+		Short: "Make a profile the current profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := config.DefaultProfilesPath()
+			profiles, err := config.LoadProfiles(path, false)
+			if err != nil {
+				return err
+			}
+			if _, exists := profiles.Profiles[args[0]]; !exists {
+				return fmt.Errorf("profile '%s' not found", args[0])
+			}
+
+			profiles.Current = args[0]
+			if err := profiles.Save(path); err != nil {
+				return err
+			}
+			printer.Print("✅", "[OK]", "Switched to profile '%s'\n", args[0])
+			return nil
+		},
+	}
+}
+
+// newProfileDeleteCommand creates the delete subcommand
+func newProfileDeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := config.DefaultProfilesPath()
+			profiles, err := config.LoadProfiles(path, false)
+			if err != nil {
+				return err
+			}
+			if _, exists := profiles.Profiles[args[0]]; !exists {
+				return fmt.Errorf("profile '%s' not found", args[0])
+			}
+
+			delete(profiles.Profiles, args[0])
+			if profiles.Current == args[0] {
+				profiles.Current = ""
+			}
+			if err := profiles.Save(path); err != nil {
+				return err
+			}
+			printer.Print("✅", "[OK]", "Deleted profile '%s'\n", args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,359 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// maxSchemaRefDepth bounds $ref resolution so recursive schemas terminate
+const maxSchemaRefDepth = 8
+
+// invalidToolNameChars matches characters not allowed in generated tool names
+var invalidToolNameChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// NewToolsCommand creates the tools command
+func NewToolsCommand(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tools",
+		Short: "Manage custom tools",
+		Long:  `Generate and manage custom tool definitions for the AI CLI.`,
+	}
+
+	cmd.AddCommand(newToolsImportOpenAPICommand())
+
+	return cmd
+}
+
+// newToolsImportOpenAPICommand creates the import-openapi subcommand
+func newToolsImportOpenAPICommand() *cobra.Command {
+	var spec, prefix, output string
+	var timeout int
+
+	cmd := &cobra.Command{
+		Use:   "import-openapi",
+		Short: "Generate custom tools from an OpenAPI 3.0 spec",
+		Long: `Download an OpenAPI 3.0 spec and write a custom tool definition for each POST, PUT and DELETE operation.
+Only the definitions are written: no command reads custom_tools_config, so nothing loads or runs these tools.`,
+		Example: "  mcp-cli tools import-openapi --spec https://api.example.com/openapi.json --prefix svc",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return importOpenAPITools(spec, prefix, output, time.Duration(timeout)*time.Second)
+		},
+	}
+
+	cmd.Flags().StringVar(&spec, "spec", "", "URL or file path of the OpenAPI 3.0 spec (JSON or YAML)")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Prefix for the generated tool names")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (defaults to ~/.config/mcp-servers/tools-<prefix>.yaml)")
+	cmd.Flags().IntVarP(&timeout, "timeout", "t", 30, "Download timeout in seconds")
+	cmd.MarkFlagRequired("spec")
+	cmd.MarkFlagRequired("prefix")
+
+	return cmd
+}
+
+// openAPISpec is the subset of an OpenAPI 3.0 document used to generate tools
+type openAPISpec struct {
+	OpenAPI string `yaml:"openapi"`
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Paths      map[string]openAPIPathItem `yaml:"paths"`
+	Components struct {
+		Schemas    map[string]interface{}      `yaml:"schemas"`
+		Parameters map[string]openAPIParameter `yaml:"parameters"`
+	} `yaml:"components"`
+}
+
+// openAPIPathItem holds the operations of one path that become tools
+type openAPIPathItem struct {
+	Parameters []openAPIParameter `yaml:"parameters"`
+	Post       *openAPIOperation  `yaml:"post"`
+	Put        *openAPIOperation  `yaml:"put"`
+	Delete     *openAPIOperation  `yaml:"delete"`
+}
+
+// openAPIOperation is a single API operation
+type openAPIOperation struct {
+	OperationID string             `yaml:"operationId"`
+	Summary     string             `yaml:"summary"`
+	Description string             `yaml:"description"`
+	Parameters  []openAPIParameter `yaml:"parameters"`
+	RequestBody *struct {
+		Required bool `yaml:"required"`
+		Content  map[string]struct {
+			Schema map[string]interface{} `yaml:"schema"`
+		} `yaml:"content"`
+	} `yaml:"requestBody"`
+}
+
+// openAPIParameter is a path, query or header parameter, or a reference to one
+type openAPIParameter struct {
+	Ref         string                 `yaml:"$ref"`
+	Name        string                 `yaml:"name"`
+	In          string                 `yaml:"in"`
+	Description string                 `yaml:"description"`
+	Required    bool                   `yaml:"required"`
+	Schema      map[string]interface{} `yaml:"schema"`
+}
+
+// importOpenAPITools converts the spec's mutating operations to custom tools and writes them to output
+func importOpenAPITools(specLocation, prefix, output string, timeout time.Duration) error {
+	if invalidToolNameChars.MatchString(prefix) {
+		return fmt.Errorf("prefix may only contain letters, digits and underscores")
+	}
+
+	data, err := readOpenAPISpec(specLocation, timeout)
+	if err != nil {
+		return err
+	}
+	var spec openAPISpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		return fmt.Errorf("unsupported OpenAPI version %q: only 3.x specs are supported", spec.OpenAPI)
+	}
+
+	baseURL, err := openAPIBaseURL(spec, specLocation)
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var tools config.CustomToolsFile
+	for _, path := range paths {
+		item := spec.Paths[path]
+		for _, op := range []struct {
+			method    string
+			operation *openAPIOperation
+		}{
+			{http.MethodPost, item.Post},
+			{http.MethodPut, item.Put},
+			{http.MethodDelete, item.Delete},
+		} {
+			if op.operation == nil {
+				continue
+			}
+			tools.Tools = append(tools.Tools, spec.toolDefinition(prefix, op.method, baseURL, path, item.Parameters, op.operation))
+		}
+	}
+	if len(tools.Tools) == 0 {
+		return fmt.Errorf("the spec has no POST, PUT or DELETE operations")
+	}
+
+	if output == "" {
+		output = config.CustomToolsPath(prefix)
+	}
+	if err := tools.Save(output); err != nil {
+		return err
+	}
+
+	printer.Print("✅", "[OK]", "Wrote %d tool(s) to %s\n", len(tools.Tools), output)
+	return nil
+}
+
+// readOpenAPISpec downloads the spec from a URL or reads it from a file
+func readOpenAPISpec(location string, timeout time.Duration) ([]byte, error) {
+	if !strings.HasPrefix(location, "http://") && !strings.HasPrefix(location, "https://") {
+		data, err := os.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read OpenAPI spec: %w", err)
+		}
+		return data, nil
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download OpenAPI spec: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download OpenAPI spec: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download OpenAPI spec: %w", err)
+	}
+	return data, nil
+}
+
+// openAPIBaseURL returns the spec's first server URL, resolved against the spec URL when relative
+func openAPIBaseURL(spec openAPISpec, specLocation string) (string, error) {
+	server := ""
+	if len(spec.Servers) > 0 {
+		server = spec.Servers[0].URL
+	}
+
+	base, err := url.Parse(server)
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL %q: %w", server, err)
+	}
+	if base.IsAbs() {
+		return strings.TrimSuffix(server, "/"), nil
+	}
+
+	location, err := url.Parse(specLocation)
+	if err != nil || !location.IsAbs() {
+		return "", fmt.Errorf("the spec has no absolute server URL; add one under servers")
+	}
+	return strings.TrimSuffix(location.ResolveReference(base).String(), "/"), nil
+}
+
+// toolDefinition builds the custom tool for one operation
+func (spec openAPISpec) toolDefinition(prefix, method, baseURL, path string, pathParameters []openAPIParameter, operation *openAPIOperation) config.CustomToolDefinition {
+	operationID := operation.OperationID
+	if operationID == "" {
+		operationID = strings.ToLower(method) + path
+	}
+	name := prefix + "_" + strings.Trim(invalidToolNameChars.ReplaceAllString(operationID, "_"), "_")
+
+	description := operation.Summary
+	if description == "" {
+		description = operation.Description
+	}
+	if description == "" {
+		description = method + " " + path
+	}
+
+	return config.CustomToolDefinition{
+		Name:        name,
+		Description: description,
+		Method:      method,
+		URL:         baseURL + path,
+		Parameters:  spec.toolParameters(append(append([]openAPIParameter(nil), pathParameters...), operation.Parameters...), operation),
+	}
+}
+
+// toolParameters merges the request body schema with the operation's path and query parameters into one object schema
+func (spec openAPISpec) toolParameters(parameters []openAPIParameter, operation *openAPIOperation) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	if operation.RequestBody != nil {
+		if schema := spec.requestBodySchema(operation); schema != nil {
+			if schema["type"] == "object" || schema["properties"] != nil {
+				if bodyProperties, ok := schema["properties"].(map[string]interface{}); ok {
+					for name, property := range bodyProperties {
+						properties[name] = property
+					}
+				}
+				if bodyRequired, ok := schema["required"].([]interface{}); ok {
+					for _, name := range bodyRequired {
+						if s, ok := name.(string); ok {
+							required = append(required, s)
+						}
+					}
+				}
+			} else {
+				properties["body"] = schema
+				if operation.RequestBody.Required {
+					required = append(required, "body")
+				}
+			}
+		}
+	}
+
+	for _, parameter := range parameters {
+		if parameter.Ref != "" {
+			resolved, ok := spec.Components.Parameters[strings.TrimPrefix(parameter.Ref, "#/components/parameters/")]
+			if !ok {
+				continue
+			}
+			parameter = resolved
+		}
+		if parameter.In != "path" && parameter.In != "query" {
+			continue
+		}
+		if _, exists := properties[parameter.Name]; exists {
+			continue
+		}
+
+		property := map[string]interface{}{"type": "string"}
+		if resolved, ok := spec.resolveSchema(parameter.Schema, 0).(map[string]interface{}); ok {
+			property = resolved
+		}
+		if parameter.Description != "" {
+			property["description"] = parameter.Description
+		}
+		properties[parameter.Name] = property
+		if parameter.Required || parameter.In == "path" {
+			required = append(required, parameter.Name)
+		}
+	}
+
+	result := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		result["required"] = required
+	}
+	return result
+}
+
+// requestBodySchema returns the resolved JSON request body schema, falling back to the first content type
+func (spec openAPISpec) requestBodySchema(operation *openAPIOperation) map[string]interface{} {
+	content := operation.RequestBody.Content
+	media, ok := content["application/json"]
+	if !ok {
+		types := make([]string, 0, len(content))
+		for contentType := range content {
+			types = append(types, contentType)
+		}
+		if len(types) == 0 {
+			return nil
+		}
+		sort.Strings(types)
+		media = content[types[0]]
+	}
+	if media.Schema == nil {
+		return nil
+	}
+	schema, _ := spec.resolveSchema(media.Schema, 0).(map[string]interface{})
+	return schema
+}
+
+// resolveSchema returns a copy of value with #/components/schemas references inlined
+func (spec openAPISpec) resolveSchema(value interface{}, depth int) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if ref, ok := v["$ref"].(string); ok {
+			target, found := spec.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+			if !found || depth >= maxSchemaRefDepth {
+				return map[string]interface{}{"type": "object"}
+			}
+			return spec.resolveSchema(target, depth+1)
+		}
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = spec.resolveSchema(item, depth)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = spec.resolveSchema(item, depth)
+		}
+		return out
+	default:
+		return value
+	}
+}
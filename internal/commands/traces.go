@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mcp-servers/cli/internal/config"
+	"github.com/mcp-servers/cli/pkg/nlp"
+	"github.com/spf13/cobra"
+)
+
+// traceQueryWidth is how much of each query traces list shows
+const traceQueryWidth = 60
+
+// NewTracesCommand creates the traces command
+func NewTracesCommand(cfg *config.Config) *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "traces",
+		Short: "Inspect recorded AI CLI traces",
+		Long:  `List and export the query and response traces the AI CLI records in its trace_path file.`,
+	}
+
+	cmd.PersistentFlags().StringVar(&file, "file", config.DefaultLLMConfig().TracePath, "Trace file to read (the AI CLI's trace_path)")
+
+	cmd.AddCommand(
+		newTracesListCommand(&file),
+		newTracesExportCommand(&file),
+	)
+
+	return cmd
+}
+
+// newTracesListCommand creates the list subcommand
+func newTracesListCommand(file *string) *cobra.Command {
+	var limit int
+	var since time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Show recent traces",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			traces, err := loadTraces(*file, since)
+			if err != nil {
+				return err
+			}
+			if limit > 0 && len(traces) > limit {
+				traces = traces[len(traces)-limit:]
+			}
+			return listTraces(os.Stdout, traces)
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Maximum number of traces to show (0 for all)")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show traces from this long ago, e.g. 24h (0 for all)")
+
+	return cmd
+}
+
+// newTracesExportCommand creates the export subcommand
+func newTracesExportCommand(file *string) *cobra.Command {
+	var format, output string
+	var since time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export traces as CSV or JSON",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			traces, err := loadTraces(*file, since)
+			if err != nil {
+				return err
+			}
+
+			w := io.Writer(os.Stdout)
+			if output != "" && output != "-" {
+				f, err := os.OpenFile(output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+			return exportTraces(w, traces, format)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "json", "Export format: csv or json")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only export traces from this long ago, e.g. 24h (0 for all)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (defaults to stdout)")
+
+	return cmd
+}
+
+// loadTraces reads the traces recorded within since, or all traces when since is zero
+func loadTraces(file string, since time.Duration) ([]nlp.Trace, error) {
+	var from time.Time
+	if since > 0 {
+		from = time.Now().Add(-since)
+	}
+
+	traces, err := nlp.ReadTraces(file, from)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no trace file at %s; set trace_path in the AI CLI configuration to record traces", file)
+	}
+	return traces, err
+}
+
+// listTraces prints one table row per trace
+func listTraces(w io.Writer, traces []nlp.Trace) error {
+	if len(traces) == 0 {
+		fmt.Fprintln(w, "No traces found.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTIME\tPROVIDER\tMODEL\tLATENCY\tTOKENS\tQUERY\t")
+	for _, trace := range traces {
+		query := strings.Join(strings.Fields(trace.Query), " ")
+		if len(query) > traceQueryWidth {
+			query = query[:traceQueryWidth-3] + "..."
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%dms\t%d\t%s\t\n", trace.ID, trace.Timestamp.Local().Format("2006-01-02 15:04:05"),
+			trace.Provider, trace.Model, trace.LatencyMs, trace.TokenCount, query)
+	}
+	return tw.Flush()
+}
+
+// exportTraces writes traces as a JSON array or as CSV with a header row
+func exportTraces(w io.Writer, traces []nlp.Trace, format string) error {
+	switch format {
+	case "json":
+		if traces == nil {
+			traces = []nlp.Trace{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(traces)
+	case "csv":
+		cw := csv.NewWriter(w)
+		cw.Write([]string{"id", "timestamp", "provider", "model", "query", "response", "tool_calls_json", "latency_ms", "token_count"})
+		for _, trace := range traces {
+			cw.Write([]string{
+				strconv.FormatInt(trace.ID, 10),
+				trace.Timestamp.Format(time.RFC3339),
+				trace.Provider,
+				trace.Model,
+				trace.Query,
+				trace.Response,
+				trace.ToolCallsJSON,
+				strconv.FormatInt(trace.LatencyMs, 10),
+				strconv.Itoa(trace.TokenCount),
+			})
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		return fmt.Errorf("unsupported format %q: use csv or json", format)
+	}
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/mcp-servers/cli/internal/config"
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"github.com/spf13/cobra"
+)
+
+// NewVersionCheckCommand creates the version-check command
+func NewVersionCheckCommand(cfg *config.Config) *cobra.Command {
+	var timeout int
+
+	cmd := &cobra.Command{
+		Use:   "version-check [server]",
+		Short: "Compare client and server MCP protocol versions",
+		Long:  `Initialize a session with a configured MCP server and print the protocol versions of both sides.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkProtocolVersion(cfg, args[0], time.Duration(timeout)*time.Second)
+		},
+	}
+
+	cmd.Flags().IntVarP(&timeout, "timeout", "t", 10, "Request timeout in seconds")
+
+	return cmd
+}
+
+// checkProtocolVersion initializes a session with the server and reports whether its protocol version is compatible
+func checkProtocolVersion(cfg *config.Config, serverName string, timeout time.Duration) error {
+	server, exists := cfg.Servers[serverName]
+	if !exists {
+		return fmt.Errorf("server '%s' not found", serverName)
+	}
+
+	serverVersion, err := initializeServer(server, timeout)
+	if err != nil {
+		return fmt.Errorf("failed to initialize server '%s': %w", serverName, err)
+	}
+
+	fmt.Printf("Client protocol version: %s\n", mcp.ProtocolVersion)
+	fmt.Printf("Server protocol version: %s\n", serverVersion)
+
+	warning, err := mcp.CheckProtocolVersion(serverVersion)
+	if err != nil {
+		printer.Print("❌", "[ERR]", "%v\n", err)
+		return fmt.Errorf("server '%s' is not compatible", serverName)
+	}
+	if warning != "" {
+		printer.Print("⚠️", "[WARN]", "%s\n", warning)
+		return nil
+	}
+	printer.Print("✅", "[OK]", "Protocol versions are compatible\n")
+	return nil
+}
+
+// initializeServer sends an initialize message to the server's /mcp endpoint and returns its protocol version
+func initializeServer(server config.ServerConfig, timeout time.Duration) (string, error) {
+	msg, err := mcp.NewMessage(mcp.MessageTypeInitialize, "version-check-1", mcp.InitializeRequest{
+		ProtocolVersion: mcp.ProtocolVersion,
+		ClientInfo:      mcp.ClientInfo{Name: "mcp-cli", Version: "1.0.0"},
+	})
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := newServerRequest(server, http.MethodPost, "/mcp", bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := serverHTTPClient(server, timeout).Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	var response mcp.Message
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("unexpected response (status %d): %w", resp.StatusCode, err)
+	}
+	if response.Type == mcp.MessageTypeError {
+		var mcpErr mcp.Error
+		if err := response.UnmarshalData(&mcpErr); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("%s", mcpErr.Message)
+	}
+
+	var init mcp.InitializationResponse
+	if err := response.UnmarshalData(&init); err != nil {
+		return "", err
+	}
+	return init.ProtocolVersion, nil
+}
@@ -54,11 +54,16 @@ type ServerConfig struct {
 
 // AuthConfig contains authentication settings
 type AuthConfig struct {
-	Type     string            `yaml:"type" mapstructure:"type"` // none, basic, token, oauth2
+	Type     string            `yaml:"type" mapstructure:"type"` // none, basic, token, oauth2, oidc
 	Username string            `yaml:"username" mapstructure:"username"`
 	Password string            `yaml:"password" mapstructure:"password"`
 	Token    string            `yaml:"token" mapstructure:"token"`
 	Headers  map[string]string `yaml:"headers" mapstructure:"headers"`
+
+	// OIDC client credentials, used when Type is oidc
+	Issuer       string `yaml:"issuer" mapstructure:"issuer"`
+	ClientID     string `yaml:"client_id" mapstructure:"client_id"`
+	ClientSecret string `yaml:"client_secret" mapstructure:"client_secret"`
 }
 
 // TLSConfig contains TLS/SSL settings
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/mcp-servers/cli/pkg/llm"
 	"gopkg.in/yaml.v3"
@@ -31,12 +32,20 @@ type LLMConfig struct {
 	ExternalTools bool `yaml:"external_tools" json:"external_tools"`
 
 	// Runtime settings
-	MaxIterations int  `yaml:"max_iterations" json:"max_iterations"`
-	Quiet         bool `yaml:"quiet" json:"quiet"`
-	RemoveWorkdir bool `yaml:"remove_workdir" json:"remove_workdir"`
+	MaxIterations  int  `yaml:"max_iterations" json:"max_iterations"`
+	Quiet          bool `yaml:"quiet" json:"quiet"`
+	RemoveWorkdir  bool `yaml:"remove_workdir" json:"remove_workdir"`
+	MaxQueryLength int  `yaml:"max_query_length" json:"max_query_length"`
+
+	// QueryCacheTTL is how long identical queries are answered from cache; zero disables caching
+	QueryCacheTTL time.Duration `yaml:"query_cache_ttl" json:"query_cache_ttl"`
 
 	// Kubernetes configuration
-	Kubeconfig string `yaml:"kubeconfig" json:"kubeconfig"`
+	Kubeconfig  string `yaml:"kubeconfig" json:"kubeconfig"`
+	KMSEndpoint string `yaml:"kms_endpoint" json:"kms_endpoint"`
+
+	// ContextPrompts maps kubeconfig context names to extra system prompt text
+	ContextPrompts map[string]string `yaml:"context_prompts" json:"context_prompts"`
 
 	// UI configuration
 	UserInterface   string `yaml:"user_interface" json:"user_interface"`
@@ -48,6 +57,21 @@ type LLMConfig struct {
 
 	// Debug and trace settings
 	TracePath string `yaml:"trace_path" json:"trace_path"`
+
+	// Monitoring settings
+	MonitoringListenAddress string `yaml:"monitoring_listen_address" json:"monitoring_listen_address"`
+	SLOp99LatencyMs         int    `yaml:"slo_p99_latency_ms" json:"slo_p99_latency_ms"`
+
+	// HealthScoreWeights weights the parts of the MCP server's health_score_namespace score
+	HealthScoreWeights HealthScoreWeights `yaml:"health_score_weights" json:"health_score_weights"`
+}
+
+// HealthScoreWeights holds the relative weight of each part of a namespace health score; only their ratios matter
+type HealthScoreWeights struct {
+	Pods        float64 `yaml:"pods" json:"pods"`
+	Deployments float64 `yaml:"deployments" json:"deployments"`
+	Events      float64 `yaml:"events" json:"events"`
+	Quota       float64 `yaml:"quota" json:"quota"`
 }
 
 // DefaultLLMConfig returns default configuration
@@ -67,29 +91,56 @@ func DefaultLLMConfig() *LLMConfig {
 		MaxIterations:          20,
 		Quiet:                  false,
 		RemoveWorkdir:          false,
+		MaxQueryLength:         2048,
+		QueryCacheTTL:          30 * time.Second,
 		Kubeconfig:             "~/.kube/config",
 		UserInterface:          "terminal",
 		UIListenAddress:        "localhost:8888",
 		PromptTemplateFilePath: "",
 		ExtraPromptPaths:       []string{},
-		TracePath:              "/tmp/mcp-servers-trace.txt",
+		TracePath:              "~/.config/mcp-servers/traces.jsonl",
+		SLOp99LatencyMs:        10000,
+		HealthScoreWeights:     HealthScoreWeights{Pods: 40, Deployments: 30, Events: 15, Quota: 15},
 	}
 }
 
-// LoadLLMConfig loads LLM configuration from file and environment
+// LoadLLMConfig loads LLM configuration from the current profile, file and environment, expanding ${VAR} references in config files
 func LoadLLMConfig(configPath string) (*LLMConfig, error) {
+	return LoadLLMConfigProfile(configPath, "", true)
+}
+
+// LoadLLMConfigRaw loads LLM configuration like LoadLLMConfig but reads config files without expanding ${VAR} references
+func LoadLLMConfigRaw(configPath string) (*LLMConfig, error) {
+	return LoadLLMConfigProfile(configPath, "", false)
+}
+
+// LoadLLMConfigProfile loads LLM configuration starting from the named profile, or the current profile when profile is empty.
+// File and environment settings are applied on top of the profile.
+func LoadLLMConfigProfile(configPath, profile string, expandEnv bool) (*LLMConfig, error) {
+	profiles, err := LoadProfiles(DefaultProfilesPath(), expandEnv)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := profiles.Resolve(profile)
+	if err != nil {
+		return nil, err
+	}
+
 	config := DefaultLLMConfig()
+	if selected != nil {
+		config = &selected.LLMConfig
+	}
 
 	// Load from config file if provided
 	if configPath != "" {
-		if err := loadConfigFromFile(config, configPath); err != nil {
+		if err := loadConfigFromFile(config, configPath, expandEnv); err != nil {
 			return nil, fmt.Errorf("failed to load config from file: %w", err)
 		}
 	}
 
 	// Load from default config location
 	defaultConfigPath := getDefaultConfigPath()
-	if err := loadConfigFromFile(config, defaultConfigPath); err != nil && !os.IsNotExist(err) {
+	if err := loadConfigFromFile(config, defaultConfigPath, expandEnv); err != nil && !os.IsNotExist(err) {
 		return nil, fmt.Errorf("failed to load default config: %w", err)
 	}
 
@@ -104,8 +155,8 @@ func LoadLLMConfig(configPath string) (*LLMConfig, error) {
 	return config, nil
 }
 
-// loadConfigFromFile loads configuration from a YAML file
-func loadConfigFromFile(config *LLMConfig, configPath string) error {
+// loadConfigFromFile loads configuration from a YAML file, optionally expanding environment variables first
+func loadConfigFromFile(config *LLMConfig, configPath string, expandEnv bool) error {
 	// Expand home directory
 	if strings.HasPrefix(configPath, "~") {
 		home, err := os.UserHomeDir()
@@ -125,6 +176,9 @@ func loadConfigFromFile(config *LLMConfig, configPath string) error {
 	if err != nil {
 		return fmt.Errorf("failed to read config file: %w", err)
 	}
+	if expandEnv {
+		data = []byte(ExpandEnv(string(data)))
+	}
 
 	if err := yaml.Unmarshal(data, config); err != nil {
 		return fmt.Errorf("failed to parse config file: %w", err)
@@ -133,6 +187,23 @@ func loadConfigFromFile(config *LLMConfig, configPath string) error {
 	return nil
 }
 
+// ExpandEnv replaces $VAR, ${VAR} and ${VAR:-default} with environment values; the default applies when VAR is unset or empty.
+// Unset variables without a default expand to the empty string, and $$ produces a literal $.
+func ExpandEnv(s string) string {
+	return os.Expand(s, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		if name, fallback, ok := strings.Cut(name, ":-"); ok {
+			if value := os.Getenv(name); value != "" {
+				return value
+			}
+			return fallback
+		}
+		return os.Getenv(name)
+	})
+}
+
 // loadConfigFromEnv loads configuration from environment variables
 func loadConfigFromEnv(config *LLMConfig) {
 	// LLM provider settings
@@ -167,6 +238,43 @@ func loadConfigFromEnv(config *LLMConfig) {
 	if kubeconfig := os.Getenv("KUBECONFIG"); kubeconfig != "" {
 		config.Kubeconfig = kubeconfig
 	}
+	if kmsEndpoint := os.Getenv("KMS_ENDPOINT"); kmsEndpoint != "" {
+		config.KMSEndpoint = kmsEndpoint
+	}
+}
+
+// llmEnvVars are the environment variables loadConfigFromEnv recognizes
+var llmEnvVars = []string{
+	"LLM_PROVIDER",
+	"LLM_MODEL",
+	"LLM_API_KEY",
+	"OPENAI_API_KEY",
+	"GEMINI_API_KEY",
+	"OPENROUTER_API_KEY",
+	"MCP_SERVER",
+	"MCP_CLIENT",
+	"KUBECONFIG",
+	"KMS_ENDPOINT",
+}
+
+// LLMConfigFromEnv builds a configuration from the defaults and environment variables alone, ignoring profiles and
+// config files, and returns the recognized variables that are set
+func LLMConfigFromEnv() (*LLMConfig, []string) {
+	config := DefaultLLMConfig()
+	loadConfigFromEnv(config)
+
+	var found []string
+	for _, name := range llmEnvVars {
+		if os.Getenv(name) != "" {
+			found = append(found, name)
+		}
+	}
+	return config, found
+}
+
+// Validate checks the configuration for missing or out of range settings
+func (c *LLMConfig) Validate() error {
+	return validateLLMConfig(c)
 }
 
 // validateLLMConfig validates the configuration
@@ -199,9 +307,38 @@ func validateLLMConfig(config *LLMConfig) error {
 		return fmt.Errorf("max_tokens must be positive")
 	}
 
+	// Validate max query length
+	if config.MaxQueryLength <= 0 {
+		return fmt.Errorf("max_query_length must be positive")
+	}
+
+	// Validate query cache TTL
+	if config.QueryCacheTTL < 0 {
+		return fmt.Errorf("query_cache_ttl must not be negative")
+	}
+
+	// Validate SLO target
+	if config.SLOp99LatencyMs < 0 {
+		return fmt.Errorf("slo_p99_latency_ms must not be negative")
+	}
+
+	// Validate health score weights
+	weights := config.HealthScoreWeights
+	if weights.Pods < 0 || weights.Deployments < 0 || weights.Events < 0 || weights.Quota < 0 {
+		return fmt.Errorf("health_score_weights must not be negative")
+	}
+	if weights.Pods+weights.Deployments+weights.Events+weights.Quota == 0 {
+		return fmt.Errorf("health_score_weights must not all be zero")
+	}
+
 	return nil
 }
 
+// DefaultLLMConfigPath returns the configuration file every load reads after the profile and --config file
+func DefaultLLMConfigPath() string {
+	return getDefaultConfigPath()
+}
+
 // getDefaultConfigPath returns the default configuration file path
 func getDefaultConfigPath() string {
 	home, err := os.UserHomeDir()
@@ -248,8 +385,8 @@ func (c *LLMConfig) SaveConfig(configPath string) error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	// Write to file
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	// Write to file, readable only by the user since it holds the API key
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
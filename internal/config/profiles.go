@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is a named environment, such as dev or production, with its own LLM and cluster settings
+type Profile struct {
+	LLMConfig `yaml:",inline"`
+
+	// MCPServerURL is the MCP server that clients using the profile connect to
+	MCPServerURL string `yaml:"mcp_server_url,omitempty" json:"mcp_server_url,omitempty"`
+}
+
+// ProfilesFile holds the saved profiles and the one in use
+type ProfilesFile struct {
+	Current  string              `yaml:"current"`
+	Profiles map[string]*Profile `yaml:"profiles"`
+}
+
+// DefaultProfilesPath returns ~/.config/mcp-servers/profiles.yaml
+func DefaultProfilesPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "~/.config/mcp-servers/profiles.yaml"
+	}
+	return filepath.Join(home, ".config", "mcp-servers", "profiles.yaml")
+}
+
+// LoadProfiles reads a profiles file, optionally expanding environment variables first.
+// Settings a profile omits keep their DefaultLLMConfig values. A missing file yields no profiles.
+func LoadProfiles(path string, expandEnv bool) (*ProfilesFile, error) {
+	profiles := &ProfilesFile{Profiles: map[string]*Profile{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+	if expandEnv {
+		data = []byte(ExpandEnv(string(data)))
+	}
+
+	var raw struct {
+		Current  string               `yaml:"current"`
+		Profiles map[string]yaml.Node `yaml:"profiles"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles: %w", err)
+	}
+
+	profiles.Current = raw.Current
+	for name, node := range raw.Profiles {
+		profile := &Profile{LLMConfig: *DefaultLLMConfig()}
+		if err := node.Decode(profile); err != nil {
+			return nil, fmt.Errorf("failed to parse profile %s: %w", name, err)
+		}
+		profiles.Profiles[name] = profile
+	}
+	return profiles, nil
+}
+
+// Save writes the profiles file readable only by the current user, since profiles hold API keys
+func (f *ProfilesFile) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("failed to marshal profiles: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write profiles: %w", err)
+	}
+	return os.Chmod(path, 0600)
+}
+
+// Names returns the profile names in sorted order
+func (f *ProfilesFile) Names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve returns the named profile, or the current profile when name is empty.
+// It returns nil without error when name is empty and no profile is current.
+func (f *ProfilesFile) Resolve(name string) (*Profile, error) {
+	if name == "" {
+		name = f.Current
+		if name == "" {
+			return nil, nil
+		}
+	}
+
+	profile, ok := f.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in %s", name, DefaultProfilesPath())
+	}
+	return profile, nil
+}
+
+// LoadProfile returns the named profile from the default profiles file, or the current profile when name is empty
+func LoadProfile(name string) (*Profile, error) {
+	profiles, err := LoadProfiles(DefaultProfilesPath(), true)
+	if err != nil {
+		return nil, err
+	}
+	return profiles.Resolve(name)
+}
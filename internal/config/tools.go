@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CustomToolDefinition describes a tool that calls an HTTP endpoint, as listed in a custom_tools_config file
+type CustomToolDefinition struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description"`
+	Method      string `yaml:"method" json:"method"`
+	// URL may contain {name} placeholders filled from path parameters
+	URL        string                 `yaml:"url" json:"url"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+}
+
+// CustomToolsFile is the layout of a custom_tools_config file
+type CustomToolsFile struct {
+	Tools []CustomToolDefinition `yaml:"tools" json:"tools"`
+}
+
+// CustomToolsPath returns the default location of the custom tools file for name, e.g. tools-svc.yaml
+func CustomToolsPath(name string) string {
+	return filepath.Join(filepath.Dir(getDefaultConfigPath()), "tools-"+name+".yaml")
+}
+
+// Save writes the custom tools file as YAML, creating its directory if needed
+func (f *CustomToolsFile) Save(path string) error {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+		path = filepath.Join(home, strings.TrimPrefix(path[1:], "/"))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return fmt.Errorf("failed to marshal custom tools: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write custom tools file: %w", err)
+	}
+	return nil
+}
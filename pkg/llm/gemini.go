@@ -3,6 +3,7 @@ package llm
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/google/generative-ai-go/genai"
 	"google.golang.org/api/option"
@@ -10,6 +11,7 @@ import (
 
 // GeminiProvider implements the Provider interface for Google Gemini
 type GeminiProvider struct {
+	mu     sync.RWMutex
 	client *genai.Client
 	model  *genai.GenerativeModel
 	config Config
@@ -21,10 +23,24 @@ func NewGeminiProvider(config Config) (Provider, error) {
 		return nil, fmt.Errorf("Gemini API key is required")
 	}
 
+	client, model, err := newGeminiModel(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GeminiProvider{
+		client: client,
+		model:  model,
+		config: config,
+	}, nil
+}
+
+// newGeminiModel creates a Gemini client and configures its generative model
+func newGeminiModel(config Config) (*genai.Client, *genai.GenerativeModel, error) {
 	ctx := context.Background()
 	client, err := genai.NewClient(ctx, option.WithAPIKey(config.APIKey))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
+		return nil, nil, fmt.Errorf("failed to create Gemini client: %w", err)
 	}
 
 	modelName := config.Model
@@ -42,16 +58,16 @@ func NewGeminiProvider(config Config) (Provider, error) {
 		model.Temperature = &temperature
 	}
 
-	return &GeminiProvider{
-		client: client,
-		model:  model,
-		config: config,
-	}, nil
+	return client, model, nil
 }
 
 // GenerateResponse generates a response using Gemini
 func (p *GeminiProvider) GenerateResponse(ctx context.Context, prompt string) (string, error) {
-	resp, err := p.model.GenerateContent(ctx, genai.Text(prompt))
+	p.mu.RLock()
+	model := p.model
+	p.mu.RUnlock()
+
+	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
 	if err != nil {
 		return "", fmt.Errorf("failed to generate response: %w", err)
 	}
@@ -78,3 +94,29 @@ func (p *GeminiProvider) GetModel() string {
 func (p *GeminiProvider) GetProvider() string {
 	return "gemini"
 }
+
+// RotateAPIKey replaces the API key and rebuilds the underlying client
+func (p *GeminiProvider) RotateAPIKey(newKey string) error {
+	if newKey == "" {
+		return fmt.Errorf("Gemini API key is required")
+	}
+
+	p.mu.RLock()
+	config := p.config
+	p.mu.RUnlock()
+	config.APIKey = newKey
+
+	client, model, err := newGeminiModel(config)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	oldClient := p.client
+	p.client = client
+	p.model = model
+	p.config = config
+	p.mu.Unlock()
+
+	return oldClient.Close()
+}
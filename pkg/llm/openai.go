@@ -3,13 +3,17 @@ package llm
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"sync"
 
 	openai "github.com/sashabaranov/go-openai"
 )
 
 // OpenAIProvider implements the Provider interface for OpenAI
 type OpenAIProvider struct {
+	mu     sync.RWMutex
 	client *openai.Client
 	config Config
 	model  string
@@ -43,7 +47,7 @@ func NewOpenAIProvider(config Config) (Provider, error) {
 
 // GenerateResponse generates a response using OpenAI
 func (p *OpenAIProvider) GenerateResponse(ctx context.Context, prompt string) (string, error) {
-	resp, err := p.client.CreateChatCompletion(
+	resp, err := p.getClient().CreateChatCompletion(
 		ctx,
 		openai.ChatCompletionRequest{
 			Model: p.model,
@@ -79,6 +83,28 @@ func (p *OpenAIProvider) GetProvider() string {
 	return "openai"
 }
 
+// RotateAPIKey replaces the API key and rebuilds the underlying client
+func (p *OpenAIProvider) RotateAPIKey(newKey string) error {
+	if newKey == "" {
+		return fmt.Errorf("OpenAI API key is required")
+	}
+
+	client := openai.NewClientWithConfig(openai.DefaultConfig(newKey))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.client = client
+	p.config.APIKey = newKey
+	return nil
+}
+
+// getClient returns the current client under the read lock
+func (p *OpenAIProvider) getClient() *openai.Client {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.client
+}
+
 // GenerateResponseWithTools generates a response with tool calls
 func (p *OpenAIProvider) GenerateResponseWithTools(ctx context.Context, query Query) (*Response, error) {
 	// Build system message with tools
@@ -86,6 +112,9 @@ func (p *OpenAIProvider) GenerateResponseWithTools(ctx context.Context, query Qu
 	for _, tool := range query.Tools {
 		systemMessage += fmt.Sprintf("\n- %s: %s", tool.Name, tool.Description)
 	}
+	if query.SystemPrompt != "" {
+		systemMessage = query.SystemPrompt + "\n\n" + systemMessage
+	}
 
 	// Build messages
 	messages := []openai.ChatCompletionMessage{
@@ -138,7 +167,7 @@ func (p *OpenAIProvider) GenerateResponseWithTools(ctx context.Context, query Qu
 		req.ToolChoice = "auto"
 	}
 
-	resp, err := p.client.CreateChatCompletion(ctx, req)
+	resp, err := p.getClient().CreateChatCompletion(ctx, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate response: %w", err)
 	}
@@ -150,6 +179,11 @@ func (p *OpenAIProvider) GenerateResponseWithTools(ctx context.Context, query Qu
 	choice := resp.Choices[0]
 	response := &Response{
 		Content: choice.Message.Content,
+		Metadata: map[string]interface{}{
+			"prompt_tokens":     resp.Usage.PromptTokens,
+			"completion_tokens": resp.Usage.CompletionTokens,
+			"total_tokens":      resp.Usage.TotalTokens,
+		},
 	}
 
 	// Extract tool calls
@@ -174,3 +208,66 @@ func parseJSONArguments(args string) map[string]interface{} {
 	}
 	return result
 }
+
+// GenerateResponseStream streams a plain-text answer without tool calls
+func (p *OpenAIProvider) GenerateResponseStream(ctx context.Context, query Query, out chan<- string) error {
+	messages := []openai.ChatCompletionMessage{
+		{
+			Role:    openai.ChatMessageRoleSystem,
+			Content: streamSystemMessage(query),
+		},
+	}
+	for _, msg := range query.History {
+		role := openai.ChatMessageRoleUser
+		if msg.Role == "assistant" {
+			role = openai.ChatMessageRoleAssistant
+		}
+		messages = append(messages, openai.ChatCompletionMessage{
+			Role:    role,
+			Content: msg.Content,
+		})
+	}
+	messages = append(messages, openai.ChatCompletionMessage{
+		Role:    openai.ChatMessageRoleUser,
+		Content: query.Text,
+	})
+
+	stream, err := p.getClient().CreateChatCompletionStream(ctx, openai.ChatCompletionRequest{
+		Model:       p.model,
+		Messages:    messages,
+		MaxTokens:   p.config.MaxTokens,
+		Temperature: float32(p.config.Temperature),
+		Stream:      true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start response stream: %w", err)
+	}
+	defer stream.Close()
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read response stream: %w", err)
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		select {
+		case out <- chunk.Choices[0].Delta.Content:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// streamSystemMessage builds the system message for streamed answers, which cannot carry tool calls
+func streamSystemMessage(query Query) string {
+	systemMessage := "You are a Kubernetes assistant. Answer in plain text; when a kubectl command helps, include it in the answer."
+	if query.SystemPrompt != "" {
+		systemMessage = query.SystemPrompt + "\n\n" + systemMessage
+	}
+	return systemMessage
+}
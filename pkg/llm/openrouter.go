@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -8,11 +9,13 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
 // OpenRouterProvider implements the Provider interface for OpenRouter
 type OpenRouterProvider struct {
+	mu      sync.RWMutex
 	client  *http.Client
 	config  Config
 	apiKey  string
@@ -27,6 +30,7 @@ type OpenRouterRequest struct {
 	Temperature float64   `json:"temperature,omitempty"`
 	Tools       []Tool    `json:"tools,omitempty"`
 	ToolChoice  string    `json:"tool_choice,omitempty"`
+	Stream      bool      `json:"stream,omitempty"`
 }
 
 // OpenRouterResponse represents the response from OpenRouter API
@@ -43,6 +47,18 @@ type OpenRouterResponse struct {
 	} `json:"error,omitempty"`
 }
 
+// OpenRouterStreamChunk represents one server-sent event of a streamed OpenRouter response
+type OpenRouterStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
 // NewOpenRouterProvider creates a new OpenRouter provider
 func NewOpenRouterProvider(config Config) (Provider, error) {
 	if config.APIKey == "" {
@@ -95,6 +111,19 @@ func (p *OpenRouterProvider) GetProvider() string {
 	return "openrouter"
 }
 
+// RotateAPIKey replaces the API key used for subsequent requests
+func (p *OpenRouterProvider) RotateAPIKey(newKey string) error {
+	if newKey == "" {
+		return fmt.Errorf("OpenRouter API key is required")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.apiKey = newKey
+	p.config.APIKey = newKey
+	return nil
+}
+
 // GenerateResponseWithTools generates a response with tool calls
 func (p *OpenRouterProvider) GenerateResponseWithTools(ctx context.Context, query Query) (*Response, error) {
 	// Build system message with tools
@@ -102,6 +131,9 @@ func (p *OpenRouterProvider) GenerateResponseWithTools(ctx context.Context, quer
 	for _, tool := range query.Tools {
 		systemMessage += fmt.Sprintf("- %s: %s\n", tool.Name, tool.Description)
 	}
+	if query.SystemPrompt != "" {
+		systemMessage = query.SystemPrompt + "\n\n" + systemMessage
+	}
 
 	// Build messages
 	messages := []Message{
@@ -113,8 +145,13 @@ func (p *OpenRouterProvider) GenerateResponseWithTools(ctx context.Context, quer
 
 	// Add conversation history
 	for _, msg := range query.History {
+		role := msg.Role
+		if role == "tool" {
+			// Tool results are not tied to a tool_call_id, so send them as user content
+			role = "user"
+		}
 		messages = append(messages, Message{
-			Role:    msg.Role,
+			Role:    role,
 			Content: msg.Content,
 		})
 	}
@@ -169,7 +206,11 @@ func (p *OpenRouterProvider) makeRequest(ctx context.Context, payload OpenRouter
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	p.mu.RLock()
+	apiKey := p.apiKey
+	p.mu.RUnlock()
+
+	req.Header.Set("Authorization", "Bearer "+apiKey)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("HTTP-Referer", "https://mcp-servers-cli")
 	req.Header.Set("X-Title", "MCP Servers CLI")
@@ -235,3 +276,87 @@ func (p *OpenRouterProvider) parseToolCallsFromResponse(content string) []ToolCa
 
 	return toolCalls
 }
+
+// GenerateResponseStream streams a plain-text answer without tool calls by parsing server-sent events
+func (p *OpenRouterProvider) GenerateResponseStream(ctx context.Context, query Query, out chan<- string) error {
+	messages := []Message{{Role: "system", Content: streamSystemMessage(query)}}
+	for _, msg := range query.History {
+		role := msg.Role
+		if role == "tool" {
+			role = "user"
+		}
+		messages = append(messages, Message{Role: role, Content: msg.Content})
+	}
+	messages = append(messages, Message{Role: "user", Content: query.Text})
+
+	jsonData, err := json.Marshal(OpenRouterRequest{
+		Model:       p.config.Model,
+		Messages:    messages,
+		MaxTokens:   p.config.MaxTokens,
+		Temperature: p.config.Temperature,
+		Stream:      true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+"/chat/completions", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	p.mu.RLock()
+	apiKey := p.apiKey
+	p.mu.RUnlock()
+
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("HTTP-Referer", "https://mcp-servers-cli")
+	req.Header.Set("X-Title", "MCP Servers CLI")
+
+	// Streams can outlast the client timeout, so rely on the context instead
+	resp, err := (&http.Client{Transport: p.client.Transport}).Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// Skip blank separators and ": OPENROUTER PROCESSING" keep-alive comments
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return nil
+		}
+
+		var chunk OpenRouterStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return fmt.Errorf("failed to unmarshal stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return fmt.Errorf("OpenRouter API error: %s", chunk.Error.Message)
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		select {
+		case out <- chunk.Choices[0].Delta.Content:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read response stream: %w", err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package llm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 )
 
@@ -17,6 +18,21 @@ type Provider interface {
 	GetProvider() string
 }
 
+// KeyRotator is implemented by providers that can swap their API key at runtime
+type KeyRotator interface {
+	// RotateAPIKey replaces the API key used for subsequent requests
+	RotateAPIKey(newKey string) error
+}
+
+// StreamingProvider is implemented by providers that can stream a plain-text answer as it is generated
+type StreamingProvider interface {
+	// GenerateResponseStream sends content tokens to out as they arrive and returns when the stream ends; it does not close out
+	GenerateResponseStream(ctx context.Context, query Query, out chan<- string) error
+}
+
+// ErrStreamingNotSupported is returned when a provider cannot stream responses
+var ErrStreamingNotSupported = errors.New("provider does not support streaming")
+
 // Config holds LLM configuration
 type Config struct {
 	Provider      string  `yaml:"provider" json:"provider"`
@@ -47,6 +63,8 @@ type Query struct {
 	Context map[string]interface{} `json:"context,omitempty"`
 	Tools   []Tool                 `json:"tools,omitempty"`
 	History []Message              `json:"history,omitempty"`
+	// SystemPrompt is extra instruction text placed ahead of the default system message
+	SystemPrompt string `json:"system_prompt,omitempty"`
 }
 
 // Tool represents an available tool for the LLM
@@ -58,7 +76,7 @@ type Tool struct {
 
 // Message represents a conversation message
 type Message struct {
-	Role    string `json:"role"` // "user", "assistant", "system"
+	Role    string `json:"role"` // "user", "assistant", "system", "tool"
 	Content string `json:"content"`
 }
 
@@ -0,0 +1,217 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultLatencyBuckets are the histogram bucket upper bounds in seconds
+var DefaultLatencyBuckets = []float64{1, 2, 5, 10, 30}
+
+// sloWindowSize is the number of recent responses used for rolling percentiles
+const sloWindowSize = 1000
+
+// ResponseTimeHistogram is a cumulative histogram of response times
+type ResponseTimeHistogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	counts  []uint64
+	sum     float64
+	count   uint64
+}
+
+// NewResponseTimeHistogram creates a histogram with the given bucket upper bounds in seconds
+func NewResponseTimeHistogram(buckets []float64) *ResponseTimeHistogram {
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &ResponseTimeHistogram{
+		buckets: sorted,
+		counts:  make([]uint64, len(sorted)),
+	}
+}
+
+// Observe records a single response time
+func (h *ResponseTimeHistogram) Observe(d time.Duration) {
+	seconds := d.Seconds()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, bound := range h.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// WritePrometheus writes the histogram in the Prometheus text exposition format
+func (h *ResponseTimeHistogram) WritePrometheus(w io.Writer, name string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, err := fmt.Fprintf(w, "# TYPE %s histogram\n", name); err != nil {
+		return err
+	}
+	for i, bound := range h.buckets {
+		if _, err := fmt.Fprintf(w, "%s_bucket{le=\"%g\"} %d\n", name, bound, h.counts[i]); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n%s_sum %g\n%s_count %d\n",
+		name, h.count, name, h.sum, name, h.count)
+	return err
+}
+
+// SLOReport summarizes recent response latency against the SLO target
+type SLOReport struct {
+	P50Ms       float64 `json:"p50_ms"`
+	P99Ms       float64 `json:"p99_ms"`
+	SLOTargetMs int     `json:"slo_target_ms"`
+	Compliant   bool    `json:"compliant"`
+}
+
+// SLOTracker wraps a Provider and tracks response latency against a p99 target
+type SLOTracker struct {
+	provider  Provider
+	targetMs  int
+	histogram *ResponseTimeHistogram
+
+	mu     sync.Mutex
+	window []time.Duration
+	next   int
+}
+
+// NewSLOTracker wraps provider, warning when the rolling p99 latency exceeds targetMs
+func NewSLOTracker(provider Provider, targetMs int) *SLOTracker {
+	return &SLOTracker{
+		provider:  provider,
+		targetMs:  targetMs,
+		histogram: NewResponseTimeHistogram(DefaultLatencyBuckets),
+	}
+}
+
+// GenerateResponse generates a response and records its latency
+func (t *SLOTracker) GenerateResponse(ctx context.Context, prompt string) (string, error) {
+	start := time.Now()
+	response, err := t.provider.GenerateResponse(ctx, prompt)
+	t.record(time.Since(start))
+	return response, err
+}
+
+// GenerateResponseWithTools generates a tool-aware response and records its latency
+func (t *SLOTracker) GenerateResponseWithTools(ctx context.Context, query Query) (*Response, error) {
+	toolProvider, ok := t.provider.(interface {
+		GenerateResponseWithTools(context.Context, Query) (*Response, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not support tool calls", t.provider.GetProvider())
+	}
+
+	start := time.Now()
+	response, err := toolProvider.GenerateResponseWithTools(ctx, query)
+	t.record(time.Since(start))
+	return response, err
+}
+
+// GenerateResponseStream streams a response and records the latency of the whole stream
+func (t *SLOTracker) GenerateResponseStream(ctx context.Context, query Query, out chan<- string) error {
+	streamer, ok := t.provider.(StreamingProvider)
+	if !ok {
+		return fmt.Errorf("provider %s: %w", t.provider.GetProvider(), ErrStreamingNotSupported)
+	}
+
+	start := time.Now()
+	err := streamer.GenerateResponseStream(ctx, query, out)
+	t.record(time.Since(start))
+	return err
+}
+
+// GetModel returns the wrapped provider's model
+func (t *SLOTracker) GetModel() string {
+	return t.provider.GetModel()
+}
+
+// GetProvider returns the wrapped provider's name
+func (t *SLOTracker) GetProvider() string {
+	return t.provider.GetProvider()
+}
+
+// RotateAPIKey rotates the wrapped provider's API key if it supports rotation
+func (t *SLOTracker) RotateAPIKey(newKey string) error {
+	rotator, ok := t.provider.(KeyRotator)
+	if !ok {
+		return fmt.Errorf("provider %s does not support API key rotation", t.provider.GetProvider())
+	}
+	return rotator.RotateAPIKey(newKey)
+}
+
+// Histogram returns the response time histogram
+func (t *SLOTracker) Histogram() *ResponseTimeHistogram {
+	return t.histogram
+}
+
+// record adds a response time to the histogram and rolling window and checks the SLO
+func (t *SLOTracker) record(d time.Duration) {
+	t.histogram.Observe(d)
+
+	t.mu.Lock()
+	if len(t.window) < sloWindowSize {
+		t.window = append(t.window, d)
+	} else {
+		t.window[t.next] = d
+		t.next = (t.next + 1) % sloWindowSize
+	}
+	t.mu.Unlock()
+
+	if report := t.Report(); !report.Compliant {
+		logrus.Warnf("LLM p99 latency %.0fms exceeds SLO target of %dms", report.P99Ms, t.targetMs)
+	}
+}
+
+// Report computes rolling latency percentiles and SLO compliance
+func (t *SLOTracker) Report() SLOReport {
+	t.mu.Lock()
+	samples := append([]time.Duration(nil), t.window...)
+	t.mu.Unlock()
+
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	p99 := percentileMs(samples, 0.99)
+	return SLOReport{
+		P50Ms:       percentileMs(samples, 0.50),
+		P99Ms:       p99,
+		SLOTargetMs: t.targetMs,
+		Compliant:   t.targetMs <= 0 || p99 <= float64(t.targetMs),
+	}
+}
+
+// ServeHTTP serves the SLO report as JSON
+func (t *SLOTracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(t.Report())
+}
+
+// percentileMs returns the nearest-rank percentile of sorted samples in milliseconds
+func percentileMs(sorted []time.Duration, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return float64(sorted[rank]) / float64(time.Millisecond)
+}
@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// DefaultCompressionThreshold is the resource content size above which content is gzip-compressed
+const DefaultCompressionThreshold = 32 * 1024
+
+// Resource metadata describing compressed content
+const (
+	MetadataContentEncoding = "content-encoding"
+	ContentEncodingGzip     = "gzip"
+)
+
+// Compress gzips the content when it exceeds threshold bytes, storing it as a base64 JSON string.
+// A threshold of zero or less disables compression.
+func (r *Resource) Compress(threshold int) error {
+	if threshold <= 0 || len(r.Content) <= threshold || r.Metadata[MetadataContentEncoding] != "" {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(r.Content); err != nil {
+		return fmt.Errorf("failed to compress resource content: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress resource content: %w", err)
+	}
+
+	// []byte marshals as a base64 string, keeping Content valid JSON
+	encoded, err := json.Marshal(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to encode compressed resource content: %w", err)
+	}
+
+	r.Content = encoded
+	if r.Metadata == nil {
+		r.Metadata = make(map[string]string)
+	}
+	r.Metadata[MetadataContentEncoding] = ContentEncodingGzip
+	return nil
+}
+
+// Decompress restores content compressed by Compress; uncompressed resources are left unchanged
+func (r *Resource) Decompress() error {
+	switch encoding := r.Metadata[MetadataContentEncoding]; encoding {
+	case "":
+		return nil
+	case ContentEncodingGzip:
+	default:
+		return fmt.Errorf("unsupported resource content encoding: %s", encoding)
+	}
+
+	var compressed []byte
+	if err := json.Unmarshal(r.Content, &compressed); err != nil {
+		return fmt.Errorf("failed to decode compressed resource content: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return fmt.Errorf("failed to decompress resource content: %w", err)
+	}
+	defer zr.Close()
+
+	content, err := io.ReadAll(zr)
+	if err != nil {
+		return fmt.Errorf("failed to decompress resource content: %w", err)
+	}
+
+	r.Content = content
+	delete(r.Metadata, MetadataContentEncoding)
+	return nil
+}
@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// podListContent returns a JSON pod list of at least size bytes, resembling the resources the server compresses
+func podListContent(size int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"kind":"PodList","items":[`)
+	for i := 0; buf.Len() < size; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"metadata":{"name":"web-%d","namespace":"default","labels":{"app":"web"}},"spec":{"nodeName":"node-%d","containers":[{"name":"web","image":"nginx:1.25"}]},"status":{"phase":"Running","podIP":"10.0.%d.%d"}}`, i, i%8, i/256, i%256)
+	}
+	buf.WriteString(`]}`)
+	return buf.Bytes()
+}
+
+func TestCompressRoundTrip(t *testing.T) {
+	content := podListContent(DefaultCompressionThreshold + 1)
+	r := &Resource{Content: append([]byte(nil), content...)}
+	if err := r.Compress(DefaultCompressionThreshold); err != nil {
+		t.Fatalf("Compress: %v", err)
+	}
+	if r.Metadata[MetadataContentEncoding] != ContentEncodingGzip {
+		t.Fatalf("content over the threshold was not compressed")
+	}
+	if err := r.Decompress(); err != nil {
+		t.Fatalf("Decompress: %v", err)
+	}
+	if !bytes.Equal(r.Content, content) {
+		t.Error("decompressed content differs from the original")
+	}
+
+	small := &Resource{Content: []byte(`{"kind":"PodList","items":[]}`)}
+	if err := small.Compress(DefaultCompressionThreshold); err != nil {
+		t.Fatalf("Compress: %v", err)
+	}
+	if small.Metadata[MetadataContentEncoding] != "" {
+		t.Error("content under the threshold was compressed")
+	}
+}
+
+// BenchmarkCompress measures compressing content just over the default threshold, the smallest size that is compressed
+func BenchmarkCompress(b *testing.B) {
+	content := podListContent(DefaultCompressionThreshold + 1)
+	b.SetBytes(int64(len(content)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := &Resource{Content: content}
+		if err := r.Compress(DefaultCompressionThreshold); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecompress measures restoring content compressed at the default threshold
+func BenchmarkDecompress(b *testing.B) {
+	content := podListContent(DefaultCompressionThreshold + 1)
+	compressed := &Resource{Content: content}
+	if err := compressed.Compress(DefaultCompressionThreshold); err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(content)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r := &Resource{
+			Content:  compressed.Content,
+			Metadata: map[string]string{MetadataContentEncoding: ContentEncodingGzip},
+		}
+		if err := r.Decompress(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
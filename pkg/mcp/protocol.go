@@ -3,6 +3,8 @@ package mcp
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -20,8 +22,12 @@ const (
 	MessageTypeListResources  = "listResources"
 	MessageTypeReadResource   = "readResource"
 	MessageTypeListTools      = "listTools"
+	MessageTypeDescribeTools  = "describeTools"
 	MessageTypeCallTool       = "callTool"
 	MessageTypeError          = "error"
+
+	// MessageTypeResourceUpdated is pushed over /mcp/events when a watched resource changes
+	MessageTypeResourceUpdated = "resourceUpdated"
 )
 
 // Message represents an MCP protocol message
@@ -103,9 +109,176 @@ type ToolCall struct {
 	Arguments map[string]interface{} `json:"arguments"`
 }
 
+// ToolDescription is the self-documenting form of a tool returned by describeTools
+type ToolDescription struct {
+	Tool
+	Parameters []ToolParameter `json:"parameters"`
+	Examples   []ToolExample   `json:"examples,omitempty"`
+	Errors     []ToolErrorCode `json:"errors,omitempty"`
+}
+
+// ToolParameter describes one argument of a tool
+type ToolParameter struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Description string   `json:"description,omitempty"`
+	Required    bool     `json:"required"`
+	Enum        []string `json:"enum,omitempty"`
+}
+
+// ToolExample pairs a natural language query with the tool call that answers it
+type ToolExample struct {
+	Query string   `json:"query"`
+	Call  ToolCall `json:"call"`
+}
+
+// ToolErrorCode is a failure reason a tool can report, with how to recover from it
+type ToolErrorCode struct {
+	Code       string `json:"code"`
+	Suggestion string `json:"suggestion"`
+}
+
+// toolExamples holds example invocations for the built-in Kubernetes tools
+var toolExamples = map[string][]ToolExample{
+	"get_pods": {
+		{Query: "show me the pods in kube-system", Call: ToolCall{Name: "get_pods", Arguments: map[string]interface{}{"namespace": "kube-system"}}},
+		{Query: "list every pod in the cluster", Call: ToolCall{Name: "get_pods", Arguments: map[string]interface{}{}}},
+	},
+	"get_deployment": {
+		{Query: "how many replicas of web are ready in production?", Call: ToolCall{Name: "get_deployment", Arguments: map[string]interface{}{"name": "web", "namespace": "production"}}},
+	},
+	"create_deployment": {
+		{Query: "deploy nginx:1.25 as web with 3 replicas in default", Call: ToolCall{Name: "create_deployment", Arguments: map[string]interface{}{"name": "web", "namespace": "default", "image": "nginx:1.25", "replicas": 3}}},
+	},
+	"scale_deployment": {
+		{Query: "scale the api deployment in staging to 5 replicas", Call: ToolCall{Name: "scale_deployment", Arguments: map[string]interface{}{"name": "api", "namespace": "staging", "replicas": 5}}},
+	},
+	"delete_pod": {
+		{Query: "delete the pod web-7d4b9c-x2k8f in default", Call: ToolCall{Name: "delete_pod", Arguments: map[string]interface{}{"name": "web-7d4b9c-x2k8f", "namespace": "default"}}},
+	},
+	"get_top_pods": {
+		{Query: "which pods use the most memory?", Call: ToolCall{Name: "get_top_pods", Arguments: map[string]interface{}{"sort_by": "memory"}}},
+		{Query: "top 5 pods by CPU in production", Call: ToolCall{Name: "get_top_pods", Arguments: map[string]interface{}{"namespace": "production", "sort_by": "cpu", "top_n": 5}}},
+	},
+	"get_top_nodes": {
+		{Query: "which nodes are busiest?", Call: ToolCall{Name: "get_top_nodes", Arguments: map[string]interface{}{"sort_by": "cpu"}}},
+	},
+	"list_warning_events": {
+		{Query: "what's wrong with my cluster?", Call: ToolCall{Name: "list_warning_events", Arguments: map[string]interface{}{}}},
+	},
+	"migrate_deployment": {
+		{Query: "move the worker deployment from staging to production", Call: ToolCall{Name: "migrate_deployment", Arguments: map[string]interface{}{"name": "worker", "source_namespace": "staging", "destination_namespace": "production"}}},
+	},
+	"bulk_annotate": {
+		{Query: "annotate all deployments with app=api in default with team=payments", Call: ToolCall{Name: "bulk_annotate", Arguments: map[string]interface{}{"resource_type": "deployments", "selector": "app=api", "namespace": "default", "annotations": map[string]interface{}{"team": "payments"}}}},
+	},
+	"generate_helm_values": {
+		{Query: "turn the web deployment into a Helm values file", Call: ToolCall{Name: "generate_helm_values", Arguments: map[string]interface{}{"name": "web", "namespace": "default"}}},
+	},
+}
+
+// GetToolExamples returns example invocations of a tool, or nil if it has none
+func GetToolExamples(toolName string) []ToolExample {
+	return toolExamples[toolName]
+}
+
+// DescribeTool expands a tool's input schema into parameters and adds its examples and known error codes
+func DescribeTool(tool Tool) ToolDescription {
+	required := map[string]bool{}
+	switch names := tool.InputSchema["required"].(type) {
+	case []string:
+		for _, name := range names {
+			required[name] = true
+		}
+	case []interface{}:
+		for _, name := range names {
+			if s, ok := name.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	properties, _ := tool.InputSchema["properties"].(map[string]interface{})
+	params := make([]ToolParameter, 0, len(properties))
+	for name, raw := range properties {
+		schema, _ := raw.(map[string]interface{})
+		description, _ := schema["description"].(string)
+		params = append(params, ToolParameter{
+			Name:        name,
+			Type:        schemaType(schema),
+			Description: description,
+			Required:    required[name],
+			Enum:        stringList(schema["enum"]),
+		})
+	}
+	sort.Slice(params, func(i, j int) bool {
+		if params[i].Required != params[j].Required {
+			return params[i].Required
+		}
+		return params[i].Name < params[j].Name
+	})
+
+	return ToolDescription{
+		Tool:       tool,
+		Parameters: params,
+		Examples:   GetToolExamples(tool.Name),
+		Errors:     ToolErrorCodes(tool.Name),
+	}
+}
+
+// schemaType returns a property's JSON schema type, joining the alternatives of a oneOf with "|"
+func schemaType(schema map[string]interface{}) string {
+	if t, ok := schema["type"].(string); ok {
+		return t
+	}
+
+	var types []string
+	switch alternatives := schema["oneOf"].(type) {
+	case []map[string]interface{}:
+		for _, alternative := range alternatives {
+			if t, ok := alternative["type"].(string); ok {
+				types = append(types, t)
+			}
+		}
+	case []interface{}:
+		for _, alternative := range alternatives {
+			if m, ok := alternative.(map[string]interface{}); ok {
+				if t, ok := m["type"].(string); ok {
+					types = append(types, t)
+				}
+			}
+		}
+	}
+	if len(types) == 0 {
+		return "any"
+	}
+	return strings.Join(types, "|")
+}
+
+// stringList converts a schema list such as enum, typed or decoded from JSON, to strings
+func stringList(v interface{}) []string {
+	switch list := v.(type) {
+	case []string:
+		return list
+	case []interface{}:
+		values := make([]string, 0, len(list))
+		for _, item := range list {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	}
+	return nil
+}
+
 // ToolResult represents the result of a tool call
 type ToolResult struct {
 	Content []ToolResultContent `json:"content"`
+
+	// IsError marks a failed tool call; Content then holds the error message
+	IsError bool `json:"isError,omitempty"`
+
+	// RecoverySuggestion tells the caller how a failed call might be corrected
+	RecoverySuggestion string `json:"recoverySuggestion,omitempty"`
 }
 
 // ToolResultContent represents content in a tool result
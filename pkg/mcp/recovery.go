@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Failure reasons that have a known recovery suggestion
+const (
+	ReasonAlreadyExists     = "AlreadyExists"
+	ReasonForbidden         = "Forbidden"
+	ReasonInvalid           = "Invalid"
+	ReasonNamespaceNotFound = "NamespaceNotFound"
+)
+
+// recoverySuggestions maps failure reasons to a hint for correcting the call
+var recoverySuggestions = map[string]string{
+	ReasonAlreadyExists:     "The resource already exists: update it (e.g. with scale_deployment or apply_manifest) or delete the existing resource first",
+	ReasonForbidden:         "Check RBAC: the service account may lack permission for this operation",
+	ReasonInvalid:           "Validate the manifest schema and the tool arguments",
+	ReasonNamespaceNotFound: "Create the namespace first, e.g. by applying a Namespace manifest with apply_manifest",
+}
+
+// mutatingToolPrefixes marks tools that create objects and so can also fail with AlreadyExists or NamespaceNotFound
+var mutatingToolPrefixes = []string{"create_", "apply_", "batch_apply_", "copy_", "migrate_"}
+
+// ToolErrorCodes returns the failure reasons a tool can report, each with its recovery suggestion
+func ToolErrorCodes(toolName string) []ToolErrorCode {
+	reasons := []string{ReasonForbidden, ReasonInvalid}
+	for _, prefix := range mutatingToolPrefixes {
+		if strings.HasPrefix(toolName, prefix) {
+			reasons = append(reasons, ReasonAlreadyExists, ReasonNamespaceNotFound)
+			break
+		}
+	}
+
+	codes := make([]ToolErrorCode, 0, len(reasons))
+	for _, reason := range reasons {
+		codes = append(codes, ToolErrorCode{Code: reason, Suggestion: recoverySuggestions[reason]})
+	}
+	return codes
+}
+
+// RecoverySuggestion returns the suggestion for a failure reason, or an empty string if none is known
+func RecoverySuggestion(reason string) string {
+	return recoverySuggestions[reason]
+}
+
+// NewToolError builds a failed tool result carrying the error message and a recovery suggestion
+func NewToolError(err error, reason string) *ToolResult {
+	return &ToolResult{
+		Content:            []ToolResultContent{{Type: "text", Text: err.Error()}},
+		IsError:            true,
+		RecoverySuggestion: RecoverySuggestion(reason),
+	}
+}
+
+// Err returns the failure of an error result, including its recovery suggestion, or nil on success
+func (r *ToolResult) Err() error {
+	if !r.IsError {
+		return nil
+	}
+
+	var messages []string
+	for _, content := range r.Content {
+		if content.Text != "" {
+			messages = append(messages, content.Text)
+		}
+	}
+	message := strings.Join(messages, "\n")
+	if message == "" {
+		message = "tool call failed"
+	}
+
+	if r.RecoverySuggestion != "" {
+		return fmt.Errorf("%s\nRecovery suggestion: %s", message, r.RecoverySuggestion)
+	}
+	return fmt.Errorf("%s", message)
+}
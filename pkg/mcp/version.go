@@ -0,0 +1,55 @@
+package mcp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CheckProtocolVersion compares a server's protocol version with ProtocolVersion.
+// It returns an error when the major versions differ and a warning when the server's minor version is newer.
+// Date versions such as 2024-11-05 use the year as the major version and the month and day as the minor version.
+func CheckProtocolVersion(serverVersion string) (string, error) {
+	serverMajor, serverMinor, err := parseProtocolVersion(serverVersion)
+	if err != nil {
+		return "", err
+	}
+	clientMajor, clientMinor, err := parseProtocolVersion(ProtocolVersion)
+	if err != nil {
+		return "", err
+	}
+
+	if serverMajor != clientMajor {
+		return "", fmt.Errorf("incompatible MCP protocol version: server uses %s, client uses %s", serverVersion, ProtocolVersion)
+	}
+	if serverMinor > clientMinor {
+		return fmt.Sprintf("server uses newer MCP protocol version %s than client version %s; consider upgrading the client", serverVersion, ProtocolVersion), nil
+	}
+	return "", nil
+}
+
+// parseProtocolVersion splits a MAJOR.MINOR[.PATCH] or YYYY-MM-DD protocol version into major and minor numbers
+func parseProtocolVersion(version string) (int, int, error) {
+	separator := "."
+	if strings.Count(version, "-") == 2 {
+		separator = "-"
+	}
+	parts := strings.Split(version, separator)
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("invalid MCP protocol version %q", version)
+	}
+
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, 0, fmt.Errorf("invalid MCP protocol version %q", version)
+		}
+		numbers[i] = n
+	}
+
+	if separator == "-" {
+		return numbers[0], numbers[1]*100 + numbers[2], nil
+	}
+	return numbers[0], numbers[1], nil
+}
@@ -0,0 +1,207 @@
+package nlp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/llm"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultMaxIterations is the default cap on reasoning loop iterations
+const DefaultMaxIterations = 20
+
+// continuePrompt asks the LLM to carry on after tool results were added to the conversation
+const continuePrompt = "Continue with the original request using the tool results above. Call more tools if needed, otherwise give the final answer."
+
+// ToolExecutor executes tool calls requested by the LLM during the reasoning loop
+type ToolExecutor interface {
+	// ExecuteTool runs a tool call and returns its textual result
+	ExecuteTool(ctx context.Context, toolCall llm.ToolCall) (string, error)
+}
+
+// SetToolExecutor enables the multi-step reasoning loop using executor to run tool calls
+func (p *Processor) SetToolExecutor(executor ToolExecutor) {
+	p.executor = executor
+}
+
+// SetMaxIterations sets the maximum number of reasoning loop iterations
+func (p *Processor) SetMaxIterations(maxIterations int) {
+	if maxIterations > 0 {
+		p.maxIterations = maxIterations
+	}
+}
+
+// runReasoningLoop calls the LLM, executes its tool calls and feeds the results back until it answers without tools
+func (p *Processor) runReasoningLoop(ctx context.Context, generate func(context.Context, llm.Query) (*llm.Response, error), query string) (*llm.Response, error) {
+	history := append([]llm.Message(nil), p.history...)
+	text := query
+	totalDropped := 0
+
+	for iteration := 1; ; iteration++ {
+		var dropped int
+		history, dropped = p.fitHistory(history, text)
+		totalDropped += dropped
+		if iteration == 1 {
+			p.history = append([]llm.Message(nil), history...)
+			history = append(history, p.triageContext(ctx, query)...)
+		}
+
+		response, err := generate(ctx, llm.Query{
+			Text:         text,
+			Tools:        p.tools,
+			History:      history,
+			SystemPrompt: p.promptWithExamples(),
+			Context: map[string]interface{}{
+				"domain": "kubernetes",
+				"task":   "command_generation",
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		p.tokenBudget.Spend(responseTokens(response, history, text))
+
+		if response.Metadata == nil {
+			response.Metadata = map[string]interface{}{}
+		}
+		response.Metadata["iteration"] = iteration
+		response.Metadata["history_dropped"] = totalDropped
+
+		logrus.Debugf("Reasoning iteration %d: %d tool call(s), content=%q", iteration, len(response.ToolCalls), response.Content)
+
+		if p.executor == nil || len(response.ToolCalls) == 0 {
+			return response, nil
+		}
+		if iteration >= p.maxIterations {
+			logrus.Warnf("Reasoning loop stopped after reaching the maximum of %d iterations", p.maxIterations)
+			return response, nil
+		}
+
+		var calls []string
+		for _, toolCall := range response.ToolCalls {
+			calls = append(calls, toolCall.ToolName)
+		}
+		history = append(history,
+			llm.Message{Role: "user", Content: text},
+			llm.Message{Role: "assistant", Content: strings.TrimSpace(response.Content + "\nCalling tools: " + strings.Join(calls, ", "))},
+		)
+
+		for _, toolCall := range response.ToolCalls {
+			result, allowed := p.verifyPermissions(ctx, toolCall)
+			if allowed {
+				var err error
+				if toolCall.ToolName == explainErrorTool {
+					result, err = p.explainError(ctx, toolCall)
+				} else {
+					result, err = p.executor.ExecuteTool(ctx, toolCall)
+				}
+				if err != nil {
+					result = "Error: " + err.Error()
+				}
+			}
+			logrus.Debugf("Reasoning iteration %d: tool %s returned %d bytes", iteration, toolCall.ToolName, len(result))
+
+			history = append(history, llm.Message{
+				Role:    "tool",
+				Content: fmt.Sprintf("Result of %s: %s", toolCall.ToolName, result),
+			})
+		}
+
+		text = continuePrompt
+	}
+}
+
+// requiredPermission returns the RBAC check to run before a mutating tool call, if any
+func requiredPermission(toolCall llm.ToolCall) (llm.ToolCall, bool) {
+	args := toolCall.Arguments
+	namespace, _ := args["namespace"].(string)
+	var verb, resource string
+
+	switch toolCall.ToolName {
+	case "kubectl_create_deployment":
+		verb, resource = "create", "deployments"
+	case "kubectl_scale_deployment":
+		verb, resource = "patch", "deployments/scale"
+	case "kubectl_rolling_update_image":
+		verb, resource = "patch", "deployments"
+		namespace, _ = args["namespace"].(string)
+	case "kubectl_create_job_from_deployment":
+		verb, resource = "create", "jobs"
+	case "kubectl_update_rollout_strategy":
+		verb, resource = "patch", "deployments"
+	case "kubectl_scale_to_zero", "kubectl_wake_up_deployment":
+		verb, resource = "patch", "deployments"
+	case "kubectl_delete_pod":
+		verb, resource = "delete", "pods"
+	case "kubectl_cleanup_orphaned_resources":
+		// The first resource's type stands for the rest; kubectl auth can-i accepts type/name
+		verb = "delete"
+		if resources := stringListArg(args["resources"]); len(resources) > 0 {
+			resource = resources[0]
+		}
+	case "kubectl_apply_namespace_policy":
+		verb, resource = "create", "configmaps"
+	case "kubectl_create_quota":
+		verb, resource = "create", "resourcequotas"
+	case "kubectl_migrate_deployment":
+		verb, resource = "create", "deployments"
+		namespace, _ = args["destination_namespace"].(string)
+	case "kubectl_copy_secret":
+		verb, resource = "create", "secrets"
+		namespace, _ = args["dst_namespace"].(string)
+	case "kubectl_copy_configmap":
+		verb, resource = "create", "configmaps"
+		namespace, _ = args["dst_namespace"].(string)
+	case "kubectl_sync_labels":
+		verb = "patch"
+		resource, _ = args["target_type"].(string)
+	case "kubectl_taint_node", "kubectl_untaint_node":
+		verb, resource = "patch", "nodes"
+	case "kubectl_add_toleration_to_deployment":
+		verb, resource = "patch", "deployments"
+	case "kubectl_test_connectivity":
+		verb, resource = "create", "pods/exec"
+		namespace, _ = args["source_namespace"].(string)
+	case "kubectl_bulk_annotate":
+		verb = "patch"
+		resource, _ = args["resource_type"].(string)
+	default:
+		return llm.ToolCall{}, false
+	}
+	if resource == "" {
+		return llm.ToolCall{}, false
+	}
+
+	check := map[string]interface{}{"verb": verb, "resource": resource}
+	if namespace != "" {
+		check["namespace"] = namespace
+	}
+	return llm.ToolCall{ToolName: "kubectl_verify_permissions", Arguments: check}, true
+}
+
+// verifyPermissions runs the RBAC pre-flight check for a mutating tool call, returning guidance when it is denied.
+// Checks that cannot be completed do not block the call; the tool's own error is more useful than a guess.
+func (p *Processor) verifyPermissions(ctx context.Context, toolCall llm.ToolCall) (string, bool) {
+	check, ok := requiredPermission(toolCall)
+	if !ok {
+		return "", true
+	}
+
+	answer, err := p.executor.ExecuteTool(ctx, check)
+	if err != nil || strings.TrimSpace(answer) != "no" {
+		if err != nil {
+			logrus.Debugf("Permission check for %s failed: %v", toolCall.ToolName, err)
+		}
+		return "", true
+	}
+
+	scope := "cluster-wide"
+	if namespace, _ := check.Arguments["namespace"].(string); namespace != "" {
+		scope = "in namespace " + namespace
+	}
+	verb, resource := check.Arguments["verb"], check.Arguments["resource"]
+	logrus.Debugf("Skipping %s: not allowed to %s %s %s", toolCall.ToolName, verb, resource, scope)
+	return fmt.Sprintf("Not executed: permission denied, the current user cannot %s %s %s. Tell the user to ask a cluster administrator for a Role or ClusterRole granting %q on %q.", verb, resource, scope, verb, resource), false
+}
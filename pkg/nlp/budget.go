@@ -0,0 +1,122 @@
+package nlp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/llm"
+	"github.com/sirupsen/logrus"
+)
+
+// tokenBudgetWarningRatio is the share of the token budget below which responses are flagged
+const tokenBudgetWarningRatio = 0.2
+
+// summarizeHistoryPrompt asks the LLM to condense the conversation once the token budget is spent
+const summarizeHistoryPrompt = "Summarize this conversation between a user and a Kubernetes assistant so it can continue without the full transcript. Keep the cluster, namespaces, resource names, commands run and open questions; drop pleasantries. Answer with the summary only."
+
+// TokenBudget tracks the tokens left in a session; a zero total disables it
+type TokenBudget struct {
+	total     int
+	remaining int
+}
+
+// NewTokenBudget creates a budget of total tokens
+func NewTokenBudget(total int) TokenBudget {
+	return TokenBudget{total: total, remaining: total}
+}
+
+// Spend subtracts tokens from the budget, stopping at zero
+func (b *TokenBudget) Spend(tokens int) {
+	b.remaining = max(b.remaining-tokens, 0)
+}
+
+// Remaining returns the tokens left in the budget
+func (b *TokenBudget) Remaining() int {
+	return b.remaining
+}
+
+// Low reports whether less than 20% of the budget is left
+func (b *TokenBudget) Low() bool {
+	return b.total > 0 && float64(b.remaining) < float64(b.total)*tokenBudgetWarningRatio
+}
+
+// Exhausted reports whether the budget is spent
+func (b *TokenBudget) Exhausted() bool {
+	return b.total > 0 && b.remaining == 0
+}
+
+// Reset refills the budget
+func (b *TokenBudget) Reset() {
+	b.remaining = b.total
+}
+
+// GetRemainingBudget returns the tokens left in the session's budget
+func (p *Processor) GetRemainingBudget() int {
+	return p.tokenBudget.Remaining()
+}
+
+// TokenBudgetLow reports whether the session is approaching its token limit
+func (p *Processor) TokenBudgetLow() bool {
+	return p.tokenBudget.Low()
+}
+
+// responseTokens returns the tokens a response used as reported by the provider, or estimates them from the prompt and answer
+func responseTokens(response *llm.Response, history []llm.Message, query string) int {
+	if tokens, ok := response.Metadata["total_tokens"].(int); ok {
+		return tokens
+	}
+	return EstimateTokens(history) + EstimateTokens([]llm.Message{
+		{Role: "user", Content: query},
+		{Role: "assistant", Content: response.Content},
+	})
+}
+
+// checkTokenBudget flags a response with token_budget_warning when the budget runs low, and summarizes the history
+// and refills the budget once it is spent
+func (p *Processor) checkTokenBudget(ctx context.Context, response *llm.Response) {
+	if response.Metadata == nil {
+		response.Metadata = map[string]interface{}{}
+	}
+	if p.tokenBudget.Low() {
+		response.Metadata["token_budget_warning"] = true
+	} else {
+		delete(response.Metadata, "token_budget_warning")
+	}
+
+	if !p.tokenBudget.Exhausted() {
+		return
+	}
+	if err := p.summarizeHistory(ctx); err != nil {
+		// The history is still truncated to fit each request, so carry on without the summary
+		logrus.Warnf("Failed to summarize conversation history: %v", err)
+	}
+	p.tokenBudget.Reset()
+}
+
+// summarizeHistory replaces the conversation history with an LLM-written summary of it
+func (p *Processor) summarizeHistory(ctx context.Context) error {
+	if len(p.history) == 0 {
+		return nil
+	}
+
+	var b strings.Builder
+	b.WriteString(summarizeHistoryPrompt)
+	b.WriteString("\n\nConversation:\n")
+	for _, msg := range p.history {
+		fmt.Fprintf(&b, "%s: %s\n", msg.Role, msg.Content)
+	}
+
+	summary, err := p.llmProvider.GenerateResponse(ctx, b.String())
+	if err != nil {
+		return err
+	}
+	logrus.Debugf("Summarized %d history message(s) after spending the token budget", len(p.history))
+
+	// A system message is never dropped when the history is truncated
+	p.history = []llm.Message{{
+		Role:    "system",
+		Content: "Summary of the earlier conversation: " + strings.TrimSpace(summary),
+	}}
+	return nil
+}
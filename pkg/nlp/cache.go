@@ -0,0 +1,115 @@
+package nlp
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/llm"
+)
+
+// DefaultQueryCacheTTL is how long a cached query response stays valid
+const DefaultQueryCacheTTL = 30 * time.Second
+
+// defaultQueryCacheSize is the maximum number of cached query responses
+const defaultQueryCacheSize = 100
+
+// queryCacheEntry is a cached response for a normalized query
+type queryCacheEntry struct {
+	key      string
+	response *llm.Response
+	expires  time.Time
+}
+
+// queryCache is an LRU cache of LLM responses with a per-entry TTL
+type queryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+// newQueryCache creates a query cache holding up to capacity entries for ttl each
+func newQueryCache(capacity int, ttl time.Duration) *queryCache {
+	return &queryCache{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// normalizeQuery lowercases a query and collapses whitespace so equivalent queries share a cache key
+func normalizeQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+// get returns a copy of the cached response marked as coming from the cache
+func (c *queryCache) get(query string) (*llm.Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[normalizeQuery(query)]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*queryCacheEntry)
+	if time.Now().After(entry.expires) {
+		c.order.Remove(elem)
+		delete(c.entries, entry.key)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+
+	response := *entry.response
+	response.Metadata = make(map[string]interface{}, len(entry.response.Metadata)+1)
+	for k, v := range entry.response.Metadata {
+		response.Metadata[k] = v
+	}
+	response.Metadata["from_cache"] = true
+	return &response, true
+}
+
+// put stores a response, evicting the least recently used entry when full
+func (c *queryCache) put(query string, response *llm.Response) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := normalizeQuery(query)
+	entry := &queryCacheEntry{key: key, response: response, expires: time.Now().Add(c.ttl)}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*queryCacheEntry).key)
+	}
+}
+
+// setTTL changes the TTL applied to newly cached responses
+func (c *queryCache) setTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// clear removes all cached responses
+func (c *queryCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+}
@@ -0,0 +1,55 @@
+package nlp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/llm"
+	"github.com/mcp-servers/cli/pkg/mcp"
+)
+
+// toolExamplesFor returns the MCP examples of the server tool behind an NLP tool, renamed to the NLP tool.
+// Examples whose arguments the NLP tool does not accept are skipped so they never teach an invalid call.
+func toolExamplesFor(tool llm.Tool) []mcp.ToolExample {
+	properties, _ := tool.Parameters["properties"].(map[string]interface{})
+
+	var examples []mcp.ToolExample
+	for _, example := range mcp.GetToolExamples(strings.TrimPrefix(tool.Name, "kubectl_")) {
+		compatible := true
+		for arg := range example.Call.Arguments {
+			if _, ok := properties[arg]; !ok {
+				compatible = false
+				break
+			}
+		}
+		if compatible {
+			example.Call.Name = tool.Name
+			examples = append(examples, example)
+		}
+	}
+	return examples
+}
+
+// promptWithExamples returns the system prompt followed by few-shot examples of tool calls
+func (p *Processor) promptWithExamples() string {
+	var b strings.Builder
+	for _, tool := range p.tools {
+		for _, example := range toolExamplesFor(tool) {
+			args, err := json.Marshal(example.Call.Arguments)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(&b, "\nUser: %s\nCall: %s %s", example.Query, example.Call.Name, args)
+		}
+	}
+	if b.Len() == 0 {
+		return p.systemPrompt
+	}
+
+	prompt := "Examples of queries and the tool calls that answer them:" + b.String()
+	if p.systemPrompt != "" {
+		prompt = p.systemPrompt + "\n\n" + prompt
+	}
+	return prompt
+}
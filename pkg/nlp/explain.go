@@ -0,0 +1,54 @@
+package nlp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/llm"
+)
+
+// explainErrorTool is the tool the reasoning loop answers itself by asking the LLM to diagnose a failing pod
+const explainErrorTool = "kubectl_explain_error"
+
+// explainLogLines is how many log lines are included in a pod failure diagnosis
+const explainLogLines = 100
+
+// explainErrorPrompt asks the LLM to diagnose a pod from its describe output and logs
+const explainErrorPrompt = "Given this pod's state and logs, explain why it is failing and suggest a fix. Be concise: state the most likely root cause first, then the concrete change or command that fixes it."
+
+// explainError gathers a pod's state, events and recent logs through the executor and returns the LLM's diagnosis
+func (p *Processor) explainError(ctx context.Context, toolCall llm.ToolCall) (string, error) {
+	name, _ := toolCall.Arguments["pod_name"].(string)
+	if name == "" {
+		return "", fmt.Errorf("pod name is required")
+	}
+	namespace := toolCall.Arguments["namespace"]
+
+	describe, err := p.executor.ExecuteTool(ctx, llm.ToolCall{
+		ToolName:  "kubectl_describe_pod",
+		Arguments: map[string]interface{}{"name": name, "namespace": namespace},
+	})
+	if err != nil {
+		return "", err
+	}
+	// A container that has not started has no logs; the describe output is still worth diagnosing
+	logs, err := p.executor.ExecuteTool(ctx, llm.ToolCall{
+		ToolName:  "kubectl_get_pod_logs",
+		Arguments: map[string]interface{}{"name": name, "namespace": namespace, "tail": float64(explainLogLines)},
+	})
+	if err != nil {
+		logs = "unavailable: " + err.Error()
+	}
+
+	var b strings.Builder
+	b.WriteString(explainErrorPrompt)
+	fmt.Fprintf(&b, "\n\nPod state, container statuses and events:\n%s\n", strings.TrimSpace(describe))
+	fmt.Fprintf(&b, "\nLast %d log lines:\n%s\n", explainLogLines, strings.TrimSpace(logs))
+
+	diagnosis, err := p.llmProvider.GenerateResponse(ctx, b.String())
+	if err != nil {
+		return "", fmt.Errorf("failed to get diagnosis: %w", err)
+	}
+	return strings.TrimSpace(diagnosis), nil
+}
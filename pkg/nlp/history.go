@@ -0,0 +1,91 @@
+package nlp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/llm"
+)
+
+// DefaultHistoryImportLimit is how many recent kubectl commands are imported from shell history
+const DefaultHistoryImportLimit = 20
+
+// DefaultShellHistoryPath returns the zsh history file if present, otherwise the bash history file
+func DefaultShellHistoryPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+
+	for _, name := range []string{".zsh_history", ".bash_history"} {
+		path := filepath.Join(home, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("no ~/.zsh_history or ~/.bash_history found")
+}
+
+// ParseShellHistory returns the kubectl commands in bash or zsh history, oldest first
+func ParseShellHistory(r io.Reader) ([]string, error) {
+	var commands []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// zsh extended history lines look like ": 1700000000:0;kubectl get pods"
+		if strings.HasPrefix(line, ": ") {
+			if idx := strings.Index(line, ";"); idx != -1 {
+				line = line[idx+1:]
+			}
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "kubectl" || strings.HasPrefix(line, "kubectl ") {
+			commands = append(commands, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read shell history: %w", err)
+	}
+	return commands, nil
+}
+
+// HistoryFromCommands converts kubectl commands into user/assistant conversation turns
+func HistoryFromCommands(commands []string) []llm.Message {
+	messages := make([]llm.Message, 0, 2*len(commands))
+	for _, command := range commands {
+		messages = append(messages,
+			llm.Message{Role: "user", Content: "do: " + command},
+			llm.Message{Role: "assistant", Content: command},
+		)
+	}
+	return messages
+}
+
+// ImportShellHistory loads the most recent kubectl commands from a shell history file into the conversation history
+func (p *Processor) ImportShellHistory(path string, limit int) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open shell history: %w", err)
+	}
+	defer file.Close()
+
+	commands, err := ParseShellHistory(file)
+	if err != nil {
+		return 0, err
+	}
+	if limit > 0 && len(commands) > limit {
+		commands = commands[len(commands)-limit:]
+	}
+
+	p.history = append(p.history, HistoryFromCommands(commands)...)
+	return len(commands), nil
+}
@@ -0,0 +1,111 @@
+package nlp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// maxManifestRetries is how many times the LLM is asked to fix an invalid manifest
+const maxManifestRetries = 2
+
+// manifestPrompt instructs the LLM to produce only a Kubernetes manifest
+const manifestPrompt = `You are a Kubernetes expert. Generate a valid Kubernetes YAML manifest for the following description.
+Respond with only the YAML. Do not add explanations or Markdown code fences.
+Separate multiple resources with a line containing only "---".
+Every resource must have apiVersion, kind and metadata.name.
+
+Description: %s`
+
+// manifestFixPrompt asks the LLM to repair a manifest that failed validation
+const manifestFixPrompt = `The following Kubernetes manifest is invalid: %v
+
+Return a corrected version containing only the YAML, without explanations or Markdown code fences.
+
+%s`
+
+// GenerateManifest asks the LLM for a Kubernetes manifest matching the description and validates it
+func (p *Processor) GenerateManifest(ctx context.Context, description string) (string, error) {
+	description, err := p.SanitizeQuery(description)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := p.llmProvider.GenerateResponse(ctx, fmt.Sprintf(manifestPrompt, description))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate manifest: %w", err)
+	}
+	manifest := stripCodeFences(response)
+
+	for attempt := 0; ; attempt++ {
+		validationErr := ValidateManifest(manifest)
+		if validationErr == nil {
+			return manifest, nil
+		}
+		if attempt == maxManifestRetries {
+			return "", fmt.Errorf("generated manifest is invalid after %d retries: %w", maxManifestRetries, validationErr)
+		}
+
+		logrus.Debugf("Generated manifest is invalid (attempt %d): %v", attempt+1, validationErr)
+		response, err = p.llmProvider.GenerateResponse(ctx, fmt.Sprintf(manifestFixPrompt, validationErr, manifest))
+		if err != nil {
+			return "", fmt.Errorf("failed to fix manifest: %w", err)
+		}
+		manifest = stripCodeFences(response)
+	}
+}
+
+// ValidateManifest checks that every document in a YAML manifest is a well-formed Kubernetes object
+func ValidateManifest(manifest string) error {
+	documents := SplitManifest(manifest)
+	if len(documents) == 0 {
+		return fmt.Errorf("manifest is empty")
+	}
+
+	for i, doc := range documents {
+		data, err := yaml.YAMLToJSON([]byte(doc))
+		if err != nil {
+			return fmt.Errorf("document %d is not valid YAML: %w", i+1, err)
+		}
+
+		var obj unstructured.Unstructured
+		if err := obj.UnmarshalJSON(data); err != nil {
+			return fmt.Errorf("document %d is not a Kubernetes object: %w", i+1, err)
+		}
+		if obj.GetName() == "" {
+			return fmt.Errorf("document %d (%s) is missing metadata.name", i+1, obj.GetKind())
+		}
+	}
+
+	return nil
+}
+
+// SplitManifest splits a multi-document YAML manifest, dropping empty documents
+func SplitManifest(manifest string) []string {
+	var documents []string
+	for _, doc := range strings.Split("\n"+manifest, "\n---") {
+		if strings.TrimSpace(doc) != "" {
+			documents = append(documents, strings.TrimSpace(doc))
+		}
+	}
+	return documents
+}
+
+// stripCodeFences removes Markdown code fences the LLM may wrap around YAML
+func stripCodeFences(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "```") {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	lines = lines[1:]
+	if len(lines) > 0 && strings.HasPrefix(strings.TrimSpace(lines[len(lines)-1]), "```") {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,153 @@
+package nlp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/llm"
+	"github.com/sirupsen/logrus"
+)
+
+// Trace is one recorded query and the LLM's response
+type Trace struct {
+	ID            int64     `json:"id"`
+	Timestamp     time.Time `json:"timestamp"`
+	Provider      string    `json:"provider"`
+	Model         string    `json:"model"`
+	Query         string    `json:"query"`
+	Response      string    `json:"response"`
+	ToolCallsJSON string    `json:"tool_calls_json,omitempty"`
+	LatencyMs     int64     `json:"latency_ms"`
+	TokenCount    int       `json:"token_count"`
+}
+
+// TraceLogger appends traces to a JSON Lines file, one trace per line
+type TraceLogger struct {
+	mu     sync.Mutex
+	file   *os.File
+	nextID int64
+}
+
+// NewTraceLogger opens or creates the trace file at path, continuing the ID sequence of existing traces
+func NewTraceLogger(path string) (*TraceLogger, error) {
+	path = expandTracePath(path)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create trace directory: %w", err)
+	}
+
+	existing, err := ReadTraces(path, time.Time{})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	var lastID int64
+	if len(existing) > 0 {
+		lastID = existing[len(existing)-1].ID
+	}
+
+	// Traces hold queries and answers, so keep them private to the user
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+	return &TraceLogger{file: file, nextID: lastID + 1}, nil
+}
+
+// Log assigns the trace an ID and appends it to the trace file
+func (t *TraceLogger) Log(trace Trace) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	trace.ID = t.nextID
+	data, err := json.Marshal(trace)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trace: %w", err)
+	}
+	if _, err := t.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write trace: %w", err)
+	}
+	t.nextID++
+	return nil
+}
+
+// Close closes the trace file
+func (t *TraceLogger) Close() error {
+	return t.file.Close()
+}
+
+// ReadTraces returns the traces in the file at path recorded at or after since, oldest first.
+// Lines that are not valid traces are skipped.
+func ReadTraces(path string, since time.Time) ([]Trace, error) {
+	file, err := os.Open(expandTracePath(path))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var traces []Trace
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var trace Trace
+		if err := json.Unmarshal(scanner.Bytes(), &trace); err != nil {
+			continue
+		}
+		if trace.Timestamp.Before(since) {
+			continue
+		}
+		traces = append(traces, trace)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trace file: %w", err)
+	}
+	return traces, nil
+}
+
+// expandTracePath expands a leading ~ to the user's home directory
+func expandTracePath(path string) string {
+	if strings.HasPrefix(path, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// SetTraceLogger records every query answered by the LLM with tracer; nil disables tracing
+func (p *Processor) SetTraceLogger(tracer *TraceLogger) {
+	p.tracer = tracer
+}
+
+// trace records a query and its response, logging rather than returning failures so tracing never breaks a query
+func (p *Processor) trace(query string, response *llm.Response, latency time.Duration) {
+	if p.tracer == nil {
+		return
+	}
+
+	tokens := responseTokens(response, nil, query)
+	var toolCalls string
+	if len(response.ToolCalls) > 0 {
+		if data, err := json.Marshal(response.ToolCalls); err == nil {
+			toolCalls = string(data)
+		}
+	}
+
+	err := p.tracer.Log(Trace{
+		Timestamp:     time.Now().UTC(),
+		Provider:      p.llmProvider.GetProvider(),
+		Model:         p.llmProvider.GetModel(),
+		Query:         query,
+		Response:      response.Content,
+		ToolCallsJSON: toolCalls,
+		LatencyMs:     latency.Milliseconds(),
+		TokenCount:    tokens,
+	})
+	if err != nil {
+		logrus.Warnf("Failed to record trace: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package nlp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/llm"
+	"github.com/sirupsen/logrus"
+)
+
+// Diagnostic tools run ahead of the LLM for triage questions
+const (
+	// warningEventsTool lists recent Warning events across the cluster
+	warningEventsTool = "kubectl_get_warning_events"
+
+	// imagePullErrorsTool lists containers stuck pulling their image
+	imagePullErrorsTool = "kubectl_list_image_pull_errors"
+
+	// namespaceHealthTool summarises the pods, deployments, quotas and events of a namespace
+	namespaceHealthTool = "kubectl_health_score_namespace"
+)
+
+// clusterTriagePattern matches open-ended questions about cluster health, such as "what's wrong with my cluster?"
+var clusterTriagePattern = regexp.MustCompile(`(?i)\b(wrong|broken|failing|issues?|problems?|unhealthy|healthy|going on)\b.*\bcluster\b|\bcluster\b.*\b(wrong|broken|failing|issues?|problems?|unhealthy|healthy)\b`)
+
+// namespaceTriagePattern matches open-ended questions about a namespace, such as "something is wrong in my namespace"
+var namespaceTriagePattern = regexp.MustCompile(`(?i)\b(wrong|broken|failing|issues?|problems?|unhealthy|healthy|going on)\b.*\bnamespace\b|\bnamespace\b.*\b(wrong|broken|failing|issues?|problems?|unhealthy|healthy)\b`)
+
+// namespaceNamePattern matches a valid namespace name
+var namespaceNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// namespaceStopWords are words next to "namespace" that do not name one
+var namespaceStopWords = map[string]bool{
+	"a": true, "my": true, "the": true, "this": true, "that": true, "our": true, "your": true, "in": true, "of": true,
+	"is": true, "are": true, "has": true, "seems": true, "looks": true, "with": true, "for": true, "current": true,
+	"s": true, "what": true, "why": true, "whole": true, "entire": true, "wrong": true, "broken": true, "failing": true,
+	"unhealthy": true, "healthy": true,
+}
+
+// podStartupPattern matches questions about pods that do not start or images that do not pull,
+// such as "why aren't my pods starting?" or "any image pull issues?"
+var podStartupPattern = regexp.MustCompile(`(?i)\bimage ?pull|\bpull(ing)? (errors?|issues?|problems?|fail)|` +
+	`\bpods?\b.*\b(aren'?t|are not|not|won'?t|never|fail(ing|s)? to)\b.*\b(start(ing)?|run(ning)?|come up|ready)\b|` +
+	`\b(aren'?t|are not|won'?t|never)\b.*\bpods?\b.*\b(start(ing)?|run(ning)?|come up|ready)\b`)
+
+// triageRule runs a diagnostic tool first when a query matches its pattern; args, when set, takes the tool arguments from the query
+type triageRule struct {
+	pattern *regexp.Regexp
+	tool    string
+	args    func(query string) map[string]interface{}
+}
+
+// triageRules are checked in order; every matching rule contributes its tool result
+var triageRules = []triageRule{
+	{pattern: namespaceTriagePattern, tool: namespaceHealthTool, args: namespaceArgs},
+	{pattern: podStartupPattern, tool: imagePullErrorsTool},
+	{pattern: clusterTriagePattern, tool: warningEventsTool},
+}
+
+// namespaceArgs returns the namespace a query names, as in "the payments namespace" or "namespace payments",
+// as tool arguments, or none so the tool uses the current namespace
+func namespaceArgs(query string) map[string]interface{} {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-')
+	})
+	for i, word := range words {
+		if word != "namespace" {
+			continue
+		}
+		for _, j := range []int{i - 1, i + 1} {
+			if j >= 0 && j < len(words) && !namespaceStopWords[words[j]] && namespaceNamePattern.MatchString(words[j]) {
+				return map[string]interface{}{"namespace": words[j]}
+			}
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// triageContext runs the diagnostic tools of the triage rules a query matches through the executor,
+// so the LLM starts from what the cluster reports before choosing targeted diagnostics
+func (p *Processor) triageContext(ctx context.Context, query string) []llm.Message {
+	if p.executor == nil {
+		return nil
+	}
+
+	var messages []llm.Message
+	for _, rule := range triageRules {
+		if !rule.pattern.MatchString(query) {
+			continue
+		}
+		args := map[string]interface{}{}
+		if rule.args != nil {
+			args = rule.args(query)
+		}
+		result, err := p.executor.ExecuteTool(ctx, llm.ToolCall{ToolName: rule.tool, Arguments: args})
+		if err != nil {
+			logrus.Debugf("Failed to run %s for triage: %v", rule.tool, err)
+			continue
+		}
+		messages = append(messages, llm.Message{
+			Role:    "tool",
+			Content: fmt.Sprintf("Result of %s: %s", rule.tool, result),
+		})
+	}
+	return messages
+}
@@ -0,0 +1,31 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Printer writes status lines prefixed with an emoji, or with a plain ASCII tag when emoji are disabled
+type Printer struct {
+	out     io.Writer
+	noEmoji bool
+}
+
+// NewPrinter creates a printer writing to stdout
+func NewPrinter(noEmoji bool) *Printer {
+	return &Printer{out: os.Stdout, noEmoji: noEmoji}
+}
+
+// Prefix returns the emoji or its plain replacement
+func (p *Printer) Prefix(emojiPrefix, plainPrefix string) string {
+	if p.noEmoji {
+		return plainPrefix
+	}
+	return emojiPrefix
+}
+
+// Print writes the prefix followed by a space and the formatted message
+func (p *Printer) Print(emojiPrefix, plainPrefix, format string, args ...interface{}) {
+	fmt.Fprintf(p.out, "%s %s", p.Prefix(emojiPrefix, plainPrefix), fmt.Sprintf(format, args...))
+}
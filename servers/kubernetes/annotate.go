@@ -0,0 +1,127 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+)
+
+// annotateTools returns the bulk annotation tool definitions
+func annotateTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "bulk_annotate",
+			Description: "Add or overwrite annotations on every resource of a type matching a label selector, e.g. to tag resources during a refactoring",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"resource_type": map[string]interface{}{
+						"type":        "string",
+						"description": "Resource type to annotate (e.g. deployment, service, configmap)",
+					},
+					"selector": map[string]interface{}{
+						"type":        "string",
+						"description": "Label selector choosing the resources (e.g. app=web,tier!=cache)",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the resources (defaults to default)",
+					},
+					"annotations": map[string]interface{}{
+						"type":        "object",
+						"description": "Annotation keys mapped to values, e.g. {\"team\":\"payments\"}",
+					},
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "List the resources that would be annotated without changing them",
+					},
+				},
+				"required": []string{"resource_type", "selector", "annotations"},
+			},
+		},
+	}
+}
+
+// annotationFailure records a resource whose annotation patch failed
+type annotationFailure struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+func (s *Server) bulkAnnotateTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	resourceType, err := stringArg(args, "resource_type")
+	if err != nil {
+		return nil, err
+	}
+	selector, err := stringArg(args, "selector")
+	if err != nil {
+		return nil, err
+	}
+	rawAnnotations, ok := args["annotations"].(map[string]interface{})
+	if !ok || len(rawAnnotations) == 0 {
+		return nil, fmt.Errorf("annotations is required and must be a non-empty object")
+	}
+	annotations := make(map[string]string, len(rawAnnotations))
+	for key, value := range rawAnnotations {
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("annotation %s must have a string value", key)
+		}
+		annotations[key] = text
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	dryRun := boolArg(args, "dry_run")
+
+	ctx := context.Background()
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(s.clientset.Discovery()))
+	client, err := s.resourceClientFor(mapper, resourceType, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build annotation patch: %w", err)
+	}
+
+	matched := make([]string, 0, len(list.Items))
+	failed := []annotationFailure{}
+	patched := 0
+	for _, item := range list.Items {
+		name := item.GetName()
+		matched = append(matched, name)
+		if dryRun {
+			continue
+		}
+		if _, err := client.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			failed = append(failed, annotationFailure{Name: name, Error: err.Error()})
+			continue
+		}
+		patched++
+	}
+
+	if dryRun {
+		return jsonResult(map[string]interface{}{
+			"dry_run":        true,
+			"would_annotate": matched,
+			"annotations":    annotations,
+		})
+	}
+	return jsonResult(map[string]interface{}{
+		"patched": patched,
+		"failed":  failed,
+	})
+}
@@ -0,0 +1,255 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+)
+
+// maxRemoteManifestSize bounds the manifest apply_manifest_from_url downloads; release manifests with CRDs run to a few MiB
+const maxRemoteManifestSize = 16 << 20
+
+// lastAppliedAnnotation is the annotation client-side kubectl apply stores the previous configuration in
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// applyURLTools returns the remote manifest tool definitions
+func applyURLTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "apply_manifest_from_url",
+			Description: "Download a manifest over HTTPS, e.g. a project's release manifest, validate it and apply it with server-side apply; reports which resources were created, updated or unchanged",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"url": map[string]interface{}{
+						"type":        "string",
+						"description": "https:// URL of the YAML or JSON manifest",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace for namespaced resources that do not set one (defaults to default)",
+					},
+				},
+				"required": []string{"url"},
+			},
+		},
+	}
+}
+
+// appliedResourceFailure is a resource of a remote manifest that could not be applied
+type appliedResourceFailure struct {
+	Resource string `json:"resource"`
+	Error    string `json:"error"`
+}
+
+func (s *Server) applyManifestFromURLTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	rawURL, err := stringArg(args, "url")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	ctx := context.Background()
+
+	manifest, err := fetchManifest(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("manifest at %s contains no resources", rawURL)
+	}
+
+	// Resources in namespaces or of kinds the manifest itself creates cannot be dry-run before it is applied
+	namespaces, kinds := manifestProvides(objects)
+	skipped := 0
+	documents, errs, warnings, err := s.validateManifest(ctx, manifest, namespace, func(obj *unstructured.Unstructured) bool {
+		skip := namespaces[obj.GetNamespace()] || kinds[obj.GroupVersionKind().GroupKind()]
+		if skip {
+			skipped++
+		}
+		return skip
+	})
+	if err != nil {
+		return nil, err
+	}
+	if skipped > 0 {
+		warnings = append(warnings, fmt.Sprintf("Dry-run skipped for %d resource(s) that depend on namespaces or CRDs the manifest creates", skipped))
+	}
+	if len(errs) > 0 {
+		return jsonResult(map[string]interface{}{
+			"url":       rawURL,
+			"applied":   false,
+			"documents": documents,
+			"errors":    errs,
+			"warnings":  warnings,
+		})
+	}
+
+	for _, obj := range objects {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", lastAppliedAnnotation)
+		if annotations, found, _ := unstructured.NestedMap(obj.Object, "metadata", "annotations"); found && len(annotations) == 0 {
+			unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+		}
+	}
+	sortForRestore(objects)
+
+	created, updated, unchanged := []string{}, []string{}, []string{}
+	failed := []appliedResourceFailure{}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(s.clientset.Discovery()))
+	for _, obj := range objects {
+		resource := obj.GetKind() + "/" + obj.GetName()
+		before, err := s.liveResourceVersion(ctx, mapper, obj, namespace)
+		if err != nil {
+			failed = append(failed, appliedResourceFailure{Resource: resource, Error: err.Error()})
+			continue
+		}
+		result, err := s.serverSideApply(ctx, mapper, obj, namespace)
+		if err != nil {
+			failed = append(failed, appliedResourceFailure{Resource: resource, Error: err.Error()})
+			continue
+		}
+
+		line := appliedLine(result)
+		switch {
+		case before == "":
+			created = append(created, line)
+		case before == result.GetResourceVersion():
+			unchanged = append(unchanged, line)
+		default:
+			updated = append(updated, line)
+		}
+		if obj.GetKind() == "CustomResourceDefinition" {
+			// Later documents may be instances of the new kind
+			mapper.Reset()
+		}
+	}
+
+	s.audit("apply_manifest_from_url", logrus.Fields{
+		"url":       rawURL,
+		"created":   len(created),
+		"updated":   len(updated),
+		"unchanged": len(unchanged),
+		"failed":    len(failed),
+	})
+
+	return jsonResult(map[string]interface{}{
+		"url":       rawURL,
+		"applied":   len(failed) == 0,
+		"created":   created,
+		"updated":   updated,
+		"unchanged": unchanged,
+		"failed":    failed,
+		"warnings":  warnings,
+	})
+}
+
+// fetchManifest downloads a manifest, refusing anything but https, including redirects to plain http
+func fetchManifest(ctx context.Context, rawURL string) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid url: %w", err)
+	}
+	if parsed.Scheme != "https" || parsed.Host == "" {
+		return "", fmt.Errorf("url must be an https:// URL, got %q", rawURL)
+	}
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" {
+				return fmt.Errorf("refusing redirect to non-https URL %s", req.URL)
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download manifest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download manifest: %s returned %s", rawURL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteManifestSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if len(body) > maxRemoteManifestSize {
+		return "", fmt.Errorf("manifest at %s is larger than %d MiB", rawURL, maxRemoteManifestSize>>20)
+	}
+	if strings.TrimSpace(string(body)) == "" {
+		return "", fmt.Errorf("manifest at %s is empty", rawURL)
+	}
+	return string(body), nil
+}
+
+// manifestProvides returns the namespaces and custom resource kinds that the objects of a manifest create
+func manifestProvides(objects []*unstructured.Unstructured) (map[string]bool, map[schema.GroupKind]bool) {
+	namespaces := map[string]bool{}
+	kinds := map[schema.GroupKind]bool{}
+	for _, obj := range objects {
+		switch obj.GetKind() {
+		case "Namespace":
+			namespaces[obj.GetName()] = true
+		case "CustomResourceDefinition":
+			group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+			kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+			kinds[schema.GroupKind{Group: group, Kind: kind}] = true
+		}
+	}
+	return namespaces, kinds
+}
+
+// liveResourceVersion returns the resourceVersion of the object as it exists in the cluster, or "" if it does not exist
+func (s *Server) liveResourceVersion(ctx context.Context, mapper meta.RESTMapper, obj *unstructured.Unstructured, defaultNamespace string) (string, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return "", fmt.Errorf("failed to map %s: %w", gvk.String(), err)
+	}
+
+	resource := s.dynamicClient.Resource(mapping.Resource)
+	var live *unstructured.Unstructured
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		namespace := obj.GetNamespace()
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+		live, err = resource.Namespace(namespace).Get(ctx, obj.GetName(), metav1.GetOptions{})
+	} else {
+		live, err = resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	}
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return live.GetResourceVersion(), nil
+}
@@ -0,0 +1,8 @@
+package kubernetes
+
+import "github.com/sirupsen/logrus"
+
+// audit records a destructive or safety-overriding action in the server log, tagged audit=true so it can be filtered out of the rest
+func (s *Server) audit(action string, fields logrus.Fields) {
+	s.logger.WithFields(fields).WithFields(logrus.Fields{"audit": true, "action": action}).Warn("audit: " + action)
+}
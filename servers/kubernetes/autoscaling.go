@@ -0,0 +1,308 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HPA defaults applied by the API server and controller when a HorizontalPodAutoscaler leaves them unset
+const (
+	// defaultHPACPUUtilization is the CPU target of an HPA without metrics
+	defaultHPACPUUtilization = 80
+
+	// hpaTolerance is the ratio difference from 1.0 within which the controller does not scale
+	hpaTolerance = 0.1
+)
+
+// autoscalingTools returns the autoscaling simulation tool definitions
+func autoscalingTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "simulate_autoscaling",
+			Description: "Project how many replicas a deployment's HorizontalPodAutoscaler would scale to at a given CPU or memory utilization, as a percentage of the pods' requests, using the HPA formula ceil(currentReplicas * currentMetric / desiredMetric)",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"deployment_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment the HPA scales",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment (defaults to default)",
+					},
+					"cpu_utilization_percent": map[string]interface{}{
+						"type":        "number",
+						"description": "Simulated average CPU usage as a percentage of the CPU request, e.g. 80",
+					},
+					"memory_utilization_percent": map[string]interface{}{
+						"type":        "number",
+						"description": "Simulated average memory usage as a percentage of the memory request",
+					},
+				},
+				"required": []string{"deployment_name"},
+			},
+		},
+	}
+}
+
+// metricProjection is the replica count one HPA metric asks for at the simulated utilization
+type metricProjection struct {
+	Metric           string  `json:"metric"`
+	TargetPercent    float64 `json:"target_percent"`
+	SimulatedPercent float64 `json:"simulated_percent"`
+	CurrentPercent   *int32  `json:"current_percent,omitempty"`
+	DesiredReplicas  int32   `json:"desired_replicas"`
+	WithinTolerance  bool    `json:"within_tolerance,omitempty"`
+}
+
+func (s *Server) simulateAutoscalingTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "deployment_name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	simulated := map[corev1.ResourceName]float64{}
+	for resourceName, key := range map[corev1.ResourceName]string{
+		corev1.ResourceCPU:    "cpu_utilization_percent",
+		corev1.ResourceMemory: "memory_utilization_percent",
+	} {
+		value, ok, err := floatArg(args, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if value < 0 {
+				return nil, fmt.Errorf("%s must not be negative", key)
+			}
+			simulated[resourceName] = value
+		}
+	}
+	if len(simulated) == 0 {
+		return nil, fmt.Errorf("cpu_utilization_percent or memory_utilization_percent is required")
+	}
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	hpa, err := s.deploymentHPA(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	current := deployment.Status.Replicas
+	if current == 0 && deployment.Spec.Replicas != nil {
+		current = *deployment.Spec.Replicas
+	}
+	minReplicas := int32(1)
+	if hpa.Spec.MinReplicas != nil {
+		minReplicas = *hpa.Spec.MinReplicas
+	}
+	requests := podRequests(deployment)
+
+	var notes []string
+	if current == 0 {
+		notes = append(notes, "The deployment is scaled to zero, which disables the HPA until it is scaled up again")
+	}
+
+	// The controller scales to the largest replica count any metric asks for
+	projected := int32(0)
+	trigger := ""
+	projections := []metricProjection{}
+	for _, target := range hpaResourceTargets(hpa, requests) {
+		utilization, ok := simulated[target.resource]
+		if !ok {
+			continue
+		}
+		projection := metricProjection{
+			Metric:           string(target.resource),
+			TargetPercent:    target.percent,
+			SimulatedPercent: utilization,
+			CurrentPercent:   currentUtilization(hpa, target.resource),
+		}
+		if target.missingRequest {
+			notes = append(notes, fmt.Sprintf("Not every container has a %s request, so the HPA cannot compute %s utilization and ignores this metric", target.resource, target.resource))
+			continue
+		}
+		projection.DesiredReplicas, projection.WithinTolerance = desiredReplicas(current, utilization, target.percent)
+		projections = append(projections, projection)
+		if projection.DesiredReplicas > projected {
+			projected, trigger = projection.DesiredReplicas, projection.Metric
+		}
+	}
+	if len(projections) == 0 {
+		return nil, fmt.Errorf("HPA %s has no usable cpu or memory target for the simulated utilization", hpa.Name)
+	}
+
+	switch {
+	case projected > hpa.Spec.MaxReplicas:
+		notes = append(notes, fmt.Sprintf("The metrics ask for %d replicas but maxReplicas caps the deployment at %d", projected, hpa.Spec.MaxReplicas))
+		projected = hpa.Spec.MaxReplicas
+	case projected < minReplicas:
+		projected = minReplicas
+	}
+
+	result := map[string]interface{}{
+		"deployment":         name,
+		"namespace":          namespace,
+		"hpa":                hpa.Name,
+		"current_replicas":   current,
+		"projected_replicas": projected,
+		"trigger":            trigger,
+		"min_replicas":       minReplicas,
+		"max_replicas":       hpa.Spec.MaxReplicas,
+		"metrics":            projections,
+		"requests_per_pod":   requestStrings(requests),
+	}
+	if len(notes) > 0 {
+		result["notes"] = notes
+	}
+	return jsonResult(result)
+}
+
+// deploymentHPA returns the HorizontalPodAutoscaler that scales a deployment
+func (s *Server) deploymentHPA(ctx context.Context, namespace, deployment string) (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	list, err := s.clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i, hpa := range list.Items {
+		if hpa.Spec.ScaleTargetRef.Kind == "Deployment" && hpa.Spec.ScaleTargetRef.Name == deployment {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no HorizontalPodAutoscaler in namespace %s scales deployment %s", namespace, deployment)
+}
+
+// hpaResourceTarget is an HPA's cpu or memory target as a percentage of the pod request
+type hpaResourceTarget struct {
+	resource       corev1.ResourceName
+	percent        float64
+	missingRequest bool
+}
+
+// hpaResourceTargets returns the HPA's cpu and memory targets as utilization percentages; average value targets are
+// converted using the pod requests. An HPA without metrics targets 80% CPU. Container resource targets are
+// approximated against the whole pod's requests.
+func hpaResourceTargets(hpa *autoscalingv2.HorizontalPodAutoscaler, requests corev1.ResourceList) []hpaResourceTarget {
+	if len(hpa.Spec.Metrics) == 0 {
+		_, hasRequest := requests[corev1.ResourceCPU]
+		return []hpaResourceTarget{{resource: corev1.ResourceCPU, percent: defaultHPACPUUtilization, missingRequest: !hasRequest}}
+	}
+
+	var targets []hpaResourceTarget
+	for _, metric := range hpa.Spec.Metrics {
+		var name corev1.ResourceName
+		var target autoscalingv2.MetricTarget
+		switch {
+		case metric.Type == autoscalingv2.ResourceMetricSourceType && metric.Resource != nil:
+			name, target = metric.Resource.Name, metric.Resource.Target
+		case metric.Type == autoscalingv2.ContainerResourceMetricSourceType && metric.ContainerResource != nil:
+			name, target = metric.ContainerResource.Name, metric.ContainerResource.Target
+		default:
+			continue
+		}
+		if name != corev1.ResourceCPU && name != corev1.ResourceMemory {
+			continue
+		}
+
+		request, hasRequest := requests[name]
+		entry := hpaResourceTarget{resource: name, missingRequest: !hasRequest}
+		switch {
+		case target.AverageUtilization != nil:
+			entry.percent = float64(*target.AverageUtilization)
+		case target.AverageValue != nil && hasRequest && request.AsApproximateFloat64() > 0:
+			// An average value target does not need requests, but the simulation is in percent of them
+			entry.percent = 100 * target.AverageValue.AsApproximateFloat64() / request.AsApproximateFloat64()
+			entry.missingRequest = false
+		default:
+			continue
+		}
+		targets = append(targets, entry)
+	}
+	return targets
+}
+
+// desiredReplicas applies the HPA formula, keeping the current count when the ratio is within the controller's tolerance
+func desiredReplicas(current int32, utilization, target float64) (int32, bool) {
+	if target <= 0 {
+		return current, false
+	}
+	ratio := utilization / target
+	if math.Abs(ratio-1) <= hpaTolerance {
+		return current, true
+	}
+	return int32(math.Ceil(float64(current) * ratio)), false
+}
+
+// currentUtilization returns the utilization the HPA last observed for a resource, if it reports one
+func currentUtilization(hpa *autoscalingv2.HorizontalPodAutoscaler, resource corev1.ResourceName) *int32 {
+	for _, metric := range hpa.Status.CurrentMetrics {
+		if metric.Type == autoscalingv2.ResourceMetricSourceType && metric.Resource != nil && metric.Resource.Name == resource {
+			return metric.Resource.Current.AverageUtilization
+		}
+	}
+	return nil
+}
+
+// podRequests sums the cpu and memory requests of a deployment's pod template containers. A resource is left out
+// unless every container requests it, since the HPA cannot compute its utilization otherwise.
+func podRequests(deployment *appsv1.Deployment) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		var total resource.Quantity
+		complete := true
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			quantity, ok := container.Resources.Requests[name]
+			if !ok {
+				complete = false
+				break
+			}
+			total.Add(quantity)
+		}
+		if complete && len(deployment.Spec.Template.Spec.Containers) > 0 {
+			requests[name] = total
+		}
+	}
+	return requests
+}
+
+// requestStrings formats a resource list for a JSON result
+func requestStrings(resources corev1.ResourceList) map[string]string {
+	formatted := make(map[string]string, len(resources))
+	for name, quantity := range resources {
+		formatted[string(name)] = quantity.String()
+	}
+	return formatted
+}
+
+// floatArg returns an optional numeric argument, accepting JSON numbers and numeric strings
+func floatArg(args map[string]interface{}, key string) (float64, bool, error) {
+	switch value := args[key].(type) {
+	case nil:
+		return 0, false, nil
+	case float64:
+		return value, true, nil
+	case int:
+		return float64(value), true, nil
+	case string:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("%s must be a number: %w", key, err)
+		}
+		return f, true, nil
+	default:
+		return 0, false, fmt.Errorf("%s must be a number", key)
+	}
+}
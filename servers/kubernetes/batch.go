@@ -0,0 +1,108 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+)
+
+// batchManifestExtensions are the file extensions batch_apply_manifests applies
+var batchManifestExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// batchTools returns the batch manifest tool definitions
+func batchTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "batch_apply_manifests",
+			Description: "Apply every .yaml, .yml and .json manifest in a directory on the server using server-side apply, reporting per-file failures",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"manifests_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory of manifests, relative to the server's working directory",
+					},
+					"namespace_override": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to apply namespaced resources into, replacing any namespace set in the manifests",
+					},
+				},
+				"required": []string{"manifests_dir"},
+			},
+		},
+	}
+}
+
+// batchFailure is a manifest file that could not be applied
+type batchFailure struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+func (s *Server) batchApplyManifestsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	dir, err := stringArg(args, "manifests_dir")
+	if err != nil {
+		return nil, err
+	}
+	// Only directories below the working directory may be read
+	if !filepath.IsLocal(dir) {
+		return nil, fmt.Errorf("manifests_dir must be a relative path inside the server's working directory")
+	}
+	override := optionalStringArg(args, "namespace_override", "")
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifests_dir: %w", err)
+	}
+
+	ctx := context.Background()
+	total, succeeded := 0, 0
+	failed := []batchFailure{}
+	for _, entry := range entries {
+		if entry.IsDir() || !batchManifestExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			continue
+		}
+		total++
+
+		if err := s.applyManifestFile(ctx, filepath.Join(dir, entry.Name()), override); err != nil {
+			failed = append(failed, batchFailure{File: entry.Name(), Error: err.Error()})
+			continue
+		}
+		succeeded++
+	}
+
+	return jsonResult(map[string]interface{}{
+		"total":     total,
+		"succeeded": succeeded,
+		"failed":    failed,
+	})
+}
+
+// applyManifestFile applies the resources in one manifest file, optionally forcing their namespace
+func (s *Server) applyManifestFile(ctx context.Context, path, namespaceOverride string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	objects, err := decodeManifest(string(data))
+	if err != nil {
+		return err
+	}
+	if len(objects) == 0 {
+		return fmt.Errorf("manifest contains no resources")
+	}
+
+	namespace := "default"
+	if namespaceOverride != "" {
+		namespace = namespaceOverride
+		for _, obj := range objects {
+			obj.SetNamespace("")
+		}
+	}
+	_, err = s.applyObjects(ctx, objects, namespace)
+	return err
+}
@@ -0,0 +1,263 @@
+package kubernetes
+
+import (
+	"context"
+	"math"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// nodeMetricsGVR is the metrics-server node metrics resource
+var nodeMetricsGVR = schema.GroupVersionResource{
+	Group:    "metrics.k8s.io",
+	Version:  "v1beta1",
+	Resource: "nodes",
+}
+
+// pressureConditions are node conditions that report resource pressure when True
+var pressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+}
+
+// capacityTools returns the cluster capacity tool definitions
+func capacityTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_cluster_capacity",
+			Description: "Summarize CPU, memory and pod capacity, requested allocation and live utilization across all nodes",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+	}
+}
+
+// nodeCapacity is the capacity and usage summary of one node
+type nodeCapacity struct {
+	Name                     string   `json:"name"`
+	Status                   string   `json:"status"`
+	Pressure                 []string `json:"pressure,omitempty"`
+	CPUCapacity              string   `json:"cpu_capacity"`
+	CPUAllocated             string   `json:"cpu_allocated"`
+	CPUAllocatedPct          float64  `json:"cpu_allocated_pct"`
+	CPUUtilizationPct        *float64 `json:"cpu_utilization_pct,omitempty"`
+	MemoryCapacity           string   `json:"memory_capacity"`
+	MemoryAllocated          string   `json:"memory_allocated"`
+	MemoryAllocatedPct       float64  `json:"memory_allocated_pct"`
+	MemoryUtilizationPct     *float64 `json:"memory_utilization_pct,omitempty"`
+	PodsCapacity             int64    `json:"pods_capacity"`
+	Pods                     int      `json:"pods"`
+	EphemeralStorageCapacity string   `json:"ephemeral_storage_capacity,omitempty"`
+
+	cpuAllocatable    resource.Quantity
+	memoryAllocatable resource.Quantity
+	cpuRequested      resource.Quantity
+	memoryRequested   resource.Quantity
+	cpuUsage          *resource.Quantity
+	memoryUsage       *resource.Quantity
+}
+
+// nodeCapacities builds a capacity summary for every node, joining pod requests and metrics-server usage
+func (s *Server) nodeCapacities(ctx context.Context) ([]*nodeCapacity, bool, error) {
+	nodes, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, false, err
+	}
+	pods, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, false, err
+	}
+
+	summaries := make([]*nodeCapacity, 0, len(nodes.Items))
+	byName := make(map[string]*nodeCapacity, len(nodes.Items))
+	for _, node := range nodes.Items {
+		capacity := node.Status.Capacity
+		summary := &nodeCapacity{
+			Name:              node.Name,
+			Status:            nodeReadyStatus(node),
+			Pressure:          nodePressure(node),
+			CPUCapacity:       capacity.Cpu().String(),
+			MemoryCapacity:    capacity.Memory().String(),
+			PodsCapacity:      capacity.Pods().Value(),
+			cpuAllocatable:    *node.Status.Allocatable.Cpu(),
+			memoryAllocatable: *node.Status.Allocatable.Memory(),
+			cpuRequested:      *resource.NewMilliQuantity(0, resource.DecimalSI),
+			memoryRequested:   *resource.NewQuantity(0, resource.BinarySI),
+		}
+		if storage, ok := capacity[corev1.ResourceEphemeralStorage]; ok {
+			summary.EphemeralStorageCapacity = storage.String()
+		}
+		summaries = append(summaries, summary)
+		byName[node.Name] = summary
+	}
+
+	for _, pod := range pods.Items {
+		summary, ok := byName[pod.Spec.NodeName]
+		if !ok || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		summary.Pods++
+		for _, container := range pod.Spec.Containers {
+			summary.cpuRequested.Add(*container.Resources.Requests.Cpu())
+			summary.memoryRequested.Add(*container.Resources.Requests.Memory())
+		}
+	}
+
+	metricsAvailable := s.addNodeUsage(ctx, byName)
+
+	for _, summary := range summaries {
+		summary.CPUAllocated = summary.cpuRequested.String()
+		summary.MemoryAllocated = summary.memoryRequested.String()
+		summary.CPUAllocatedPct = percentOf(summary.cpuRequested, summary.cpuAllocatable)
+		summary.MemoryAllocatedPct = percentOf(summary.memoryRequested, summary.memoryAllocatable)
+		if summary.cpuUsage != nil {
+			pct := percentOf(*summary.cpuUsage, summary.cpuAllocatable)
+			summary.CPUUtilizationPct = &pct
+		}
+		if summary.memoryUsage != nil {
+			pct := percentOf(*summary.memoryUsage, summary.memoryAllocatable)
+			summary.MemoryUtilizationPct = &pct
+		}
+	}
+
+	return summaries, metricsAvailable, nil
+}
+
+// addNodeUsage records metrics-server usage on each node summary, returning false if metrics are unavailable
+func (s *Server) addNodeUsage(ctx context.Context, byName map[string]*nodeCapacity) bool {
+	list, err := s.dynamicClient.Resource(nodeMetricsGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false
+	}
+
+	for _, item := range list.Items {
+		summary, ok := byName[item.GetName()]
+		if !ok {
+			continue
+		}
+		usage, _, _ := unstructured.NestedStringMap(item.Object, "usage")
+		if q, err := resource.ParseQuantity(usage["cpu"]); err == nil {
+			summary.cpuUsage = &q
+		}
+		if q, err := resource.ParseQuantity(usage["memory"]); err == nil {
+			summary.memoryUsage = &q
+		}
+	}
+	return true
+}
+
+// nodeReadyStatus returns Ready, NotReady or Unknown from the node's Ready condition
+func nodeReadyStatus(node corev1.Node) string {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type != corev1.NodeReady {
+			continue
+		}
+		switch condition.Status {
+		case corev1.ConditionTrue:
+			return "Ready"
+		case corev1.ConditionFalse:
+			return "NotReady"
+		}
+	}
+	return "Unknown"
+}
+
+// nodePressure lists the pressure conditions currently reported by the node
+func nodePressure(node corev1.Node) []string {
+	var pressure []string
+	for _, condition := range node.Status.Conditions {
+		for _, conditionType := range pressureConditions {
+			if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+				pressure = append(pressure, string(conditionType))
+			}
+		}
+	}
+	return pressure
+}
+
+// percentOf returns part as a percentage of total, rounded to one decimal place
+func percentOf(part, total resource.Quantity) float64 {
+	if total.IsZero() {
+		return 0
+	}
+	pct := float64(part.MilliValue()) / float64(total.MilliValue()) * 100
+	return math.Round(pct*10) / 10
+}
+
+func (s *Server) getClusterCapacityTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	summaries, metricsAvailable, err := s.nodeCapacities(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	cpuAllocatable := resource.NewMilliQuantity(0, resource.DecimalSI)
+	memoryAllocatable := resource.NewQuantity(0, resource.BinarySI)
+	cpuRequested := resource.NewMilliQuantity(0, resource.DecimalSI)
+	memoryRequested := resource.NewQuantity(0, resource.BinarySI)
+	cpuUsage := resource.NewMilliQuantity(0, resource.DecimalSI)
+	memoryUsage := resource.NewQuantity(0, resource.BinarySI)
+	var podsCapacity int64
+	var pods int
+	var ready int
+	var underPressure []string
+
+	for _, summary := range summaries {
+		cpuAllocatable.Add(summary.cpuAllocatable)
+		memoryAllocatable.Add(summary.memoryAllocatable)
+		cpuRequested.Add(summary.cpuRequested)
+		memoryRequested.Add(summary.memoryRequested)
+		if summary.cpuUsage != nil {
+			cpuUsage.Add(*summary.cpuUsage)
+		}
+		if summary.memoryUsage != nil {
+			memoryUsage.Add(*summary.memoryUsage)
+		}
+		podsCapacity += summary.PodsCapacity
+		pods += summary.Pods
+		if summary.Status == "Ready" {
+			ready++
+		}
+		if len(summary.Pressure) > 0 {
+			underPressure = append(underPressure, summary.Name)
+		}
+	}
+
+	cpuAvailable := cpuAllocatable.DeepCopy()
+	cpuAvailable.Sub(*cpuRequested)
+	memoryAvailable := memoryAllocatable.DeepCopy()
+	memoryAvailable.Sub(*memoryRequested)
+
+	totals := map[string]interface{}{
+		"nodes":                len(summaries),
+		"ready_nodes":          ready,
+		"cpu_allocatable":      cpuAllocatable.String(),
+		"cpu_allocated":        cpuRequested.String(),
+		"cpu_available":        cpuAvailable.String(),
+		"cpu_allocated_pct":    percentOf(*cpuRequested, *cpuAllocatable),
+		"memory_allocatable":   memoryAllocatable.String(),
+		"memory_allocated":     memoryRequested.String(),
+		"memory_available":     memoryAvailable.String(),
+		"memory_allocated_pct": percentOf(*memoryRequested, *memoryAllocatable),
+		"pods_capacity":        podsCapacity,
+		"pods":                 pods,
+	}
+	if metricsAvailable {
+		totals["cpu_utilization_pct"] = percentOf(*cpuUsage, *cpuAllocatable)
+		totals["memory_utilization_pct"] = percentOf(*memoryUsage, *memoryAllocatable)
+	}
+
+	return jsonResult(map[string]interface{}{
+		"totals":            totals,
+		"metrics_available": metricsAvailable,
+		"under_pressure":    underPressure,
+		"nodes":             summaries,
+	})
+}
@@ -0,0 +1,243 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// certificateWarningDays is how close to expiry a certificate is reported as expiring soon
+const certificateWarningDays = 30
+
+// certManagerCertificateAnnotation names the cert-manager Certificate that issued a Secret
+const certManagerCertificateAnnotation = "cert-manager.io/certificate-name"
+
+// certManagerCertificateGVR is the cert-manager Certificate resource
+var certManagerCertificateGVR = schema.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+// certificateTools returns the TLS certificate tool definitions
+func certificateTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_certificate_expiry",
+			Description: "List the certificates in a namespace's TLS Secrets with their names, issuer and expiry, soonest first, warning about those expiring within 30 days and showing the renewal status of cert-manager managed ones",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to check (defaults to default)",
+					},
+				},
+			},
+		},
+	}
+}
+
+// certificateExpiry is the leaf certificate of one TLS Secret
+type certificateExpiry struct {
+	SecretName    string   `json:"secret_name"`
+	Namespace     string   `json:"namespace"`
+	CommonName    string   `json:"common_name"`
+	SANs          []string `json:"sans"`
+	NotAfter      string   `json:"not_after"`
+	ExpiresInDays int      `json:"expires_in_days"`
+	Issuer        string   `json:"issuer"`
+	// cert-manager details, set when the Secret carries its certificate-name annotation
+	Certificate      string `json:"certificate,omitempty"`
+	CertificateReady string `json:"certificate_ready,omitempty"`
+	RenewalTime      string `json:"renewal_time,omitempty"`
+
+	notAfter time.Time
+}
+
+func (s *Server) getCertificateExpiryTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "default")
+	ctx := context.Background()
+
+	secrets, err := s.clientset.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", string(corev1.SecretTypeTLS)).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	certificates := []*certificateExpiry{}
+	var warnings []string
+	managed := false
+	for _, secret := range secrets.Items {
+		leaf, chain, err := parseCertificateChain(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Secret %s: %v", secret.Name, err))
+			continue
+		}
+
+		expiry := &certificateExpiry{
+			SecretName:    secret.Name,
+			Namespace:     namespace,
+			CommonName:    leaf.Subject.CommonName,
+			SANs:          certificateSANs(leaf),
+			NotAfter:      leaf.NotAfter.UTC().Format(time.RFC3339),
+			ExpiresInDays: int(leaf.NotAfter.Sub(now).Hours() / 24),
+			Issuer:        leaf.Issuer.CommonName,
+			Certificate:   secret.Annotations[certManagerCertificateAnnotation],
+			notAfter:      leaf.NotAfter,
+		}
+		if expiry.Issuer == "" {
+			expiry.Issuer = leaf.Issuer.String()
+		}
+		managed = managed || expiry.Certificate != ""
+		certificates = append(certificates, expiry)
+
+		// Clients reject the chain once any certificate in it expires, even if the leaf is still valid
+		for _, intermediate := range chain {
+			if intermediate.NotAfter.Before(leaf.NotAfter) && intermediate.NotAfter.Sub(now) < certificateWarningDays*24*time.Hour {
+				warnings = append(warnings, fmt.Sprintf("Secret %s bundles intermediate certificate %s, which expires %s, before its own certificate", secret.Name, intermediate.Subject.CommonName, intermediate.NotAfter.UTC().Format(time.RFC3339)))
+			}
+		}
+	}
+	sort.Slice(certificates, func(i, j int) bool {
+		if !certificates[i].notAfter.Equal(certificates[j].notAfter) {
+			return certificates[i].notAfter.Before(certificates[j].notAfter)
+		}
+		return certificates[i].SecretName < certificates[j].SecretName
+	})
+
+	certManagerInstalled, statusUnknown := false, false
+	if managed {
+		// The expiry report stands without cert-manager's view, so failing to read it, e.g. for lack of RBAC, is only noted
+		if certManagerInstalled, err = s.addCertManagerStatus(ctx, namespace, certificates); err != nil {
+			statusUnknown = true
+			warnings = append(warnings, fmt.Sprintf("cert-manager Certificates could not be listed: %v", err))
+		}
+	}
+
+	expiringSoon := 0
+	for _, expiry := range certificates {
+		if expiry.ExpiresInDays >= certificateWarningDays {
+			continue
+		}
+		expiringSoon++
+		state := fmt.Sprintf("expires in %d day(s) on %s", expiry.ExpiresInDays, expiry.NotAfter)
+		if expiry.notAfter.Before(now) {
+			state = "expired on " + expiry.NotAfter
+		}
+		warning := fmt.Sprintf("Secret %s (%s) %s", expiry.SecretName, expiry.CommonName, state)
+		switch {
+		case expiry.Certificate == "":
+			warning += "; renew it and update the Secret"
+		case statusUnknown:
+			warning += fmt.Sprintf("; it was issued for cert-manager Certificate %s, whose renewal status could not be read", expiry.Certificate)
+		case !certManagerInstalled:
+			warning += fmt.Sprintf("; it was issued for cert-manager Certificate %s, but cert-manager is not installed, so nothing will renew it", expiry.Certificate)
+		case expiry.CertificateReady == "":
+			warning += fmt.Sprintf("; it was issued for cert-manager Certificate %s, which no longer exists, so nothing will renew it", expiry.Certificate)
+		case expiry.CertificateReady == "True":
+			warning += fmt.Sprintf("; cert-manager Certificate %s is Ready and should renew it before then", expiry.Certificate)
+		default:
+			warning += fmt.Sprintf("; cert-manager Certificate %s is not Ready, so check kubectl describe certificate %s -n %s for why it has not renewed", expiry.Certificate, expiry.Certificate, namespace)
+		}
+		warnings = append(warnings, warning)
+	}
+
+	result := map[string]interface{}{
+		"namespace":     namespace,
+		"certificates":  certificates,
+		"expiring_soon": expiringSoon,
+	}
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+	return jsonResult(result)
+}
+
+// parseCertificateChain returns the leaf certificate of a PEM bundle and the certificates bundled after it
+func parseCertificateChain(data []byte) (*x509.Certificate, []*x509.Certificate, error) {
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("no %s data", corev1.TLSCertKey)
+	}
+	var certificates []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", corev1.TLSCertKey, err)
+		}
+		certificates = append(certificates, certificate)
+	}
+	if len(certificates) == 0 {
+		return nil, nil, fmt.Errorf("%s holds no PEM certificate", corev1.TLSCertKey)
+	}
+	return certificates[0], certificates[1:], nil
+}
+
+// certificateSANs lists a certificate's subject alternative names
+func certificateSANs(certificate *x509.Certificate) []string {
+	sans := append([]string{}, certificate.DNSNames...)
+	for _, ip := range certificate.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, certificate.EmailAddresses...)
+	for _, uri := range certificate.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
+
+// addCertManagerStatus records the Ready condition and renewal time of the cert-manager Certificates behind
+// the Secrets, returning false when cert-manager is not installed
+func (s *Server) addCertManagerStatus(ctx context.Context, namespace string, certificates []*certificateExpiry) (bool, error) {
+	list, err := s.dynamicClient.Resource(certManagerCertificateGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	byName := map[string]unstructured.Unstructured{}
+	for _, certificate := range list.Items {
+		byName[certificate.GetName()] = certificate
+	}
+	for _, expiry := range certificates {
+		certificate, ok := byName[expiry.Certificate]
+		if expiry.Certificate == "" || !ok {
+			continue
+		}
+		expiry.CertificateReady = "Unknown"
+		conditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+		for _, raw := range conditions {
+			condition, _ := raw.(map[string]interface{})
+			if condition["type"] == "Ready" {
+				if status, ok := condition["status"].(string); ok {
+					expiry.CertificateReady = status
+				}
+			}
+		}
+		expiry.RenewalTime, _, _ = unstructured.NestedString(certificate.Object, "status", "renewalTime")
+	}
+	return true, nil
+}
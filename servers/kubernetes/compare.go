@@ -0,0 +1,169 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// compareTools returns the deployment comparison tool definitions
+func compareTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "compare_deployments",
+			Description: "Compare the configuration of two deployments, such as canary and stable or staging and production: images, env vars, resources, replicas, labels and scheduling",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name_a": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the first deployment",
+					},
+					"namespace_a": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the first deployment (defaults to default)",
+					},
+					"name_b": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the second deployment (defaults to name_a)",
+					},
+					"namespace_b": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the second deployment (defaults to namespace_a)",
+					},
+				},
+				"required": []string{"name_a"},
+			},
+		},
+	}
+}
+
+// fieldDifference is a compared field whose values differ; a value is null when the deployment lacks the field
+type fieldDifference struct {
+	Field  string      `json:"field"`
+	AValue interface{} `json:"a_value"`
+	BValue interface{} `json:"b_value"`
+}
+
+func (s *Server) compareDeploymentsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	nameA, err := stringArg(args, "name_a")
+	if err != nil {
+		return nil, err
+	}
+	namespaceA := optionalStringArg(args, "namespace_a", "default")
+	nameB := optionalStringArg(args, "name_b", nameA)
+	namespaceB := optionalStringArg(args, "namespace_b", namespaceA)
+	if nameA == nameB && namespaceA == namespaceB {
+		return nil, fmt.Errorf("name_b or namespace_b must differ from the first deployment")
+	}
+	ctx := context.Background()
+
+	a, err := s.clientset.AppsV1().Deployments(namespaceA).Get(ctx, nameA, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.clientset.AppsV1().Deployments(namespaceB).Get(ctx, nameB, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	same, different := compareFields(deploymentFields(a), deploymentFields(b))
+	return jsonResult(map[string]interface{}{
+		"a":                fmt.Sprintf("%s/%s", namespaceA, nameA),
+		"b":                fmt.Sprintf("%s/%s", namespaceB, nameB),
+		"fields_same":      same,
+		"fields_different": different,
+	})
+}
+
+// deploymentFields flattens the comparable configuration of a deployment into named fields.
+// Server-populated metadata such as uid and resourceVersion is never included.
+func deploymentFields(deployment *appsv1.Deployment) map[string]interface{} {
+	fields := map[string]interface{}{}
+	set := func(field string, value interface{}) {
+		if v := genericValue(value); v != nil {
+			fields[field] = v
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	set("replicas", replicas)
+	set("strategy", deployment.Spec.Strategy)
+	for key, value := range deployment.Labels {
+		set("labels."+key, value)
+	}
+	for key, value := range deployment.Spec.Template.Labels {
+		set("template.labels."+key, value)
+	}
+
+	podSpec := deployment.Spec.Template.Spec
+	if podSpec.ServiceAccountName != "" {
+		set("serviceAccountName", podSpec.ServiceAccountName)
+	}
+	if len(podSpec.NodeSelector) > 0 {
+		set("nodeSelector", podSpec.NodeSelector)
+	}
+	if podSpec.Affinity != nil {
+		set("affinity", podSpec.Affinity)
+	}
+	if len(podSpec.Tolerations) > 0 {
+		set("tolerations", podSpec.Tolerations)
+	}
+
+	for _, container := range podSpec.Containers {
+		prefix := fmt.Sprintf("containers[%s].", container.Name)
+		set(prefix+"image", container.Image)
+		if len(container.Command) > 0 {
+			set(prefix+"command", container.Command)
+		}
+		if len(container.Args) > 0 {
+			set(prefix+"args", container.Args)
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom != nil {
+				set(prefix+"env."+env.Name, map[string]interface{}{"valueFrom": env.ValueFrom})
+			} else {
+				set(prefix+"env."+env.Name, env.Value)
+			}
+		}
+		for resource, quantity := range container.Resources.Limits {
+			set(prefix+"resources.limits."+string(resource), quantity.String())
+		}
+		for resource, quantity := range container.Resources.Requests {
+			set(prefix+"resources.requests."+string(resource), quantity.String())
+		}
+		if len(container.Ports) > 0 {
+			set(prefix+"ports", container.Ports)
+		}
+	}
+	return fields
+}
+
+// compareFields splits the union of two field sets into the names of equal fields and the differences, both sorted
+func compareFields(a, b map[string]interface{}) ([]string, []fieldDifference) {
+	names := map[string]bool{}
+	for name := range a {
+		names[name] = true
+	}
+	for name := range b {
+		names[name] = true
+	}
+
+	same := []string{}
+	different := []fieldDifference{}
+	for _, name := range sortedKeys(names) {
+		if reflect.DeepEqual(a[name], b[name]) {
+			same = append(same, name)
+		} else {
+			different = append(different, fieldDifference{Field: name, AValue: a[name], BValue: b[name]})
+		}
+	}
+	return same, different
+}
@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// configMapTools returns the ConfigMap tool definitions
+func configMapTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "render_configmap_template",
+			Description: "Render each value of a ConfigMap as a Go template with the given variables and return the original and rendered values",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the ConfigMap",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the ConfigMap",
+					},
+					"vars": map[string]interface{}{
+						"type":        "object",
+						"description": "Variables available to the templates as {{ .key }}",
+					},
+				},
+				"required": []string{"name", "namespace"},
+			},
+		},
+	}
+}
+
+// renderedValue pairs a ConfigMap value with its rendered form
+type renderedValue struct {
+	Original string `json:"original"`
+	Rendered string `json:"rendered"`
+}
+
+func (s *Server) renderConfigMapTemplateTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+
+	vars := map[string]interface{}{}
+	if raw, ok := args["vars"]; ok && raw != nil {
+		vars, ok = raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("vars must be an object")
+		}
+	}
+
+	configMap, err := s.clientset.CoreV1().ConfigMaps(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := make(map[string]renderedValue, len(configMap.Data))
+	for key, value := range configMap.Data {
+		out, err := renderTemplate(key, value, vars)
+		if err != nil {
+			return nil, err
+		}
+		rendered[key] = renderedValue{Original: value, Rendered: out}
+	}
+
+	return jsonResult(map[string]interface{}{
+		"name":      name,
+		"namespace": namespace,
+		"data":      rendered,
+	})
+}
+
+// renderTemplate executes a single ConfigMap value as a Go template, failing on missing variables
+func renderTemplate(key, value string, vars map[string]interface{}) (string, error) {
+	tmpl, err := template.New(key).Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template for key %s: %w", key, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("failed to render template for key %s: %w", key, err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,231 @@
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// connectivityProbeMarker starts the line the probe script reports its result on
+const connectivityProbeMarker = "mcp-probe"
+
+// destinationHostPattern matches pod IPs, IPv6 addresses and service DNS names; anything else could escape the probe script
+var destinationHostPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9.:-]*$`)
+
+// wgetUnreachableErrors are wget messages meaning no TCP connection was made; other failures, such as an HTTP
+// error or a non-HTTP server closing the connection, happen after connecting
+var wgetUnreachableErrors = []string{
+	"refused",
+	"timed out",
+	"timeout",
+	"bad address",
+	"can't connect",
+	"unable to resolve",
+	"could not resolve",
+	"name or service not known",
+	"no route to host",
+	"network is unreachable",
+}
+
+// connectivityTools returns the network connectivity tool definitions
+func connectivityTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "test_connectivity",
+			Description: "Check whether a pod can open a connection to a pod IP or service on a port by running nc -z, or wget when nc is missing, inside the source pod; use it to verify NetworkPolicies",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"source_pod": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the pod to connect from",
+					},
+					"source_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the source pod (defaults to default)",
+					},
+					"container": map[string]interface{}{
+						"type":        "string",
+						"description": "Container of the source pod to run the check in (defaults to the pod's default container)",
+					},
+					"destination_host": map[string]interface{}{
+						"type":        "string",
+						"description": "Pod IP, service name or service DNS name to connect to",
+					},
+					"destination_port": map[string]interface{}{
+						"type":        "integer",
+						"description": "Port to connect to",
+					},
+					"protocol": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"tcp", "udp"},
+						"description": "Protocol to test (defaults to tcp)",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Seconds to wait for the connection (defaults to 5, at most 30)",
+					},
+				},
+				"required": []string{"source_pod", "destination_host", "destination_port"},
+			},
+		},
+	}
+}
+
+func (s *Server) testConnectivityTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	pod, err := stringArg(args, "source_pod")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "source_namespace", "default")
+	host, err := stringArg(args, "destination_host")
+	if err != nil {
+		return nil, err
+	}
+	if !destinationHostPattern.MatchString(host) {
+		return nil, fmt.Errorf("destination_host %q must be an IP address or DNS name", host)
+	}
+	port, err := intArg(args, "destination_port", 0)
+	if err != nil {
+		return nil, err
+	}
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("destination_port must be between 1 and 65535")
+	}
+	protocol := strings.ToLower(optionalStringArg(args, "protocol", "tcp"))
+	if protocol != "tcp" && protocol != "udp" {
+		return nil, fmt.Errorf("protocol must be tcp or udp, got %q", protocol)
+	}
+	timeout, err := intArg(args, "timeout_seconds", 5)
+	if err != nil {
+		return nil, err
+	}
+	if timeout < 1 || timeout > 30 {
+		return nil, fmt.Errorf("timeout_seconds must be between 1 and 30")
+	}
+
+	kubectlArgs := []string{"exec", pod, "-n", namespace}
+	if container := optionalStringArg(args, "container", ""); container != "" {
+		kubectlArgs = append(kubectlArgs, "-c", container)
+	}
+	kubectlArgs = append(kubectlArgs, "--", "sh", "-c", connectivityProbeScript(host, port, protocol, timeout))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second+kubectlTimeout)
+	defer cancel()
+	stdout, stderr, err := s.runKubectl(ctx, kubectlArgs...)
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("connectivity check from pod %s/%s timed out", namespace, pod)
+	}
+	if err != nil {
+		// The probe script always exits 0, so a failure is kubectl's: a missing pod, no shell or no exec permission
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("failed to exec into pod %s/%s: %s", namespace, pod, strings.TrimSpace(stderr))
+		}
+		return nil, fmt.Errorf("failed to run kubectl: %w", err)
+	}
+
+	method, code, output, err := parseProbeOutput(stdout)
+	if err != nil {
+		return nil, err
+	}
+	if method == "none" {
+		if protocol == "udp" {
+			return nil, fmt.Errorf("pod %s/%s has no nc supporting -z and -u, which a UDP check needs", namespace, pod)
+		}
+		return nil, fmt.Errorf("pod %s/%s has neither nc nor wget; run the check from a pod that has one, e.g. a busybox debug container", namespace, pod)
+	}
+
+	connected := code == 0
+	if method == "wget" && !connected {
+		// wget speaks HTTP, so an error after connecting still proves the port is reachable
+		connected = true
+		lower := strings.ToLower(output)
+		for _, unreachable := range wgetUnreachableErrors {
+			if strings.Contains(lower, unreachable) {
+				connected = false
+				break
+			}
+		}
+	}
+	if method == "wget" {
+		// Without -q GNU wget logs each step and ends with "Giving up."; the line before holds the outcome
+		output = strings.TrimSpace(strings.TrimSuffix(output, "Giving up."))
+		output = output[strings.LastIndex(output, "\n")+1:]
+	}
+
+	s.audit("test_connectivity", logrus.Fields{
+		"pod":         namespace + "/" + pod,
+		"destination": fmt.Sprintf("%s:%d/%s", host, port, protocol),
+		"connected":   connected,
+	})
+
+	result := map[string]interface{}{
+		"source_pod":       pod,
+		"source_namespace": namespace,
+		"destination_host": host,
+		"destination_port": port,
+		"protocol":         protocol,
+		"connected":        connected,
+		"method":           method,
+	}
+	if output != "" {
+		result["output"] = output
+	}
+	if protocol == "udp" {
+		result["note"] = "UDP has no handshake: a success only means no ICMP port-unreachable came back, so a dropped packet also looks reachable"
+	} else if !connected {
+		result["note"] = "The connection failed; a timeout usually means a NetworkPolicy or firewall dropped it, a refusal that nothing listens on the port"
+	}
+	return jsonResult(result)
+}
+
+// connectivityProbeScript builds the shell script that tries nc -z, then wget for TCP, and reports which it used.
+// host must already match destinationHostPattern.
+func connectivityProbeScript(host string, port int, protocol string, timeout int) string {
+	ncFlags := fmt.Sprintf("-z -w %d", timeout)
+	if protocol == "udp" {
+		ncFlags += " -u"
+	}
+	target := fmt.Sprintf("%s:%d", host, port)
+	if strings.Contains(host, ":") {
+		target = fmt.Sprintf("[%s]:%d", host, port)
+	}
+
+	var b strings.Builder
+	// Minimal busybox builds ship an nc without -z, which prints its usage instead of connecting
+	fmt.Fprintf(&b, "if command -v nc >/dev/null 2>&1; then out=$(nc %s %s %d 2>&1); code=$?; "+
+		"case \"$out\" in *sage:*|*\"nvalid option\"*|*\"nrecognized option\"*) ;; *) echo \"$out\"; echo \"%s nc $code\"; exit 0;; esac; fi; ",
+		ncFlags, host, port, connectivityProbeMarker)
+	if protocol == "tcp" {
+		// GNU wget retries 20 times by default and hides its errors under -q; busybox wget has no -t and always prints them
+		fmt.Fprintf(&b, "if command -v wget >/dev/null 2>&1; then tries=; case \"$(wget --version 2>&1)\" in *GNU*) tries=\"-t 1\";; esac; "+
+			"out=$(wget $tries -T %d -O /dev/null http://%s/ 2>&1); code=$?; echo \"$out\"; echo \"%s wget $code\"; exit 0; fi; ",
+			timeout, target, connectivityProbeMarker)
+	}
+	fmt.Fprintf(&b, "echo \"%s none 0\"", connectivityProbeMarker)
+	return b.String()
+}
+
+// parseProbeOutput returns the tool the probe script used, its exit code and its output
+func parseProbeOutput(stdout string) (string, int, string, error) {
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) != 3 || fields[0] != connectivityProbeMarker {
+		return "", 0, "", fmt.Errorf("unexpected connectivity check output: %s", strings.TrimSpace(stdout))
+	}
+	code, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return "", 0, "", fmt.Errorf("unexpected connectivity check exit code %q", fields[2])
+	}
+	output := strings.TrimSpace(strings.Join(lines[:len(lines)-1], "\n"))
+	return fields[1], code, output, nil
+}
@@ -0,0 +1,159 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// copyTools returns the cross-namespace copy tool definitions
+func copyTools() []mcp.Tool {
+	copySchema := func(kind string) map[string]interface{} {
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"src_name": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Name of the source %s", kind),
+				},
+				"src_namespace": map[string]interface{}{
+					"type":        "string",
+					"description": fmt.Sprintf("Namespace of the source %s", kind),
+				},
+				"dst_namespace": map[string]interface{}{
+					"type":        "string",
+					"description": "Namespace to copy into",
+				},
+				"overwrite": map[string]interface{}{
+					"type":        "boolean",
+					"description": fmt.Sprintf("Replace an existing %s in the destination namespace", kind),
+				},
+			},
+			"required": []string{"src_name", "src_namespace", "dst_namespace"},
+		}
+	}
+
+	return []mcp.Tool{
+		{
+			Name:        "copy_secret",
+			Description: "Copy a Secret to another namespace",
+			InputSchema: copySchema("Secret"),
+		},
+		{
+			Name:        "copy_configmap",
+			Description: "Copy a ConfigMap to another namespace",
+			InputSchema: copySchema("ConfigMap"),
+		},
+	}
+}
+
+// copyArgs reads the arguments shared by the copy tools
+func copyArgs(args map[string]interface{}) (name, srcNamespace, dstNamespace string, err error) {
+	if name, err = stringArg(args, "src_name"); err != nil {
+		return
+	}
+	if srcNamespace, err = stringArg(args, "src_namespace"); err != nil {
+		return
+	}
+	if dstNamespace, err = stringArg(args, "dst_namespace"); err != nil {
+		return
+	}
+	if srcNamespace == dstNamespace {
+		err = fmt.Errorf("source and destination namespaces must differ")
+	}
+	return
+}
+
+// copiedObjectMeta keeps only the portable fields of the source metadata
+func copiedObjectMeta(src metav1.ObjectMeta, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        src.Name,
+		Namespace:   namespace,
+		Labels:      src.Labels,
+		Annotations: src.Annotations,
+	}
+}
+
+func (s *Server) copySecretTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, srcNamespace, dstNamespace, err := copyArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	overwrite := boolArg(args, "overwrite")
+	ctx := context.Background()
+	secrets := s.clientset.CoreV1().Secrets(dstNamespace)
+
+	src, err := s.clientset.CoreV1().Secrets(srcNamespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	dst := &corev1.Secret{
+		ObjectMeta: copiedObjectMeta(src.ObjectMeta, dstNamespace),
+		Type:       src.Type,
+		Data:       src.Data,
+		Immutable:  src.Immutable,
+	}
+
+	_, err = secrets.Create(ctx, dst, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) && overwrite {
+		existing, getErr := secrets.Get(ctx, name, metav1.GetOptions{})
+		if getErr != nil {
+			return nil, getErr
+		}
+		dst.ResourceVersion = existing.ResourceVersion
+		_, err = secrets.Update(ctx, dst, metav1.UpdateOptions{})
+	}
+	if apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("secret '%s' already exists in namespace '%s'; set overwrite to replace it", name, dstNamespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Successfully copied secret '%s' from namespace '%s' to '%s'", name, srcNamespace, dstNamespace)), nil
+}
+
+func (s *Server) copyConfigMapTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, srcNamespace, dstNamespace, err := copyArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	overwrite := boolArg(args, "overwrite")
+	ctx := context.Background()
+	configMaps := s.clientset.CoreV1().ConfigMaps(dstNamespace)
+
+	src, err := s.clientset.CoreV1().ConfigMaps(srcNamespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	dst := &corev1.ConfigMap{
+		ObjectMeta: copiedObjectMeta(src.ObjectMeta, dstNamespace),
+		Data:       src.Data,
+		BinaryData: src.BinaryData,
+		Immutable:  src.Immutable,
+	}
+
+	_, err = configMaps.Create(ctx, dst, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) && overwrite {
+		existing, getErr := configMaps.Get(ctx, name, metav1.GetOptions{})
+		if getErr != nil {
+			return nil, getErr
+		}
+		dst.ResourceVersion = existing.ResourceVersion
+		_, err = configMaps.Update(ctx, dst, metav1.UpdateOptions{})
+	}
+	if apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("configmap '%s' already exists in namespace '%s'; set overwrite to replace it", name, dstNamespace)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Successfully copied configmap '%s' from namespace '%s' to '%s'", name, srcNamespace, dstNamespace)), nil
+}
@@ -0,0 +1,235 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultJobLogLines is how many log lines get_last_job_logs returns per container by default
+const defaultJobLogLines = 100
+
+// cronJobTools returns the CronJob history tool definitions
+func cronJobTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "list_jobs_by_cronjob",
+			Description: "List the Jobs a CronJob has created, oldest first, with their status, duration and completion time",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"cronjob_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the CronJob",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the CronJob (defaults to default)",
+					},
+				},
+				"required": []string{"cronjob_name"},
+			},
+		},
+		{
+			Name:        "get_last_job_logs",
+			Description: "Get the pod logs of the most recent Job a CronJob created, e.g. to see why the last scheduled run failed",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"cronjob_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the CronJob",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the CronJob (defaults to default)",
+					},
+					"tail_lines": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Log lines to return per container (defaults to %d)", defaultJobLogLines),
+					},
+				},
+				"required": []string{"cronjob_name"},
+			},
+		},
+	}
+}
+
+// cronJobRun summarizes one Job created by a CronJob
+type cronJobRun struct {
+	Name           string `json:"name"`
+	CreatedAt      string `json:"created_at"`
+	Status         string `json:"status"`
+	Duration       string `json:"duration,omitempty"`
+	CompletionTime string `json:"completion_time,omitempty"`
+}
+
+func (s *Server) listJobsByCronJobTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "cronjob_name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	ctx := context.Background()
+
+	cronJob, err := s.clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := s.cronJobJobs(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	runs := make([]cronJobRun, 0, len(jobs))
+	for _, job := range jobs {
+		run := cronJobRun{
+			Name:      job.Name,
+			CreatedAt: job.CreationTimestamp.UTC().Format(time.RFC3339),
+			Status:    jobStatus(job),
+		}
+		if job.Status.StartTime != nil {
+			end := now
+			if job.Status.CompletionTime != nil {
+				end = job.Status.CompletionTime.Time
+			} else if finished := jobFinishTime(job); !finished.IsZero() {
+				end = finished
+			}
+			run.Duration = end.Sub(job.Status.StartTime.Time).Round(time.Second).String()
+		}
+		if job.Status.CompletionTime != nil {
+			run.CompletionTime = job.Status.CompletionTime.UTC().Format(time.RFC3339)
+		}
+		runs = append(runs, run)
+	}
+
+	result := map[string]interface{}{
+		"cronjob":   name,
+		"namespace": namespace,
+		"schedule":  cronJob.Spec.Schedule,
+		"suspended": cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend,
+		"jobs":      runs,
+	}
+	if cronJob.Status.LastScheduleTime != nil {
+		result["last_schedule_time"] = cronJob.Status.LastScheduleTime.UTC().Format(time.RFC3339)
+	}
+	if cronJob.Status.LastSuccessfulTime != nil {
+		result["last_successful_time"] = cronJob.Status.LastSuccessfulTime.UTC().Format(time.RFC3339)
+	}
+	return jsonResult(result)
+}
+
+func (s *Server) getLastJobLogsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "cronjob_name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	tailLines, err := intArg(args, "tail_lines", defaultJobLogLines)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+
+	jobs, err := s.cronJobJobs(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, fmt.Errorf("cronjob %s/%s has no jobs; it may not have run yet, or its job history limits removed them", namespace, name)
+	}
+	job := jobs[len(jobs)-1]
+
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on job %s: %w", job.Name, err)
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].CreationTimestamp.Before(&pods.Items[j].CreationTimestamp)
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Job %s (%s), created %s\n", job.Name, jobStatus(job), job.CreationTimestamp.UTC().Format(time.RFC3339))
+	if len(pods.Items) == 0 {
+		b.WriteString("\nThe job's pods no longer exist, so their logs are gone; set the job's ttlSecondsAfterFinished or the CronJob's history limits to keep them longer\n")
+	}
+	tail := int64(tailLines)
+	for _, pod := range pods.Items {
+		for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+			fmt.Fprintf(&b, "\n=== pod %s (%s), container %s ===\n", pod.Name, pod.Status.Phase, container.Name)
+			data, err := s.clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: container.Name,
+				TailLines: &tail,
+			}).DoRaw(ctx)
+			if err != nil {
+				fmt.Fprintf(&b, "(logs unavailable: %v)\n", err)
+				continue
+			}
+			b.WriteString(strings.TrimRight(string(data), "\n"))
+			b.WriteString("\n")
+		}
+	}
+	return textResult(b.String()), nil
+}
+
+// cronJobJobs returns the Jobs a CronJob owns, oldest first
+func (s *Server) cronJobJobs(ctx context.Context, namespace, cronJobName string) ([]batchv1.Job, error) {
+	list, err := s.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []batchv1.Job
+	for _, job := range list.Items {
+		for _, owner := range job.OwnerReferences {
+			if owner.Kind == "CronJob" && owner.Name == cronJobName {
+				jobs = append(jobs, job)
+				break
+			}
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreationTimestamp.Before(&jobs[j].CreationTimestamp)
+	})
+	return jobs, nil
+}
+
+// jobStatus returns Complete, Failed, Suspended or Running for a job
+func jobStatus(job batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return "Complete"
+		case batchv1.JobFailed:
+			return "Failed"
+		case batchv1.JobSuspended:
+			return "Suspended"
+		}
+	}
+	return "Running"
+}
+
+// jobFinishTime returns when a failed job gave up, since only successful jobs get a completion time
+func jobFinishTime(job batchv1.Job) time.Time {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,171 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// customResourceTools returns the custom resource tool definitions
+func customResourceTools() []mcp.Tool {
+	gvrProperties := func() map[string]interface{} {
+		return map[string]interface{}{
+			"group": map[string]interface{}{
+				"type":        "string",
+				"description": "API group of the custom resource, e.g. cert-manager.io",
+			},
+			"version": map[string]interface{}{
+				"type":        "string",
+				"description": "API version of the custom resource, e.g. v1",
+			},
+			"plural": map[string]interface{}{
+				"type":        "string",
+				"description": "Plural resource name, e.g. certificates",
+			},
+			"namespace": map[string]interface{}{
+				"type":        "string",
+				"description": "Namespace for namespaced resources (optional)",
+			},
+		}
+	}
+
+	getProperties := gvrProperties()
+	getProperties["name"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Name of the custom resource",
+	}
+	deleteProperties := gvrProperties()
+	deleteProperties["name"] = map[string]interface{}{
+		"type":        "string",
+		"description": "Name of the custom resource to delete",
+	}
+
+	return []mcp.Tool{
+		{
+			Name:        "list_custom_resources",
+			Description: "List instances of any custom resource, such as Operator, Flux or cert-manager resources",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": gvrProperties(),
+				"required":   []string{"group", "version", "plural"},
+			},
+		},
+		{
+			Name:        "get_custom_resource",
+			Description: "Get a single custom resource as raw JSON",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": getProperties,
+				"required":   []string{"group", "version", "plural", "name"},
+			},
+		},
+		{
+			Name:        "delete_custom_resource",
+			Description: "Delete a custom resource",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": deleteProperties,
+				"required":   []string{"group", "version", "plural", "name"},
+			},
+		},
+	}
+}
+
+// customResourceClient validates that a custom resource is served and returns a client scoped to it
+func (s *Server) customResourceClient(args map[string]interface{}) (dynamic.ResourceInterface, error) {
+	group, err := stringArg(args, "group")
+	if err != nil {
+		return nil, err
+	}
+	version, err := stringArg(args, "version")
+	if err != nil {
+		return nil, err
+	}
+	plural, err := stringArg(args, "plural")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "")
+
+	gv := schema.GroupVersion{Group: group, Version: version}
+	resources, err := s.clientset.Discovery().ServerResourcesForGroupVersion(gv.String())
+	if err != nil {
+		return nil, fmt.Errorf("API group version %s is not served: %w", gv.String(), err)
+	}
+
+	var namespaced, found bool
+	for _, r := range resources.APIResources {
+		if r.Name == plural {
+			namespaced, found = r.Namespaced, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("resource %s not found in %s", plural, gv.String())
+	}
+
+	resource := s.dynamicClient.Resource(gv.WithResource(plural))
+	if namespaced {
+		return resource.Namespace(namespace), nil
+	}
+	return resource, nil
+}
+
+func (s *Server) listCustomResourcesTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	client, err := s.customResourceClient(args)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]interface{}, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, item.Object)
+	}
+
+	return jsonResult(items)
+}
+
+func (s *Server) getCustomResourceTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	client, err := s.customResourceClient(args)
+	if err != nil {
+		return nil, err
+	}
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := client.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResult(obj.Object)
+}
+
+func (s *Server) deleteCustomResourceTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	client, err := s.customResourceClient(args)
+	if err != nil {
+		return nil, err
+	}
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Successfully deleted %s.%s/%s '%s'",
+		optionalStringArg(args, "plural", ""), optionalStringArg(args, "group", ""), optionalStringArg(args, "version", ""), name)), nil
+}
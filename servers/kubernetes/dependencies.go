@@ -0,0 +1,260 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// dependencyTools returns the resource dependency tool definitions
+func dependencyTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_resource_dependencies",
+			Description: "Return the graph of resources related to a deployment or pod: ReplicaSets, Pods, Services, ConfigMaps and Secrets",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"resource_type": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"deployment", "pod"},
+						"description": "Type of the root resource",
+					},
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the root resource",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the root resource (defaults to default)",
+					},
+				},
+				"required": []string{"resource_type", "name"},
+			},
+		},
+	}
+}
+
+// dependencyNode is a resource in a dependency graph
+type dependencyNode struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// dependencyEdge links two nodes, identified as Kind/name
+type dependencyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// dependencyGraph collects nodes and edges without duplicates
+type dependencyGraph struct {
+	Nodes []dependencyNode `json:"nodes"`
+	Edges []dependencyEdge `json:"edges"`
+	seen  map[string]bool
+}
+
+func newDependencyGraph() *dependencyGraph {
+	return &dependencyGraph{
+		Nodes: []dependencyNode{},
+		Edges: []dependencyEdge{},
+		seen:  make(map[string]bool),
+	}
+}
+
+// addNode adds a node if not present and returns its Kind/name identifier
+func (g *dependencyGraph) addNode(kind, name, namespace string) string {
+	id := kind + "/" + name
+	if !g.seen[id] {
+		g.seen[id] = true
+		g.Nodes = append(g.Nodes, dependencyNode{Kind: kind, Name: name, Namespace: namespace})
+	}
+	return id
+}
+
+// addEdge adds a node and an edge pointing to it
+func (g *dependencyGraph) addEdge(from, kind, name, namespace, edgeType string) string {
+	to := g.addNode(kind, name, namespace)
+	for _, edge := range g.Edges {
+		if edge.From == from && edge.To == to {
+			return to
+		}
+	}
+	g.Edges = append(g.Edges, dependencyEdge{From: from, To: to, Type: edgeType})
+	return to
+}
+
+func (s *Server) getResourceDependenciesTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	resourceType, err := stringArg(args, "resource_type")
+	if err != nil {
+		return nil, err
+	}
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+
+	graph := newDependencyGraph()
+	switch strings.ToLower(resourceType) {
+	case "deployment", "deployments", "deploy":
+		err = s.deploymentDependencies(graph, name, namespace)
+	case "pod", "pods", "po":
+		err = s.podDependencies(graph, name, namespace)
+	default:
+		return nil, fmt.Errorf("unsupported resource_type: %s (supported: deployment, pod)", resourceType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResult(graph)
+}
+
+// deploymentDependencies adds a deployment's ReplicaSets, Pods, Services and referenced configuration
+func (s *Server) deploymentDependencies(graph *dependencyGraph, name, namespace string) error {
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	root := graph.addNode("Deployment", name, namespace)
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return fmt.Errorf("invalid deployment selector: %w", err)
+	}
+
+	replicaSets, err := s.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range replicaSets.Items {
+		if !ownedBy(rs.OwnerReferences, deployment.UID) {
+			continue
+		}
+		rsID := graph.addEdge(root, "ReplicaSet", rs.Name, namespace, "owns")
+		for _, pod := range pods.Items {
+			if ownedBy(pod.OwnerReferences, rs.UID) {
+				graph.addEdge(rsID, "Pod", pod.Name, namespace, "owns")
+			}
+		}
+	}
+
+	if err := s.addServiceDependencies(graph, root, namespace, deployment.Spec.Template.Labels); err != nil {
+		return err
+	}
+	addConfigDependencies(graph, root, namespace, &deployment.Spec.Template.Spec)
+
+	return nil
+}
+
+// podDependencies adds a pod's Services and referenced configuration
+func (s *Server) podDependencies(graph *dependencyGraph, name, namespace string) error {
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	root := graph.addNode("Pod", name, namespace)
+
+	if err := s.addServiceDependencies(graph, root, namespace, pod.Labels); err != nil {
+		return err
+	}
+	addConfigDependencies(graph, root, namespace, &pod.Spec)
+
+	return nil
+}
+
+// addServiceDependencies links Services whose selector matches the given pod labels
+func (s *Server) addServiceDependencies(graph *dependencyGraph, from, namespace string, podLabels map[string]string) error {
+	services, err := s.clientset.CoreV1().Services(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, svc := range services.Items {
+		if len(svc.Spec.Selector) == 0 {
+			continue
+		}
+		if labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(podLabels)) {
+			graph.addEdge(from, "Service", svc.Name, namespace, "exposed_by")
+		}
+	}
+	return nil
+}
+
+// addConfigDependencies links ConfigMaps and Secrets referenced by volumes and container environment
+func addConfigDependencies(graph *dependencyGraph, from, namespace string, spec *corev1.PodSpec) {
+	visitConfigReferences(spec, func(kind, name, edgeType string) {
+		graph.addEdge(from, kind, name, namespace, edgeType)
+	})
+}
+
+// visitConfigReferences calls visit with the kind, name and use ("mounts" or "env") of each ConfigMap and Secret a pod spec references
+func visitConfigReferences(spec *corev1.PodSpec, visit func(kind, name, edgeType string)) {
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			visit("ConfigMap", volume.ConfigMap.Name, "mounts")
+		}
+		if volume.Secret != nil {
+			visit("Secret", volume.Secret.SecretName, "mounts")
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					visit("ConfigMap", source.ConfigMap.Name, "mounts")
+				}
+				if source.Secret != nil {
+					visit("Secret", source.Secret.Name, "mounts")
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				visit("ConfigMap", envFrom.ConfigMapRef.Name, "env")
+			}
+			if envFrom.SecretRef != nil {
+				visit("Secret", envFrom.SecretRef.Name, "env")
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+				visit("ConfigMap", ref.Name, "env")
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				visit("Secret", ref.Name, "env")
+			}
+		}
+	}
+}
+
+// ownedBy reports whether the owner references include the given UID
+func ownedBy(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,389 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change in unified diffs
+const diffContextLines = 3
+
+// diffTools returns the live state diff tool definitions
+func diffTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "diff_config",
+			Description: "Compare the live state of the resources in a manifest with what applying the manifest would produce, like kubectl diff",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"manifest": map[string]interface{}{
+						"type":        "string",
+						"description": "Manifest to compare; multiple documents are separated by ---",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace for namespaced resources that do not set one (defaults to default)",
+					},
+					"output_format": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"unified", "json-patch"},
+						"description": "unified (default) for a YAML diff, json-patch for RFC 6902 operations turning the live object into the applied one",
+					},
+				},
+				"required": []string{"manifest"},
+			},
+		},
+	}
+}
+
+// serverManagedFields are metadata fields set by the API server that never come from a manifest
+var serverManagedFields = []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "generation", "selfLink"}
+
+// patchOperation is one RFC 6902 JSON patch operation
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+func (s *Server) diffConfigTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	manifest, err := stringArg(args, "manifest")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	format := optionalStringArg(args, "output_format", "unified")
+	if format != "unified" && format != "json-patch" {
+		return nil, fmt.Errorf("output_format must be unified or json-patch")
+	}
+
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("manifest contains no resources")
+	}
+
+	ctx := context.Background()
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(s.clientset.Discovery()))
+
+	var diffs []string
+	var patches []map[string]interface{}
+	for _, obj := range objects {
+		live, applied, err := s.liveAndApplied(ctx, mapper, obj, namespace)
+		if err != nil {
+			return nil, err
+		}
+		name := obj.GetKind() + "/" + obj.GetName()
+		if obj.GetNamespace() != "" {
+			name = obj.GetNamespace() + "/" + name
+		}
+
+		if format == "json-patch" {
+			patches = append(patches, map[string]interface{}{
+				"resource": name,
+				"exists":   live != nil,
+				"patch":    jsonPatch(objectMap(live), objectMap(applied)),
+			})
+			continue
+		}
+
+		from, err := objectLines(live)
+		if err != nil {
+			return nil, err
+		}
+		to, err := objectLines(applied)
+		if err != nil {
+			return nil, err
+		}
+		if diff := unifiedDiff("live/"+name, "manifest/"+name, from, to); diff != "" {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	if format == "json-patch" {
+		return jsonResult(patches)
+	}
+	if len(diffs) == 0 {
+		return textResult("No differences between the live state and the manifest"), nil
+	}
+	return textResult(strings.Join(diffs, "")), nil
+}
+
+// liveAndApplied fetches the live object and the result of a dry-run server-side apply of obj, both without server-managed fields.
+// live is nil when the object does not exist yet.
+func (s *Server) liveAndApplied(ctx context.Context, mapper meta.RESTMapper, obj *unstructured.Unstructured, defaultNamespace string) (*unstructured.Unstructured, *unstructured.Unstructured, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to map %s: %w", gvk.String(), err)
+	}
+
+	var client dynamic.ResourceInterface = s.dynamicClient.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(defaultNamespace)
+		}
+		client = s.dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+	}
+
+	live, err := client.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		live = nil
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("failed to get %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	applied, err := client.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        boolPtr(true),
+		DryRun:       []string{metav1.DryRunAll},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dry-run apply %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	stripServerFields(live)
+	stripServerFields(applied)
+	return live, applied, nil
+}
+
+// stripServerFields removes metadata and status the API server maintains
+func stripServerFields(obj *unstructured.Unstructured) {
+	if obj == nil {
+		return
+	}
+	for _, field := range serverManagedFields {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", lastAppliedAnnotation)
+	if annotations, found, _ := unstructured.NestedMap(obj.Object, "metadata", "annotations"); found && len(annotations) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+}
+
+// objectMap returns the content of obj, or nil for a missing object
+func objectMap(obj *unstructured.Unstructured) map[string]interface{} {
+	if obj == nil {
+		return nil
+	}
+	return obj.Object
+}
+
+// objectLines renders obj as YAML lines; a missing object has none
+func objectLines(obj *unstructured.Unstructured) ([]string, error) {
+	if obj == nil {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, nil
+}
+
+// diffEdit is one line of an edit script: ' ' kept, '-' removed, '+' added
+type diffEdit struct {
+	op   byte
+	line string
+}
+
+// unifiedDiff returns a unified diff turning from into to, or "" when they are equal
+func unifiedDiff(fromName, toName string, from, to []string) string {
+	edits := lineEdits(from, to)
+
+	var b strings.Builder
+	fromLine, toLine := 1, 1
+	for start := 0; start < len(edits); {
+		// Find the next change
+		first := start
+		for first < len(edits) && edits[first].op == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+
+		// Extend the hunk while changes are separated by at most twice the context
+		last := first
+		for i := first; i < len(edits); i++ {
+			if edits[i].op != ' ' {
+				last = i
+			} else if i-last > 2*diffContextLines {
+				break
+			}
+		}
+		hunkStart := first - diffContextLines
+		if hunkStart < start {
+			hunkStart = start
+		}
+		hunkEnd := last + diffContextLines + 1
+		if hunkEnd > len(edits) {
+			hunkEnd = len(edits)
+		}
+
+		// Advance line numbers to the start of the hunk
+		for _, e := range edits[start:hunkStart] {
+			fromLine, toLine = advance(e.op, fromLine, toLine)
+		}
+		fromCount, toCount := 0, 0
+		for _, e := range edits[hunkStart:hunkEnd] {
+			if e.op != '+' {
+				fromCount++
+			}
+			if e.op != '-' {
+				toCount++
+			}
+		}
+
+		if b.Len() == 0 {
+			fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount))
+		for _, e := range edits[hunkStart:hunkEnd] {
+			b.WriteByte(e.op)
+			b.WriteString(e.line)
+			fromLine, toLine = advance(e.op, fromLine, toLine)
+		}
+		start = hunkEnd
+	}
+	return b.String()
+}
+
+// advance moves the from and to line numbers past an edit
+func advance(op byte, fromLine, toLine int) (int, int) {
+	if op != '+' {
+		fromLine++
+	}
+	if op != '-' {
+		toLine++
+	}
+	return fromLine, toLine
+}
+
+// hunkRange formats a unified diff line range
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// lineEdits computes a minimal edit script between two line slices using their longest common subsequence
+func lineEdits(from, to []string) []diffEdit {
+	n, m := len(from), len(to)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var edits []diffEdit
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case from[i] == to[j]:
+			edits = append(edits, diffEdit{' ', from[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, diffEdit{'-', from[i]})
+			i++
+		default:
+			edits = append(edits, diffEdit{'+', to[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		edits = append(edits, diffEdit{'-', from[i]})
+	}
+	for ; j < m; j++ {
+		edits = append(edits, diffEdit{'+', to[j]})
+	}
+	return edits
+}
+
+// jsonPatch returns RFC 6902 operations turning from into to. Objects are compared key by key; differing arrays are replaced whole.
+func jsonPatch(from, to map[string]interface{}) []patchOperation {
+	if from == nil {
+		return []patchOperation{{Op: "add", Path: "", Value: to}}
+	}
+	return diffValues("", from, to, nil)
+}
+
+// diffValues appends the operations turning from into to at path
+func diffValues(path string, from, to interface{}, ops []patchOperation) []patchOperation {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if !fromIsMap || !toIsMap {
+		if !reflect.DeepEqual(from, to) {
+			ops = append(ops, patchOperation{Op: "replace", Path: path, Value: to})
+		}
+		return ops
+	}
+
+	keys := make([]string, 0, len(fromMap)+len(toMap))
+	for key := range fromMap {
+		keys = append(keys, key)
+	}
+	for key := range toMap {
+		if _, ok := fromMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		child := path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+		fromValue, inFrom := fromMap[key]
+		toValue, inTo := toMap[key]
+		switch {
+		case !inTo:
+			ops = append(ops, patchOperation{Op: "remove", Path: child})
+		case !inFrom:
+			ops = append(ops, patchOperation{Op: "add", Path: child, Value: toValue})
+		default:
+			ops = diffValues(child, fromValue, toValue, ops)
+		}
+	}
+	return ops
+}
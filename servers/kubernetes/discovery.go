@@ -0,0 +1,131 @@
+package kubernetes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// discoveryTools returns the cluster discovery tool definitions
+func discoveryTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_cluster_version",
+			Description: "Get the Kubernetes version of the API server",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+		{
+			Name:        "list_api_resources",
+			Description: "List every resource type the cluster serves, including custom resources, with its group and version",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"custom_only": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Only list resources outside the built-in Kubernetes API groups",
+					},
+				},
+			},
+		},
+	}
+}
+
+// builtinGroups are the Kubernetes API groups whose names are not domain-qualified
+var builtinGroups = map[string]bool{
+	"":            true,
+	"apps":        true,
+	"batch":       true,
+	"autoscaling": true,
+	"policy":      true,
+}
+
+// isBuiltinGroup reports whether an API group is part of Kubernetes itself
+func isBuiltinGroup(group string) bool {
+	return builtinGroups[group] || group == "k8s.io" || strings.HasSuffix(group, ".k8s.io")
+}
+
+func (s *Server) getClusterVersionTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	version, err := s.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResult(map[string]interface{}{
+		"gitVersion": version.GitVersion,
+		"platform":   version.Platform,
+		"buildDate":  version.BuildDate,
+	})
+}
+
+// apiResource is one served group-version-resource
+type apiResource struct {
+	Group      string   `json:"group"`
+	Version    string   `json:"version"`
+	Resource   string   `json:"resource"`
+	Kind       string   `json:"kind"`
+	Namespaced bool     `json:"namespaced"`
+	Verbs      []string `json:"verbs"`
+}
+
+func (s *Server) listAPIResourcesTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	customOnly := boolArg(args, "custom_only")
+
+	lists, err := s.clientset.Discovery().ServerPreferredResources()
+	var warnings []string
+	if err != nil {
+		// Unavailable aggregated APIs fail discovery for their group only; report the rest
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, err
+		}
+		warnings = append(warnings, err.Error())
+	}
+
+	var resources []apiResource
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("skipping %s: %v", list.GroupVersion, err))
+			continue
+		}
+		if customOnly && isBuiltinGroup(gv.Group) {
+			continue
+		}
+		for _, resource := range list.APIResources {
+			// Subresources such as pods/log are not standalone types
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+			resources = append(resources, apiResource{
+				Group:      gv.Group,
+				Version:    gv.Version,
+				Resource:   resource.Name,
+				Kind:       resource.Kind,
+				Namespaced: resource.Namespaced,
+				Verbs:      resource.Verbs,
+			})
+		}
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Group != resources[j].Group {
+			return resources[i].Group < resources[j].Group
+		}
+		return resources[i].Resource < resources[j].Resource
+	})
+
+	result := map[string]interface{}{
+		"count":     len(resources),
+		"resources": resources,
+	}
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+	return jsonResult(result)
+}
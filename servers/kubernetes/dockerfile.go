@@ -0,0 +1,521 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// dockerfileContentType is the ToolResultContent type of a generated Dockerfile
+const dockerfileContentType = "text/x-dockerfile"
+
+// manifestMediaTypes are the image manifest and index formats requested from registries
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// buildArgsPrefix matches the "|2 KEY=value ..." build arguments BuildKit records in front of RUN commands
+var buildArgsPrefix = regexp.MustCompile(`^\|\d+ (\S+=\S* )*`)
+
+// legacyExpose matches the "EXPOSE map[80/tcp:{}]" form the classic builder records for EXPOSE
+var legacyExpose = regexp.MustCompile(`^EXPOSE map\[(.*)\]$`)
+
+// dockerfileTools returns the Dockerfile reconstruction tool definitions
+func dockerfileTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "generate_dockerfile",
+			Description: "Reconstruct a plausible Dockerfile skeleton for a deployment's container image from the image's registry manifest, config and layer history",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment (defaults to default)",
+					},
+					"container": map[string]interface{}{
+						"type":        "string",
+						"description": "Container whose image to inspect (defaults to the first container)",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
+	}
+}
+
+// imageManifest is the subset of an OCI or Docker manifest or index used to find the image config
+type imageManifest struct {
+	Config struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+	Layers []struct {
+		Digest string `json:"digest"`
+		Size   int64  `json:"size"`
+	} `json:"layers"`
+	Manifests []struct {
+		Digest   string `json:"digest"`
+		Platform struct {
+			OS           string `json:"os"`
+			Architecture string `json:"architecture"`
+		} `json:"platform"`
+	} `json:"manifests"`
+}
+
+// imageConfig is the subset of an OCI image config used to rebuild a Dockerfile and describe an image
+type imageConfig struct {
+	Created      string `json:"created"`
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Config       struct {
+		User         string              `json:"User"`
+		ExposedPorts map[string]struct{} `json:"ExposedPorts"`
+		Env          []string            `json:"Env"`
+		Entrypoint   []string            `json:"Entrypoint"`
+		Cmd          []string            `json:"Cmd"`
+		Volumes      map[string]struct{} `json:"Volumes"`
+		WorkingDir   string              `json:"WorkingDir"`
+		Labels       map[string]string   `json:"Labels"`
+	} `json:"config"`
+	History []struct {
+		CreatedBy string `json:"created_by"`
+	} `json:"history"`
+}
+
+func (s *Server) generateDockerfileTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	containerName := optionalStringArg(args, "container", "")
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	podSpec := deployment.Spec.Template.Spec
+	if len(podSpec.Containers) == 0 {
+		return nil, fmt.Errorf("deployment %s/%s has no containers", namespace, name)
+	}
+	container := podSpec.Containers[0]
+	if containerName != "" {
+		found := false
+		for _, c := range podSpec.Containers {
+			if c.Name == containerName {
+				container, found = c, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("deployment %s/%s has no container named %s", namespace, name, containerName)
+		}
+	}
+
+	registry, repository, tag, digest := splitImage(container.Image)
+	reference := tag
+	if digest != "" {
+		reference = digest
+	}
+	client := &registryClient{
+		host:       registryHost(registry),
+		repository: repository,
+		http:       &http.Client{Timeout: 30 * time.Second},
+		credential: s.registryCredential(ctx, namespace, podSpec.ImagePullSecrets, registry),
+	}
+
+	manifest, config, err := client.imageConfig(reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect image %s: %w", container.Image, err)
+	}
+
+	return &mcp.ToolResult{
+		Content: []mcp.ToolResultContent{{Type: dockerfileContentType, Text: buildDockerfile(container.Image, manifest, config)}},
+	}, nil
+}
+
+// registryCredential returns the basic auth credential for a registry from the pod's image pull secrets, if any
+func (s *Server) registryCredential(ctx context.Context, namespace string, pullSecrets []corev1.LocalObjectReference, registry string) string {
+	keys := []string{registry, "https://" + registry}
+	if registry == defaultImageRegistry {
+		keys = append(keys, "https://index.docker.io/v1/", "index.docker.io")
+	}
+
+	for _, ref := range pullSecrets {
+		secret, err := s.clientset.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			s.logger.Debugf("Skipping image pull secret %s: %v", ref.Name, err)
+			continue
+		}
+		var dockerConfig struct {
+			Auths map[string]struct {
+				Username string `json:"username"`
+				Password string `json:"password"`
+				Auth     string `json:"auth"`
+			} `json:"auths"`
+		}
+		if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &dockerConfig); err != nil {
+			continue
+		}
+		for _, key := range keys {
+			if auth, ok := dockerConfig.Auths[key]; ok {
+				if auth.Auth != "" {
+					return auth.Auth
+				}
+				return base64.StdEncoding.EncodeToString([]byte(auth.Username + ":" + auth.Password))
+			}
+		}
+	}
+	return ""
+}
+
+// registryHost maps a registry name to the host serving its API
+func registryHost(registry string) string {
+	if registry == defaultImageRegistry {
+		return "registry-1.docker.io"
+	}
+	return registry
+}
+
+// registryClient reads manifests and blobs through the registry HTTP API v2
+type registryClient struct {
+	host       string
+	repository string
+	http       *http.Client
+
+	// credential is a base64 user:password pair, or empty for anonymous access
+	credential string
+	token      string
+}
+
+// imageConfig resolves a tag or digest to a single-platform manifest, preferring linux/amd64, and fetches its config
+func (c *registryClient) imageConfig(reference string) (*imageManifest, *imageConfig, error) {
+	image, err := c.resolveImage(reference)
+	if err != nil {
+		return nil, nil, err
+	}
+	return image.manifest, image.config, nil
+}
+
+// resolvedImage is an image reference resolved through the registry
+type resolvedImage struct {
+	// digest is the digest the reference points at, the index digest for multi-platform images
+	digest    string
+	platforms []string
+	manifest  *imageManifest
+	config    *imageConfig
+}
+
+// resolveImage resolves a tag or digest like imageConfig, also returning its digest and, for an index, its platforms
+func (c *registryClient) resolveImage(reference string) (*resolvedImage, error) {
+	var manifest imageManifest
+	digest, err := c.getJSONDigest("manifests/"+reference, strings.Join(manifestMediaTypes, ", "), &manifest)
+	if err != nil {
+		return nil, err
+	}
+	image := &resolvedImage{digest: digest}
+
+	if len(manifest.Manifests) > 0 {
+		chosen := manifest.Manifests[0].Digest
+		for _, m := range manifest.Manifests {
+			if m.Platform.OS == "linux" && m.Platform.Architecture == "amd64" {
+				chosen = m.Digest
+				break
+			}
+		}
+		for _, m := range manifest.Manifests {
+			// Attestation manifests are listed with an unknown platform
+			if m.Platform.OS != "" && m.Platform.OS != "unknown" {
+				image.platforms = append(image.platforms, m.Platform.OS+"/"+m.Platform.Architecture)
+			}
+		}
+		manifest = imageManifest{}
+		if err := c.getJSON("manifests/"+chosen, strings.Join(manifestMediaTypes, ", "), &manifest); err != nil {
+			return nil, err
+		}
+	}
+	if manifest.Config.Digest == "" {
+		return nil, fmt.Errorf("manifest has no image config")
+	}
+
+	var config imageConfig
+	if err := c.getJSON("blobs/"+manifest.Config.Digest, "", &config); err != nil {
+		return nil, err
+	}
+	image.manifest, image.config = &manifest, &config
+	return image, nil
+}
+
+// getJSON fetches a repository path and decodes the JSON response, answering a bearer token challenge once
+func (c *registryClient) getJSON(path, accept string, out interface{}) error {
+	_, err := c.getJSONDigest(path, accept, out)
+	return err
+}
+
+// getJSONDigest fetches and decodes like getJSON, returning the content digest the registry reports,
+// or the sha256 of the response when it reports none
+func (c *registryClient) getJSONDigest(path, accept string, out interface{}) (string, error) {
+	target := fmt.Sprintf("https://%s/v2/%s/%s", c.host, c.repository, path)
+
+	resp, err := c.get(target, accept)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := c.authenticate(challenge); err != nil {
+			return "", err
+		}
+		if resp, err = c.get(target, accept); err != nil {
+			return "", err
+		}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read registry response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registry returned status %d for %s: %s", resp.StatusCode, path, strings.TrimSpace(string(body[:min(len(body), 512)])))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return "", fmt.Errorf("failed to decode registry response for %s: %w", path, err)
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256(body))
+	}
+	return digest, nil
+}
+
+// get sends an authenticated GET request
+func (c *registryClient) get(target, accept string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	switch {
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	case c.credential != "":
+		req.Header.Set("Authorization", "Basic "+c.credential)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call registry %s: %w", c.host, err)
+	}
+	return resp, nil
+}
+
+// authenticate exchanges a Bearer WWW-Authenticate challenge for a pull token
+func (c *registryClient) authenticate(challenge string) error {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return fmt.Errorf("registry %s requires authentication: %s", c.host, challenge)
+	}
+
+	params := map[string]string{}
+	for _, part := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		if key, value, ok := strings.Cut(strings.TrimSpace(part), "="); ok {
+			params[key] = strings.Trim(value, `"`)
+		}
+	}
+	if params["realm"] == "" {
+		return fmt.Errorf("registry %s sent a token challenge without a realm", c.host)
+	}
+
+	query := url.Values{}
+	if params["service"] != "" {
+		query.Set("service", params["service"])
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", c.repository)
+	}
+	query.Set("scope", scope)
+
+	req, err := http.NewRequest(http.MethodGet, params["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if c.credential != "" {
+		req.Header.Set("Authorization", "Basic "+c.credential)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry token request returned status %d", resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode registry token: %w", err)
+	}
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+	if c.token == "" {
+		return fmt.Errorf("registry token response contained no token")
+	}
+	return nil
+}
+
+// buildDockerfile renders a Dockerfile from the image's layer history, or from its config when there is no history
+func buildDockerfile(image string, manifest *imageManifest, config *imageConfig) string {
+	var size int64
+	for _, layer := range manifest.Layers {
+		size += layer.Size
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Reconstructed from %s (%s/%s, %d layers, %.1f MiB compressed)\n", image, config.OS, config.Architecture, len(manifest.Layers), float64(size)/(1<<20))
+	b.WriteString("# Build context files, build arguments and multi-stage builds cannot be recovered from an image; review before use.\n\n")
+
+	if base := config.Config.Labels["org.opencontainers.image.base.name"]; base != "" {
+		fmt.Fprintf(&b, "FROM %s\n", base)
+	} else {
+		b.WriteString("# The base image is unknown; the first layers below rebuild it from its root filesystem\n")
+		b.WriteString("FROM scratch\n")
+	}
+
+	if len(config.History) == 0 {
+		writeConfigInstructions(&b, config)
+		return b.String()
+	}
+	for _, entry := range config.History {
+		if instruction := historyInstruction(entry.CreatedBy); instruction != "" {
+			b.WriteString(instruction)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// historyInstruction converts an image history created_by entry into a Dockerfile instruction
+func historyInstruction(createdBy string) string {
+	line := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(createdBy), "# buildkit"))
+	if line == "" {
+		return ""
+	}
+
+	switch {
+	case strings.HasPrefix(line, "/bin/sh -c #(nop) "):
+		line = strings.TrimSpace(strings.TrimPrefix(line, "/bin/sh -c #(nop) "))
+	case strings.HasPrefix(line, "/bin/sh -c "):
+		line = "RUN " + strings.TrimPrefix(line, "/bin/sh -c ")
+	case strings.HasPrefix(line, "RUN "):
+		command := buildArgsPrefix.ReplaceAllString(strings.TrimPrefix(line, "RUN "), "")
+		line = "RUN " + strings.TrimPrefix(command, "/bin/sh -c ")
+	}
+
+	if match := legacyExpose.FindStringSubmatch(line); match != nil {
+		return "EXPOSE " + strings.ReplaceAll(match[1], ":{}", "")
+	}
+
+	// Files added from the build context are recorded by content hash, e.g. "COPY file:3a1c... in /app"
+	for _, keyword := range []string{"ADD", "COPY"} {
+		prefix := keyword + " "
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(line, prefix)
+		if source, destination, ok := strings.Cut(rest, " in "); ok && (strings.HasPrefix(source, "file:") || strings.HasPrefix(source, "dir:") || strings.HasPrefix(source, "multi:")) {
+			placeholder := "."
+			if keyword == "ADD" && destination == "/" {
+				placeholder = "rootfs.tar.gz"
+			}
+			return fmt.Sprintf("# source was %s\n%s%s %s", source, prefix, placeholder, strings.TrimSpace(destination))
+		}
+	}
+
+	if first, _, _ := strings.Cut(line, " "); first != strings.ToUpper(first) {
+		return "# " + line
+	}
+	return line
+}
+
+// writeConfigInstructions writes the instructions that reproduce an image config
+func writeConfigInstructions(b *strings.Builder, config *imageConfig) {
+	c := config.Config
+	labels := make([]string, 0, len(c.Labels))
+	for key := range c.Labels {
+		labels = append(labels, key)
+	}
+	sort.Strings(labels)
+	for _, key := range labels {
+		fmt.Fprintf(b, "LABEL %q=%q\n", key, c.Labels[key])
+	}
+	for _, env := range c.Env {
+		if key, value, ok := strings.Cut(env, "="); ok {
+			fmt.Fprintf(b, "ENV %s=%q\n", key, value)
+		}
+	}
+	if c.WorkingDir != "" {
+		fmt.Fprintf(b, "WORKDIR %s\n", c.WorkingDir)
+	}
+	ports := make([]string, 0, len(c.ExposedPorts))
+	for port := range c.ExposedPorts {
+		ports = append(ports, port)
+	}
+	sort.Strings(ports)
+	for _, port := range ports {
+		fmt.Fprintf(b, "EXPOSE %s\n", port)
+	}
+	volumes := make([]string, 0, len(c.Volumes))
+	for volume := range c.Volumes {
+		volumes = append(volumes, volume)
+	}
+	sort.Strings(volumes)
+	for _, volume := range volumes {
+		fmt.Fprintf(b, "VOLUME %s\n", volume)
+	}
+	if c.User != "" {
+		fmt.Fprintf(b, "USER %s\n", c.User)
+	}
+	if len(c.Entrypoint) > 0 {
+		fmt.Fprintf(b, "ENTRYPOINT %s\n", execForm(c.Entrypoint))
+	}
+	if len(c.Cmd) > 0 {
+		fmt.Fprintf(b, "CMD %s\n", execForm(c.Cmd))
+	}
+}
+
+// execForm renders a command as a JSON array, the Dockerfile exec form
+func execForm(args []string) string {
+	data, _ := json.Marshal(args)
+	return string(data)
+}
@@ -0,0 +1,187 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// drainTools returns the node drain tool definitions
+func drainTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "drain_node",
+			Description: "Cordon a node and evict its pods. Refuses to proceed without force when a PodDisruptionBudget would be violated",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the node to drain",
+					},
+					"force": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Proceed even if PodDisruptionBudgets would be violated",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
+	}
+}
+
+// pdbViolation describes a PodDisruptionBudget that draining would violate
+type pdbViolation struct {
+	pdb  policyv1.PodDisruptionBudget
+	pods []string
+}
+
+func (s *Server) drainNodeTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	nodeName, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	force := boolArg(args, "force")
+	ctx := context.Background()
+
+	if _, err := s.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+
+	pods, err := s.drainablePods(ctx, nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	violations, err := s.pdbViolations(ctx, pods)
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) > 0 && !force {
+		return textResult(formatPDBViolations(nodeName, violations)), nil
+	}
+
+	// Pods protected by a violated budget would be refused by the eviction API, so forcing deletes them instead
+	protected := make(map[string]bool)
+	for _, v := range violations {
+		for _, pod := range v.pods {
+			protected[pod] = true
+		}
+	}
+
+	patch := []byte(`{"spec":{"unschedulable":true}}`)
+	if _, err := s.clientset.CoreV1().Nodes().Patch(ctx, nodeName, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to cordon node: %w", err)
+	}
+
+	var evicted, failed []string
+	for _, pod := range pods {
+		id := pod.Namespace + "/" + pod.Name
+		if protected[id] {
+			err = s.clientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+		} else {
+			err = s.clientset.CoreV1().Pods(pod.Namespace).EvictV1(ctx, &policyv1.Eviction{
+				ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+			})
+		}
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		evicted = append(evicted, id)
+	}
+
+	text := fmt.Sprintf("Cordoned node '%s' and evicted %d pod(s)", nodeName, len(evicted))
+	if len(violations) > 0 {
+		text += fmt.Sprintf(" (forced past %d PodDisruptionBudget violation(s))", len(violations))
+	}
+	if len(failed) > 0 {
+		text += fmt.Sprintf("\nFailed to evict %d pod(s):\n%s", len(failed), strings.Join(failed, "\n"))
+	}
+	return textResult(text), nil
+}
+
+// drainablePods lists the pods on a node, skipping DaemonSet-managed and mirror pods
+func (s *Server) drainablePods(ctx context.Context, nodeName string) ([]corev1.Pod, error) {
+	list, err := s.clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range list.Items {
+		if _, mirror := pod.Annotations[corev1.MirrorPodAnnotationKey]; mirror {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if ref := metav1.GetControllerOf(&pod); ref != nil && ref.Kind == "DaemonSet" {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// pdbViolations finds budgets whose allowed disruptions are fewer than the covered pods being drained
+func (s *Server) pdbViolations(ctx context.Context, pods []corev1.Pod) ([]pdbViolation, error) {
+	pdbs, err := s.clientset.PolicyV1().PodDisruptionBudgets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PodDisruptionBudgets: %w", err)
+	}
+
+	var violations []pdbViolation
+	for _, pdb := range pdbs.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+
+		var covered []string
+		for _, pod := range pods {
+			if pod.Namespace == pdb.Namespace && selector.Matches(labels.Set(pod.Labels)) {
+				covered = append(covered, pod.Namespace+"/"+pod.Name)
+			}
+		}
+
+		if len(covered) > int(pdb.Status.DisruptionsAllowed) {
+			violations = append(violations, pdbViolation{pdb: pdb, pods: covered})
+		}
+	}
+	return violations, nil
+}
+
+// formatPDBViolations explains which budgets block the drain
+func formatPDBViolations(nodeName string, violations []pdbViolation) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "WARNING: draining node '%s' would violate %d PodDisruptionBudget(s). Nothing was changed; call drain_node with force: true to proceed.\n", nodeName, len(violations))
+
+	for _, v := range violations {
+		var limit string
+		switch {
+		case v.pdb.Spec.MinAvailable != nil:
+			limit = "minAvailable=" + v.pdb.Spec.MinAvailable.String()
+		case v.pdb.Spec.MaxUnavailable != nil:
+			limit = "maxUnavailable=" + v.pdb.Spec.MaxUnavailable.String()
+		}
+		fmt.Fprintf(&b, "\n%s/%s (%s, healthy %d/%d, disruptions allowed %d) would lose %d pod(s):\n",
+			v.pdb.Namespace, v.pdb.Name, limit,
+			v.pdb.Status.CurrentHealthy, v.pdb.Status.ExpectedPods, v.pdb.Status.DisruptionsAllowed, len(v.pods))
+		for _, pod := range v.pods {
+			fmt.Fprintf(&b, "  - %s\n", pod)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,199 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// endpointTools returns the service endpoint tool definitions
+func endpointTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_service_endpoints",
+			Description: "Show the pod IPs and ports behind a Service, from its Endpoints and EndpointSlices, to check whether the Service routes to any ready pods",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the service",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the service (defaults to default)",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
+	}
+}
+
+// serviceEndpoint is one backend address of a Service
+type serviceEndpoint struct {
+	IP    string         `json:"ip"`
+	Pod   string         `json:"pod,omitempty"`
+	Node  string         `json:"node,omitempty"`
+	Ready bool           `json:"ready"`
+	Ports []endpointPort `json:"ports"`
+}
+
+// endpointPort is a port an endpoint serves
+type endpointPort struct {
+	Name     string `json:"name,omitempty"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol,omitempty"`
+}
+
+// endpointSliceSummary is the data of one EndpointSlice of a Service
+type endpointSliceSummary struct {
+	Name        string          `json:"name"`
+	AddressType string          `json:"address_type"`
+	Endpoints   []sliceEndpoint `json:"endpoints"`
+	Ports       []endpointPort  `json:"ports"`
+}
+
+// sliceEndpoint is one endpoint of an EndpointSlice; it can carry several addresses
+type sliceEndpoint struct {
+	Addresses   []string `json:"addresses"`
+	Pod         string   `json:"pod,omitempty"`
+	Node        string   `json:"node,omitempty"`
+	Zone        string   `json:"zone,omitempty"`
+	Ready       bool     `json:"ready"`
+	Terminating bool     `json:"terminating,omitempty"`
+}
+
+func (s *Server) getServiceEndpointsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	ctx := context.Background()
+
+	service, err := s.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{
+		"service":    name,
+		"namespace":  namespace,
+		"cluster_ip": service.Spec.ClusterIP,
+		"selector":   service.Spec.Selector,
+	}
+
+	ready := 0
+	endpoints := []serviceEndpoint{}
+	object, err := s.clientset.CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		// Services without a selector have Endpoints only if someone created them
+		result["endpoints_error"] = fmt.Sprintf("no Endpoints object named %s", name)
+	case err != nil:
+		return nil, err
+	default:
+		for _, subset := range object.Subsets {
+			ports := make([]endpointPort, 0, len(subset.Ports))
+			for _, port := range subset.Ports {
+				ports = append(ports, endpointPort{Name: port.Name, Port: port.Port, Protocol: string(port.Protocol)})
+			}
+			for _, address := range subset.Addresses {
+				endpoints = append(endpoints, newServiceEndpoint(address, true, ports))
+				ready++
+			}
+			for _, address := range subset.NotReadyAddresses {
+				endpoints = append(endpoints, newServiceEndpoint(address, false, ports))
+			}
+		}
+	}
+	result["endpoints"] = endpoints
+	result["ready_endpoints"] = ready
+
+	sliceReady := 0
+	slices, err := s.clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + name,
+	})
+	if err != nil {
+		result["endpoint_slices_error"] = err.Error()
+	} else {
+		summaries := make([]endpointSliceSummary, 0, len(slices.Items))
+		for _, slice := range slices.Items {
+			summary := summarizeEndpointSlice(slice)
+			for _, endpoint := range summary.Endpoints {
+				if endpoint.Ready {
+					sliceReady++
+				}
+			}
+			summaries = append(summaries, summary)
+		}
+		result["endpoint_slices"] = summaries
+	}
+
+	// Either source may be missing: the Endpoints API is deprecated, and EndpointSlices may not be readable
+	routing := ready > 0 || sliceReady > 0
+	result["routing"] = routing
+	if !routing && service.Spec.Type != corev1.ServiceTypeExternalName {
+		result["hint"] = "The service routes to no ready pods: check that its selector matches running pods and that their readiness probes pass"
+	}
+	return jsonResult(result)
+}
+
+// newServiceEndpoint converts an Endpoints address, naming its pod when it points at one
+func newServiceEndpoint(address corev1.EndpointAddress, ready bool, ports []endpointPort) serviceEndpoint {
+	endpoint := serviceEndpoint{IP: address.IP, Ready: ready, Ports: ports}
+	if address.TargetRef != nil && address.TargetRef.Kind == "Pod" {
+		endpoint.Pod = address.TargetRef.Name
+	}
+	if address.NodeName != nil {
+		endpoint.Node = *address.NodeName
+	}
+	return endpoint
+}
+
+// summarizeEndpointSlice converts an EndpointSlice; endpoints without a ready condition count as ready, as the API specifies
+func summarizeEndpointSlice(slice discoveryv1.EndpointSlice) endpointSliceSummary {
+	summary := endpointSliceSummary{
+		Name:        slice.Name,
+		AddressType: string(slice.AddressType),
+		Endpoints:   make([]sliceEndpoint, 0, len(slice.Endpoints)),
+		Ports:       make([]endpointPort, 0, len(slice.Ports)),
+	}
+	for _, endpoint := range slice.Endpoints {
+		entry := sliceEndpoint{
+			Addresses:   endpoint.Addresses,
+			Ready:       endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready,
+			Terminating: endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating,
+		}
+		if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+			entry.Pod = endpoint.TargetRef.Name
+		}
+		if endpoint.NodeName != nil {
+			entry.Node = *endpoint.NodeName
+		}
+		if endpoint.Zone != nil {
+			entry.Zone = *endpoint.Zone
+		}
+		summary.Endpoints = append(summary.Endpoints, entry)
+	}
+	for _, port := range slice.Ports {
+		entry := endpointPort{}
+		if port.Name != nil {
+			entry.Name = *port.Name
+		}
+		if port.Port != nil {
+			entry.Port = *port.Port
+		}
+		if port.Protocol != nil {
+			entry.Protocol = string(*port.Protocol)
+		}
+		summary.Ports = append(summary.Ports, entry)
+	}
+	return summary
+}
@@ -0,0 +1,84 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+)
+
+// Server-sent event stream settings
+const (
+	eventBufferSize        = 64
+	eventKeepAliveInterval = 30 * time.Second
+)
+
+// handleEvents streams published MCP messages to the client as server-sent events until it disconnects
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	id := strconv.FormatUint(s.eventClientSeq.Add(1), 10)
+	events := make(chan []byte, eventBufferSize)
+	s.eventClients.Store(id, events)
+	defer s.eventClients.Delete(id)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, ": connected %s\n\n", id)
+	flusher.Flush()
+	s.logger.Debugf("Event client %s connected", id)
+
+	keepAlive := time.NewTicker(eventKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case data := <-events:
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case <-r.Context().Done():
+			s.logger.Debugf("Event client %s disconnected", id)
+			return
+		case <-s.eventsDone:
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// PublishEvent sends an MCP message to every connected /mcp/events client.
+// Clients that are not keeping up miss the event rather than blocking the publisher.
+func (s *Server) PublishEvent(msg *mcp.Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	s.eventClients.Range(func(key, value interface{}) bool {
+		select {
+		case value.(chan []byte) <- data:
+		default:
+			s.logger.Warnf("Event client %s is too slow, dropping %s event", key, msg.Type)
+		}
+		return true
+	})
+	return nil
+}
@@ -0,0 +1,159 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// explainLogLines is how many log lines per container explain_error sends to the LLM
+const explainLogLines = 100
+
+// explainErrorPrompt is the instruction sent to the LLM ahead of a failing pod's diagnostic context
+const explainErrorPrompt = "Given this pod's state and logs, explain why it is failing and suggest a fix. Be concise: state the most likely root cause first, then the concrete change or command that fixes it."
+
+// explainTools returns the pod failure diagnosis tool definitions
+func explainTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "explain_error",
+			Description: "Diagnose why a pod is failing from its events, container statuses and recent logs, and suggest a fix (requires the server to be started with --llm-config)",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"pod_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the failing pod",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the pod",
+					},
+				},
+				"required": []string{"pod_name", "namespace"},
+			},
+		},
+	}
+}
+
+func (s *Server) explainErrorTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "pod_name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	if s.llmProvider == nil {
+		return nil, fmt.Errorf("explain_error needs an LLM; start the server with --llm-config")
+	}
+	ctx := context.Background()
+
+	diagnostics, err := s.podDiagnostics(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	diagnosis, err := s.llmProvider.GenerateResponse(ctx, explainErrorPrompt+"\n\n"+diagnostics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diagnosis from LLM: %w", err)
+	}
+
+	return textResult(strings.TrimSpace(diagnosis)), nil
+}
+
+// podDiagnostics collects a pod's phase, conditions, container statuses, events and recent logs as plain text
+func (s *Server) podDiagnostics(ctx context.Context, namespace, name string) (string, error) {
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Pod: %s/%s\nNode: %s\nPhase: %s\n", namespace, name, pod.Spec.NodeName, pod.Status.Phase)
+	if pod.Status.Reason != "" || pod.Status.Message != "" {
+		fmt.Fprintf(&b, "Status: %s %s\n", pod.Status.Reason, pod.Status.Message)
+	}
+
+	b.WriteString("\nConditions:\n")
+	for _, condition := range pod.Status.Conditions {
+		fmt.Fprintf(&b, "- %s=%s %s %s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+	}
+
+	b.WriteString("\nContainers:\n")
+	statuses := append(append([]corev1.ContainerStatus(nil), pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		fmt.Fprintf(&b, "- %s (image %s): ready=%t restarts=%d state=%s\n", status.Name, status.Image, status.Ready, status.RestartCount, containerStateSummary(status.State))
+		if status.LastTerminationState.Terminated != nil {
+			fmt.Fprintf(&b, "  last termination: %s\n", containerStateSummary(status.LastTerminationState))
+		}
+	}
+
+	events, err := s.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{"involvedObject.kind": "Pod", "involvedObject.name": name}.String(),
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(events.Items, func(i, j int) bool {
+		return eventTime(events.Items[i]).Before(eventTime(events.Items[j]))
+	})
+	b.WriteString("\nEvents:\n")
+	for _, event := range events.Items {
+		fmt.Fprintf(&b, "- %s %s (x%d): %s\n", event.Type, event.Reason, max(event.Count, 1), event.Message)
+	}
+
+	for _, status := range statuses {
+		logs := s.containerLogTail(ctx, namespace, name, status)
+		if logs == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\nLast %d log lines of %s:\n%s\n", explainLogLines, status.Name, logs)
+	}
+
+	return b.String(), nil
+}
+
+// containerLogTail returns the container's recent logs, using the previous instance when the current one has none
+func (s *Server) containerLogTail(ctx context.Context, namespace, pod string, status corev1.ContainerStatus) string {
+	tail := int64(explainLogLines)
+	fetch := func(previous bool) string {
+		data, err := s.clientset.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{
+			Container: status.Name,
+			TailLines: &tail,
+			Previous:  previous,
+		}).DoRaw(ctx)
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(data))
+	}
+
+	logs := fetch(false)
+	if logs == "" && status.RestartCount > 0 {
+		logs = fetch(true)
+	}
+	return logs
+}
+
+// containerStateSummary describes a container state in one line
+func containerStateSummary(state corev1.ContainerState) string {
+	switch {
+	case state.Waiting != nil:
+		return fmt.Sprintf("waiting (%s) %s", state.Waiting.Reason, state.Waiting.Message)
+	case state.Terminated != nil:
+		return fmt.Sprintf("terminated (%s, exit code %d) %s", state.Terminated.Reason, state.Terminated.ExitCode, state.Terminated.Message)
+	case state.Running != nil:
+		return "running since " + state.Running.StartedAt.UTC().Format(time.RFC3339)
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,63 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// forceDeleteMinTerminating is how long a pod must have been terminating before it is force deleted without force_anyway
+const forceDeleteMinTerminating = 5 * time.Minute
+
+// forceDeletePod deletes a pod with a grace period of zero, removing it from the API without waiting for the kubelet.
+// A pod that has not been stuck terminating for forceDeleteMinTerminating is only deleted when forceAnyway confirms it,
+// since its containers may still be running and a replacement could run alongside them.
+func (s *Server) forceDeletePod(name, namespace string, forceAnyway bool) (*mcp.ToolResult, error) {
+	ctx := context.Background()
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	terminating := terminatingFor(pod, time.Now())
+	if terminating < forceDeleteMinTerminating && !forceAnyway {
+		state := "is not terminating"
+		if pod.DeletionTimestamp != nil {
+			state = fmt.Sprintf("has only been terminating for %s", terminating.Round(time.Second))
+		}
+		return textResult(fmt.Sprintf("Warning: pod '%s' in namespace '%s' %s. A force delete removes it from the API without waiting "+
+			"for its containers to stop, so they may keep running on the node. Call again with force_anyway to delete it.", name, namespace, state)), nil
+	}
+
+	gracePeriod := int64(0)
+	if err := s.clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod}); err != nil {
+		return nil, err
+	}
+	s.audit("force_delete_pod", logrus.Fields{
+		"pod":          name,
+		"namespace":    namespace,
+		"node":         pod.Spec.NodeName,
+		"terminating":  terminating.Round(time.Second).String(),
+		"force_anyway": forceAnyway,
+	})
+
+	return textResult(fmt.Sprintf("Force deleted pod '%s' from namespace '%s'", name, namespace)), nil
+}
+
+// terminatingFor returns how long a pod has been terminating, or zero if it is not being deleted.
+// The deletion timestamp is when the grace period ends, so the deletion was requested that grace period earlier.
+func terminatingFor(pod *corev1.Pod, now time.Time) time.Duration {
+	if pod.DeletionTimestamp == nil {
+		return 0
+	}
+	requested := pod.DeletionTimestamp.Time
+	if pod.DeletionGracePeriodSeconds != nil {
+		requested = requested.Add(-time.Duration(*pod.DeletionGracePeriodSeconds) * time.Second)
+	}
+	return max(0, now.Sub(requested))
+}
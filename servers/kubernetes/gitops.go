@@ -0,0 +1,179 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GitOps resources read by get_gitops_status
+var (
+	argoApplicationGVR   = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "applications"}
+	fluxGitRepositoryGVR = schema.GroupVersionResource{Group: "source.toolkit.fluxcd.io", Version: "v1", Resource: "gitrepositories"}
+	fluxKustomizationGVR = schema.GroupVersionResource{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Resource: "kustomizations"}
+)
+
+// gitOpsTools returns the GitOps tool definitions
+func gitOpsTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_gitops_status",
+			Description: "Report whether ArgoCD or Flux is installed and whether their applications are in sync with git",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Only report GitOps resources in this namespace (optional)",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *Server) getGitOpsStatusTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	ctx := context.Background()
+	namespace := optionalStringArg(args, "namespace", "")
+	status := map[string]interface{}{}
+
+	argo, err := s.argoCDStatus(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	status["argocd"] = argo
+
+	flux, err := s.fluxStatus(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	status["flux"] = flux
+
+	if argo["installed"] == false && flux["installed"] == false {
+		status["message"] = "Neither ArgoCD nor Flux is installed"
+	}
+	return jsonResult(status)
+}
+
+// argoCDStatus reports the sync and health state of every ArgoCD Application
+func (s *Server) argoCDStatus(ctx context.Context, namespace string) (map[string]interface{}, error) {
+	status := map[string]interface{}{"installed": false}
+
+	servers, err := s.clientset.AppsV1().Deployments("").List(ctx, metav1.ListOptions{
+		FieldSelector: "metadata.name=argocd-server",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(servers.Items) == 0 {
+		return status, nil
+	}
+	status["installed"] = true
+	status["namespace"] = servers.Items[0].Namespace
+
+	list, err := s.dynamicClient.Resource(argoApplicationGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		status["message"] = "argocd-server is running but the Application CRD is not installed"
+		return status, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	applications := make([]map[string]interface{}, 0, len(list.Items))
+	outOfSync := 0
+	for _, app := range list.Items {
+		syncStatus, _, _ := unstructured.NestedString(app.Object, "status", "sync", "status")
+		healthStatus, _, _ := unstructured.NestedString(app.Object, "status", "health", "status")
+		lastSynced, _, _ := unstructured.NestedString(app.Object, "status", "operationState", "finishedAt")
+		if lastSynced == "" {
+			lastSynced, _, _ = unstructured.NestedString(app.Object, "status", "reconciledAt")
+		}
+		if syncStatus != "Synced" {
+			outOfSync++
+		}
+
+		applications = append(applications, map[string]interface{}{
+			"name":          app.GetName(),
+			"namespace":     app.GetNamespace(),
+			"sync_status":   syncStatus,
+			"health_status": healthStatus,
+			"last_synced":   lastSynced,
+		})
+	}
+
+	status["applications"] = applications
+	status["out_of_sync"] = outOfSync
+	return status, nil
+}
+
+// fluxStatus reports the readiness of every Flux GitRepository and Kustomization
+func (s *Server) fluxStatus(ctx context.Context, namespace string) (map[string]interface{}, error) {
+	status := map[string]interface{}{"installed": false}
+
+	_, err := s.clientset.CoreV1().Namespaces().Get(ctx, "flux-system", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return status, nil
+	} else if err != nil {
+		return nil, err
+	}
+	status["installed"] = true
+
+	drifted := 0
+	for key, gvr := range map[string]schema.GroupVersionResource{
+		"git_repositories": fluxGitRepositoryGVR,
+		"kustomizations":   fluxKustomizationGVR,
+	} {
+		list, err := s.dynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if apierrors.IsNotFound(err) {
+			status[key] = []map[string]interface{}{}
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		resources := make([]map[string]interface{}, 0, len(list.Items))
+		for _, item := range list.Items {
+			ready, message := fluxReadyCondition(item)
+			revision, _, _ := unstructured.NestedString(item.Object, "status", "artifact", "revision")
+			if revision == "" {
+				revision, _, _ = unstructured.NestedString(item.Object, "status", "lastAppliedRevision")
+			}
+			suspended, _, _ := unstructured.NestedBool(item.Object, "spec", "suspend")
+			if ready != "True" {
+				drifted++
+			}
+
+			resources = append(resources, map[string]interface{}{
+				"name":      item.GetName(),
+				"namespace": item.GetNamespace(),
+				"ready":     ready,
+				"message":   message,
+				"revision":  revision,
+				"suspended": suspended,
+			})
+		}
+		status[key] = resources
+	}
+
+	status["not_ready"] = drifted
+	return status, nil
+}
+
+// fluxReadyCondition returns the status and message of a Flux object's Ready condition
+func fluxReadyCondition(obj unstructured.Unstructured) (string, string) {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, raw := range conditions {
+		condition, ok := raw.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		status, _ := condition["status"].(string)
+		message, _ := condition["message"].(string)
+		return status, message
+	}
+	return "Unknown", ""
+}
@@ -0,0 +1,291 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// podMetricsGVR is the metrics-server pod metrics resource
+var podMetricsGVR = schema.GroupVersionResource{
+	Group:    "metrics.k8s.io",
+	Version:  "v1beta1",
+	Resource: "pods",
+}
+
+// Health score penalties
+const (
+	failedPodPenalty          = 20
+	pendingPodPenalty         = 10
+	warningEventPenalty       = 5
+	unavailableReplicaPenalty = 10
+	restartingPodPenalty      = 5
+	restartThreshold          = 5
+	recentEventLimit          = 5
+)
+
+// healthTools returns the deployment health tool definitions
+func healthTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_deployment_health",
+			Description: "Get a single health report for a deployment: status, pod states, recent events, resource usage, last rollout time and a 0-100 health score",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment",
+					},
+				},
+				"required": []string{"name", "namespace"},
+			},
+		},
+	}
+}
+
+func (s *Server) getDeploymentHealthTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment selector: %w", err)
+	}
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	score := 100
+	var issues []string
+
+	// Pod states
+	podStates := map[string]int{"running": 0, "pending": 0, "failed": 0, "succeeded": 0, "unknown": 0}
+	var restarting []string
+	for _, pod := range pods.Items {
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			podStates["running"]++
+		case corev1.PodPending:
+			podStates["pending"]++
+		case corev1.PodFailed:
+			podStates["failed"]++
+		case corev1.PodSucceeded:
+			podStates["succeeded"]++
+		default:
+			podStates["unknown"]++
+		}
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.RestartCount > restartThreshold {
+				restarting = append(restarting, fmt.Sprintf("%s/%s (%d restarts)", pod.Name, cs.Name, cs.RestartCount))
+			}
+		}
+	}
+	if n := podStates["failed"]; n > 0 {
+		score -= n * failedPodPenalty
+		issues = append(issues, fmt.Sprintf("%d failed pod(s)", n))
+	}
+	if n := podStates["pending"]; n > 0 {
+		score -= n * pendingPodPenalty
+		issues = append(issues, fmt.Sprintf("%d pending pod(s)", n))
+	}
+	if len(restarting) > 0 {
+		score -= len(restarting) * restartingPodPenalty
+		issues = append(issues, fmt.Sprintf("%d container(s) restarting frequently", len(restarting)))
+	}
+
+	// Replica availability
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	if missing := desired - deployment.Status.AvailableReplicas; missing > 0 {
+		score -= int(missing) * unavailableReplicaPenalty
+		issues = append(issues, fmt.Sprintf("%d of %d replica(s) unavailable", missing, desired))
+	}
+
+	// Recent events for the deployment and its pods
+	events, err := s.recentEvents(ctx, namespace, name, pods.Items)
+	if err != nil {
+		return nil, err
+	}
+	warnings := 0
+	var eventSummaries []map[string]interface{}
+	for _, event := range events {
+		if event.Type == corev1.EventTypeWarning {
+			warnings++
+		}
+		eventSummaries = append(eventSummaries, map[string]interface{}{
+			"type":    event.Type,
+			"reason":  event.Reason,
+			"object":  event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+			"message": event.Message,
+			"time":    eventTime(event).Format(time.RFC3339),
+		})
+	}
+	if warnings > 0 {
+		score -= warnings * warningEventPenalty
+		issues = append(issues, fmt.Sprintf("%d recent warning event(s)", warnings))
+	}
+
+	if score < 0 {
+		score = 0
+	}
+
+	report := map[string]interface{}{
+		"name":      name,
+		"namespace": namespace,
+		"deployment": map[string]interface{}{
+			"images":      deploymentImages(deployment.Spec.Template.Spec.Containers),
+			"strategy":    string(deployment.Spec.Strategy.Type),
+			"replicas":    desired,
+			"updated":     deployment.Status.UpdatedReplicas,
+			"ready":       deployment.Status.ReadyReplicas,
+			"available":   deployment.Status.AvailableReplicas,
+			"unavailable": deployment.Status.UnavailableReplicas,
+		},
+		"pods":                  podStates,
+		"restarting_containers": restarting,
+		"recent_events":         eventSummaries,
+		"resource_usage":        s.podResourceUsage(ctx, namespace, selector.String()),
+		"last_rollout_time":     s.lastRolloutTime(ctx, namespace, deployment.UID, selector.String()),
+		"health_score":          score,
+		"issues":                issues,
+	}
+
+	return jsonResult(report)
+}
+
+// recentEvents returns the most recent events for a deployment and its pods
+func (s *Server) recentEvents(ctx context.Context, namespace, deploymentName string, pods []corev1.Pod) ([]corev1.Event, error) {
+	names := map[string]bool{deploymentName: true}
+	for _, pod := range pods {
+		names[pod.Name] = true
+	}
+
+	list, err := s.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []corev1.Event
+	for _, event := range list.Items {
+		if names[event.InvolvedObject.Name] {
+			events = append(events, event)
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return eventTime(events[i]).After(eventTime(events[j]))
+	})
+	if len(events) > recentEventLimit {
+		events = events[:recentEventLimit]
+	}
+
+	return events, nil
+}
+
+// eventTime returns the most relevant timestamp of an event
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// podResourceUsage sums CPU and memory usage reported by metrics-server, if available
+func (s *Server) podResourceUsage(ctx context.Context, namespace, selector string) map[string]interface{} {
+	cpu, memory, pods, err := s.podUsageTotals(ctx, namespace, selector)
+	if err != nil {
+		return map[string]interface{}{"available": false, "reason": "metrics-server not available"}
+	}
+
+	return map[string]interface{}{
+		"available": true,
+		"pods":      pods,
+		"cpu":       cpu.String(),
+		"memory":    memory.String(),
+	}
+}
+
+// podUsageTotals sums the CPU and memory metrics-server reports for pods matching selector
+func (s *Server) podUsageTotals(ctx context.Context, namespace, selector string) (*resource.Quantity, *resource.Quantity, int, error) {
+	list, err := s.dynamicClient.Resource(podMetricsGVR).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	cpu := resource.NewQuantity(0, resource.DecimalSI)
+	memory := resource.NewQuantity(0, resource.BinarySI)
+	for _, item := range list.Items {
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		for _, c := range containers {
+			usage, _, _ := unstructured.NestedStringMap(c.(map[string]interface{}), "usage")
+			if q, err := resource.ParseQuantity(usage["cpu"]); err == nil {
+				cpu.Add(q)
+			}
+			if q, err := resource.ParseQuantity(usage["memory"]); err == nil {
+				memory.Add(q)
+			}
+		}
+	}
+	return cpu, memory, len(list.Items), nil
+}
+
+// lastRolloutTime returns the creation time of the deployment's newest ReplicaSet
+func (s *Server) lastRolloutTime(ctx context.Context, namespace string, uid types.UID, selector string) string {
+	list, err := s.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return ""
+	}
+
+	var latest time.Time
+	for _, rs := range list.Items {
+		for _, ref := range rs.OwnerReferences {
+			if ref.UID == uid && rs.CreationTimestamp.Time.After(latest) {
+				latest = rs.CreationTimestamp.Time
+			}
+		}
+	}
+	if latest.IsZero() {
+		return ""
+	}
+	return latest.Format(time.RFC3339)
+}
+
+// deploymentImages lists the container images of a pod template
+func deploymentImages(containers []corev1.Container) []string {
+	images := make([]string, 0, len(containers))
+	for _, c := range containers {
+		images = append(images, c.Image)
+	}
+	return images
+}
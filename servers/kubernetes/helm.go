@@ -0,0 +1,447 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"gopkg.in/yaml.v3"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// defaultImageRegistry is the registry of images referenced without one
+const defaultImageRegistry = "docker.io"
+
+// helmTools returns the Helm values generation tool definitions
+func helmTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "generate_helm_values",
+			Description: "Generate a values.yaml skeleton following bitnami/common chart conventions from an existing deployment, including its image, replicas, resources, probes, ConfigMaps, Secret key names and Service",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment (defaults to default)",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
+	}
+}
+
+// helmValues builds a YAML mapping that keeps keys in insertion order, as values.yaml files are read top to bottom
+type helmValues struct {
+	node yaml.Node
+}
+
+func newHelmValues() *helmValues {
+	return &helmValues{node: yaml.Node{Kind: yaml.MappingNode}}
+}
+
+// set appends key with value, which may be another *helmValues
+func (v *helmValues) set(key string, value interface{}) error {
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: key}
+	if nested, ok := value.(*helmValues); ok {
+		v.node.Content = append(v.node.Content, keyNode, &nested.node)
+		return nil
+	}
+
+	valueNode := &yaml.Node{}
+	if err := valueNode.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	v.node.Content = append(v.node.Content, keyNode, valueNode)
+	return nil
+}
+
+// setAll appends the keys and values of pairs in order, stopping at the first error
+func (v *helmValues) setAll(pairs ...interface{}) error {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if err := v.set(pairs[i].(string), pairs[i+1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// genericValue converts a Kubernetes API struct to plain maps and slices using its JSON field names
+func genericValue(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil
+	}
+	return out
+}
+
+// splitImage splits an image reference into registry, repository, tag and digest
+func splitImage(image string) (registry, repository, tag, digest string) {
+	if at := strings.Index(image, "@"); at >= 0 {
+		image, digest = image[:at], image[at+1:]
+	}
+	if colon := strings.LastIndex(image, ":"); colon > strings.LastIndex(image, "/") {
+		image, tag = image[:colon], image[colon+1:]
+	}
+
+	registry = defaultImageRegistry
+	if slash := strings.Index(image, "/"); slash >= 0 {
+		first := image[:slash]
+		if strings.ContainsAny(first, ".:") || first == "localhost" {
+			registry, image = first, image[slash+1:]
+		}
+	}
+	if registry == defaultImageRegistry && !strings.Contains(image, "/") {
+		image = "library/" + image
+	}
+	if tag == "" && digest == "" {
+		tag = "latest"
+	}
+	return registry, image, tag, digest
+}
+
+// probeValues returns the bitnami-style enabled flag and timings of a probe
+func probeValues(probe *corev1.Probe) *helmValues {
+	values := newHelmValues()
+	if probe == nil {
+		values.set("enabled", false)
+		return values
+	}
+	values.setAll(
+		"enabled", true,
+		"initialDelaySeconds", probe.InitialDelaySeconds,
+		"periodSeconds", probe.PeriodSeconds,
+		"timeoutSeconds", probe.TimeoutSeconds,
+		"failureThreshold", probe.FailureThreshold,
+		"successThreshold", probe.SuccessThreshold,
+	)
+	return values
+}
+
+// customProbe returns the probe handler for the chart's custom*Probe value, or an empty map when there is no probe
+func customProbe(probe *corev1.Probe) interface{} {
+	if probe == nil {
+		return map[string]interface{}{}
+	}
+	return genericValue(probe.ProbeHandler)
+}
+
+// securityContextValues returns a bitnami-style security context with an enabled flag
+func securityContextValues(securityContext interface{}, present bool) *helmValues {
+	values := newHelmValues()
+	values.set("enabled", present)
+	if !present {
+		return values
+	}
+	if fields, ok := genericValue(securityContext).(map[string]interface{}); ok {
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			values.set(key, fields[key])
+		}
+	}
+	return values
+}
+
+func (s *Server) generateHelmValuesTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	podSpec := deployment.Spec.Template.Spec
+	if len(podSpec.Containers) == 0 {
+		return nil, fmt.Errorf("deployment %s has no containers", name)
+	}
+
+	values := newHelmValues()
+	if err := s.addHelmDeploymentValues(values, deployment, podSpec.Containers[0]); err != nil {
+		return nil, err
+	}
+	if err := s.addHelmConfigValues(ctx, values, deployment, podSpec.Containers[0]); err != nil {
+		return nil, err
+	}
+	if err := s.addHelmServiceValues(ctx, values, deployment); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "## Values generated from deployment %s/%s\n", namespace, name)
+	fmt.Fprintf(&buf, "## Follows bitnami/common conventions; review before use, Secret values are not included\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&values.node); err != nil {
+		return nil, fmt.Errorf("failed to render values: %w", err)
+	}
+	encoder.Close()
+
+	return textResult(buf.String()), nil
+}
+
+// addHelmDeploymentValues adds the workload values: image, replicas, container settings and pod scheduling
+func (s *Server) addHelmDeploymentValues(values *helmValues, deployment *appsv1.Deployment, container corev1.Container) error {
+	podSpec := deployment.Spec.Template.Spec
+
+	registry, repository, tag, digest := splitImage(container.Image)
+	pullSecrets := []string{}
+	for _, secret := range podSpec.ImagePullSecrets {
+		pullSecrets = append(pullSecrets, secret.Name)
+	}
+	pullPolicy := string(container.ImagePullPolicy)
+	if pullPolicy == "" {
+		pullPolicy = string(corev1.PullIfNotPresent)
+	}
+	image := newHelmValues()
+	image.setAll(
+		"registry", registry,
+		"repository", repository,
+		"tag", tag,
+		"digest", digest,
+		"pullPolicy", pullPolicy,
+		"pullSecrets", pullSecrets,
+	)
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	ports := newHelmValues()
+	for i, port := range container.Ports {
+		portName := port.Name
+		if portName == "" {
+			portName = fmt.Sprintf("port%d", i+1)
+		}
+		ports.set(portName, port.ContainerPort)
+	}
+
+	resources := newHelmValues()
+	resources.setAll(
+		"limits", genericValueOrEmptyMap(container.Resources.Limits, len(container.Resources.Limits) > 0),
+		"requests", genericValueOrEmptyMap(container.Resources.Requests, len(container.Resources.Requests) > 0),
+	)
+
+	strategy := newHelmValues()
+	strategy.set("type", string(deployment.Spec.Strategy.Type))
+	if deployment.Spec.Strategy.RollingUpdate != nil {
+		strategy.set("rollingUpdate", genericValue(deployment.Spec.Strategy.RollingUpdate))
+	}
+
+	serviceAccount := newHelmValues()
+	serviceAccount.setAll(
+		"create", false,
+		"name", podSpec.ServiceAccountName,
+	)
+
+	sidecars := []interface{}{}
+	for _, container := range podSpec.Containers[1:] {
+		sidecars = append(sidecars, genericValue(container))
+	}
+	initContainers := []interface{}{}
+	for _, container := range podSpec.InitContainers {
+		initContainers = append(initContainers, genericValue(container))
+	}
+
+	return values.setAll(
+		"nameOverride", "",
+		"fullnameOverride", deployment.Name,
+		"commonLabels", nonNilMap(deployment.Labels),
+		"commonAnnotations", map[string]string{},
+		"image", image,
+		"replicaCount", replicas,
+		"command", nonNilSlice(container.Command),
+		"args", nonNilSlice(container.Args),
+		"containerPorts", ports,
+		"resources", resources,
+		"livenessProbe", probeValues(container.LivenessProbe),
+		"readinessProbe", probeValues(container.ReadinessProbe),
+		"startupProbe", probeValues(container.StartupProbe),
+		"customLivenessProbe", customProbe(container.LivenessProbe),
+		"customReadinessProbe", customProbe(container.ReadinessProbe),
+		"customStartupProbe", customProbe(container.StartupProbe),
+		"podLabels", nonNilMap(deployment.Spec.Template.Labels),
+		"podAnnotations", nonNilMap(deployment.Spec.Template.Annotations),
+		"podSecurityContext", securityContextValues(podSpec.SecurityContext, podSpec.SecurityContext != nil),
+		"containerSecurityContext", securityContextValues(container.SecurityContext, container.SecurityContext != nil),
+		"updateStrategy", strategy,
+		"serviceAccount", serviceAccount,
+		"nodeSelector", nonNilMap(podSpec.NodeSelector),
+		"tolerations", genericValueOrEmptyList(podSpec.Tolerations, len(podSpec.Tolerations)),
+		"affinity", genericValueOrEmptyMap(podSpec.Affinity, podSpec.Affinity != nil),
+		"priorityClassName", podSpec.PriorityClassName,
+		"sidecars", sidecars,
+		"initContainers", initContainers,
+	)
+}
+
+// addHelmConfigValues adds environment, volumes and the ConfigMaps and Secret key names the deployment references
+func (s *Server) addHelmConfigValues(ctx context.Context, values *helmValues, deployment *appsv1.Deployment, container corev1.Container) error {
+	podSpec := deployment.Spec.Template.Spec
+
+	var envCM, envSecret string
+	for _, source := range container.EnvFrom {
+		if source.ConfigMapRef != nil && envCM == "" {
+			envCM = source.ConfigMapRef.Name
+		}
+		if source.SecretRef != nil && envSecret == "" {
+			envSecret = source.SecretRef.Name
+		}
+	}
+
+	configMaps := map[string]bool{}
+	secrets := map[string]bool{}
+	visitConfigReferences(&podSpec, func(kind, name, _ string) {
+		if kind == "ConfigMap" {
+			configMaps[name] = true
+		} else {
+			secrets[name] = true
+		}
+	})
+
+	configMapKeys := newHelmValues()
+	for _, name := range sortedKeys(configMaps) {
+		keys := []string{}
+		if cm, err := s.clientset.CoreV1().ConfigMaps(deployment.Namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+			for key := range cm.Data {
+				keys = append(keys, key)
+			}
+			for key := range cm.BinaryData {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		configMapKeys.set(name, keys)
+	}
+
+	// Only key names are exported; secret values never leave the cluster
+	secretKeys := newHelmValues()
+	for _, name := range sortedKeys(secrets) {
+		keys := []string{}
+		if secret, err := s.clientset.CoreV1().Secrets(deployment.Namespace).Get(ctx, name, metav1.GetOptions{}); err == nil {
+			for key := range secret.Data {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		secretKeys.set(name, keys)
+	}
+
+	return values.setAll(
+		"extraEnvVars", genericValueOrEmptyList(container.Env, len(container.Env)),
+		"extraEnvVarsCM", envCM,
+		"extraEnvVarsSecret", envSecret,
+		"extraVolumes", genericValueOrEmptyList(podSpec.Volumes, len(podSpec.Volumes)),
+		"extraVolumeMounts", genericValueOrEmptyList(container.VolumeMounts, len(container.VolumeMounts)),
+		"existingConfigmaps", configMapKeys,
+		"existingSecrets", secretKeys,
+	)
+}
+
+// addHelmServiceValues adds the type and ports of the first Service selecting the deployment's pods
+func (s *Server) addHelmServiceValues(ctx context.Context, values *helmValues, deployment *appsv1.Deployment) error {
+	service := newHelmValues()
+
+	list, err := s.clientset.CoreV1().Services(deployment.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	podLabels := labels.Set(deployment.Spec.Template.Labels)
+	for _, svc := range list.Items {
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(podLabels) {
+			continue
+		}
+
+		ports := newHelmValues()
+		nodePorts := newHelmValues()
+		for i, port := range svc.Spec.Ports {
+			portName := port.Name
+			if portName == "" {
+				portName = fmt.Sprintf("port%d", i+1)
+			}
+			ports.set(portName, port.Port)
+			if port.NodePort != 0 {
+				nodePorts.set(portName, port.NodePort)
+			}
+		}
+		service.setAll(
+			"type", string(svc.Spec.Type),
+			"ports", ports,
+			"nodePorts", nodePorts,
+			"annotations", nonNilMap(svc.Annotations),
+		)
+		return values.set("service", service)
+	}
+
+	service.setAll(
+		"type", string(corev1.ServiceTypeClusterIP),
+		"ports", map[string]int{},
+	)
+	return values.set("service", service)
+}
+
+// nonNilMap returns m, or an empty map so YAML renders {} rather than null
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+// nonNilSlice returns s, or an empty slice so YAML renders [] rather than null
+func nonNilSlice(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// genericValueOrEmptyList converts value when it has items, otherwise returns an empty list
+func genericValueOrEmptyList(value interface{}, items int) interface{} {
+	if items == 0 {
+		return []interface{}{}
+	}
+	return genericValue(value)
+}
+
+// genericValueOrEmptyMap converts value when present, otherwise returns an empty map
+func genericValueOrEmptyMap(value interface{}, present bool) interface{} {
+	if !present {
+		return map[string]interface{}{}
+	}
+	return genericValue(value)
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,239 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// imageInfoTools returns the registry image metadata tool definitions
+func imageInfoTools() []mcp.Tool {
+	pullSecretProperties := map[string]interface{}{
+		"namespace": map[string]interface{}{
+			"type":        "string",
+			"description": "Namespace of pull_secret (defaults to default)",
+		},
+		"pull_secret": map[string]interface{}{
+			"type":        "string",
+			"description": "Image pull secret holding credentials for a private registry (optional)",
+		},
+	}
+	withPullSecret := func(properties map[string]interface{}) map[string]interface{} {
+		for key, value := range pullSecretProperties {
+			properties[key] = value
+		}
+		return properties
+	}
+
+	return []mcp.Tool{
+		{
+			Name:        "get_container_image_info",
+			Description: "Read an image's metadata from its registry without pulling layers: digest, build time, compressed size, layer count, platform and labels",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": withPullSecret(map[string]interface{}{
+					"image": map[string]interface{}{
+						"type":        "string",
+						"description": "Image reference, e.g. nginx:1.25 or ghcr.io/org/app@sha256:...",
+					},
+				}),
+				"required": []string{"image"},
+			},
+		},
+		{
+			Name:        "compare_image_versions",
+			Description: "Compare the registry metadata of two images, e.g. two tags of one repository: build times, size, shared and new layers, labels and environment",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": withPullSecret(map[string]interface{}{
+					"image_a": map[string]interface{}{
+						"type":        "string",
+						"description": "Older or baseline image, e.g. nginx:1.24",
+					},
+					"image_b": map[string]interface{}{
+						"type":        "string",
+						"description": "Newer image, e.g. nginx:1.25",
+					},
+				}),
+				"required": []string{"image_a", "image_b"},
+			},
+		},
+	}
+}
+
+// imageInfo is the registry metadata of an image
+type imageInfo struct {
+	Image        string            `json:"image"`
+	Digest       string            `json:"digest"`
+	CreatedAt    string            `json:"created_at,omitempty"`
+	SizeMB       float64           `json:"size_mb"`
+	LayersCount  int               `json:"layers_count"`
+	OS           string            `json:"os"`
+	Architecture string            `json:"architecture"`
+	Platforms    []string          `json:"platforms,omitempty"`
+	Labels       map[string]string `json:"labels"`
+
+	env    []string
+	layers []string
+}
+
+func (s *Server) getContainerImageInfoTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	image, err := stringArg(args, "image")
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := s.fetchImageInfo(context.Background(), image, args)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(info)
+}
+
+func (s *Server) compareImageVersionsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	imageA, err := stringArg(args, "image_a")
+	if err != nil {
+		return nil, err
+	}
+	imageB, err := stringArg(args, "image_b")
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+
+	a, err := s.fetchImageInfo(ctx, imageA, args)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.fetchImageInfo(ctx, imageB, args)
+	if err != nil {
+		return nil, err
+	}
+
+	differences := map[string]interface{}{
+		"same_digest":    a.Digest == b.Digest,
+		"size_change_mb": math.Round((b.SizeMB-a.SizeMB)*10) / 10,
+		"layers_change":  b.LayersCount - a.LayersCount,
+	}
+	if createdA, errA := time.Parse(time.RFC3339Nano, a.CreatedAt); errA == nil {
+		if createdB, errB := time.Parse(time.RFC3339Nano, b.CreatedAt); errB == nil {
+			differences["built_apart"] = createdB.Sub(createdA).Round(time.Minute).String()
+		}
+	}
+	if a.OS != b.OS || a.Architecture != b.Architecture {
+		differences["platform"] = fmt.Sprintf("%s/%s -> %s/%s", a.OS, a.Architecture, b.OS, b.Architecture)
+	}
+
+	// Layers are content addressed, so a shared prefix is the unchanged base both images build on
+	shared := 0
+	for shared < len(a.layers) && shared < len(b.layers) && a.layers[shared] == b.layers[shared] {
+		shared++
+	}
+	differences["shared_base_layers"] = shared
+	differences["new_layers_in_b"] = len(b.layers) - shared
+
+	if labels := diffStringMaps(a.Labels, b.Labels); len(labels) > 0 {
+		differences["labels"] = labels
+	}
+	if env := diffStringMaps(envMap(a.env), envMap(b.env)); len(env) > 0 {
+		differences["env"] = env
+	}
+
+	return jsonResult(map[string]interface{}{
+		"image_a":     a,
+		"image_b":     b,
+		"differences": differences,
+	})
+}
+
+// fetchImageInfo reads an image's metadata from its registry, authenticating with the pull_secret argument if given
+func (s *Server) fetchImageInfo(ctx context.Context, image string, args map[string]interface{}) (*imageInfo, error) {
+	registry, repository, tag, digest := splitImage(image)
+	reference := tag
+	if digest != "" {
+		reference = digest
+	}
+
+	var pullSecrets []corev1.LocalObjectReference
+	if secret := optionalStringArg(args, "pull_secret", ""); secret != "" {
+		pullSecrets = append(pullSecrets, corev1.LocalObjectReference{Name: secret})
+	}
+	client := &registryClient{
+		host:       registryHost(registry),
+		repository: repository,
+		http:       &http.Client{Timeout: 30 * time.Second},
+		credential: s.registryCredential(ctx, optionalStringArg(args, "namespace", "default"), pullSecrets, registry),
+	}
+
+	resolved, err := client.resolveImage(reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect image %s: %w", image, err)
+	}
+
+	var size int64
+	layers := make([]string, 0, len(resolved.manifest.Layers))
+	for _, layer := range resolved.manifest.Layers {
+		size += layer.Size
+		layers = append(layers, layer.Digest)
+	}
+	labels := resolved.config.Config.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	return &imageInfo{
+		Image:        image,
+		Digest:       resolved.digest,
+		CreatedAt:    resolved.config.Created,
+		SizeMB:       math.Round(float64(size)/(1<<20)*10) / 10,
+		LayersCount:  len(layers),
+		OS:           resolved.config.OS,
+		Architecture: resolved.config.Architecture,
+		Platforms:    resolved.platforms,
+		Labels:       labels,
+		env:          resolved.config.Config.Env,
+		layers:       layers,
+	}, nil
+}
+
+// envMap turns KEY=value environment entries into a map
+func envMap(env []string) map[string]string {
+	values := make(map[string]string, len(env))
+	for _, entry := range env {
+		key, value, _ := strings.Cut(entry, "=")
+		values[key] = value
+	}
+	return values
+}
+
+// diffStringMaps describes the keys added, removed or changed from a to b, one line per key in key order
+func diffStringMaps(a, b map[string]string) []string {
+	keys := map[string]bool{}
+	for key := range a {
+		keys[key] = true
+	}
+	for key := range b {
+		keys[key] = true
+	}
+
+	var changes []string
+	for _, key := range sortedKeys(keys) {
+		before, inA := a[key]
+		after, inB := b[key]
+		switch {
+		case !inA:
+			changes = append(changes, fmt.Sprintf("+ %s=%s", key, after))
+		case !inB:
+			changes = append(changes, fmt.Sprintf("- %s=%s", key, before))
+		case before != after:
+			changes = append(changes, fmt.Sprintf("~ %s: %s -> %s", key, before, after))
+		}
+	}
+	return changes
+}
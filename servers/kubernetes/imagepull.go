@@ -0,0 +1,99 @@
+package kubernetes
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// imagePullReasons are the waiting reasons of containers whose image cannot be pulled, with a remediation for each
+var imagePullReasons = map[string]string{
+	"InvalidImageName": "Validate the image reference: the name or tag is malformed (check for typos, uppercase letters or a stray scheme such as https://)",
+	"ErrImagePull":     "Check the image registry credentials: add or fix the pod's imagePullSecrets, and confirm the image and tag exist in the registry",
+	"ImagePullBackOff": "Pulls keep failing and kubelet is backing off: confirm the image and tag exist and the registry credentials in imagePullSecrets are valid",
+}
+
+// imagePullTools returns the image pull diagnostic tool definitions
+func imagePullTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "list_image_pull_errors",
+			Description: "List containers stuck on image pull errors (ImagePullBackOff, ErrImagePull, InvalidImageName) with a suggested fix for each; a first check when pods are not starting",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to check (omit for all namespaces)",
+					},
+				},
+			},
+		},
+	}
+}
+
+// imagePullError is one container that cannot pull its image
+type imagePullError struct {
+	PodName       string `json:"pod_name"`
+	Namespace     string `json:"namespace"`
+	ContainerName string `json:"container_name"`
+	Image         string `json:"image"`
+	Reason        string `json:"reason"`
+	Message       string `json:"message"`
+	Remediation   string `json:"remediation"`
+}
+
+func (s *Server) listImagePullErrorsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "")
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pullErrors := []imagePullError{}
+	for _, pod := range pods.Items {
+		statuses := append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			waiting := status.State.Waiting
+			if waiting == nil {
+				continue
+			}
+			if _, ok := imagePullReasons[waiting.Reason]; !ok {
+				continue
+			}
+			pullErrors = append(pullErrors, imagePullError{
+				PodName:       pod.Name,
+				Namespace:     pod.Namespace,
+				ContainerName: status.Name,
+				Image:         status.Image,
+				Reason:        waiting.Reason,
+				Message:       waiting.Message,
+				Remediation:   imagePullRemediation(waiting.Reason, waiting.Message),
+			})
+		}
+	}
+
+	return jsonResult(map[string]interface{}{
+		"count":  len(pullErrors),
+		"errors": pullErrors,
+	})
+}
+
+// imagePullRemediation refines the remediation for a reason using the registry error in the message
+func imagePullRemediation(reason, message string) string {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "not found") || strings.Contains(lower, "manifest unknown"):
+		return "The image or tag does not exist in the registry: validate the image tag and repository name"
+	case strings.Contains(lower, "unauthorized") || strings.Contains(lower, "denied") || strings.Contains(lower, "authentication required"):
+		return "The registry refused the pull: check the image registry credentials in the pod's imagePullSecrets"
+	case strings.Contains(lower, "timeout") || strings.Contains(lower, "no such host") || strings.Contains(lower, "connection refused"):
+		return "The node cannot reach the registry: check DNS, network policies, proxies and firewall rules for the registry host"
+	}
+	return imagePullReasons[reason]
+}
@@ -0,0 +1,322 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Istio networking resources served under networking.istio.io/v1alpha3
+var (
+	virtualServiceGVR = schema.GroupVersionResource{
+		Group:    "networking.istio.io",
+		Version:  "v1alpha3",
+		Resource: "virtualservices",
+	}
+	destinationRuleGVR = schema.GroupVersionResource{
+		Group:    "networking.istio.io",
+		Version:  "v1alpha3",
+		Resource: "destinationrules",
+	}
+)
+
+// istioTools returns the Istio service mesh tool definitions
+func istioTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "list_virtualservices",
+			Description: "List Istio VirtualServices in a namespace or across all namespaces",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to list VirtualServices from (optional)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "create_virtualservice",
+			Description: "Create an Istio VirtualService routing a host to a destination, optionally splitting traffic between subsets for canary releases",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the VirtualService",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace for the VirtualService",
+					},
+					"host": map[string]interface{}{
+						"type":        "string",
+						"description": "Host the VirtualService applies to",
+					},
+					"destination_host": map[string]interface{}{
+						"type":        "string",
+						"description": "Service host traffic is routed to",
+					},
+					"destination_port": map[string]interface{}{
+						"type":        "integer",
+						"description": "Port on the destination service",
+					},
+					"routes": map[string]interface{}{
+						"type":        "array",
+						"description": "Weighted subsets for canary routing; weights must add up to 100 (optional)",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"subset": map[string]interface{}{
+									"type":        "string",
+									"description": "DestinationRule subset name",
+								},
+								"weight": map[string]interface{}{
+									"type":        "integer",
+									"description": "Percentage of traffic sent to the subset",
+								},
+							},
+						},
+					},
+				},
+				"required": []string{"name", "namespace", "host", "destination_host", "destination_port"},
+			},
+		},
+		{
+			Name:        "list_destinationrules",
+			Description: "List Istio DestinationRules in a namespace or across all namespaces",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to list DestinationRules from (optional)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "get_service_mesh_status",
+			Description: "Check whether Istio is installed and report the istiod control plane status",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{},
+			},
+		},
+	}
+}
+
+func (s *Server) listVirtualServicesTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "")
+
+	list, err := s.dynamicClient.Resource(virtualServiceGVR).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, item := range list.Items {
+		hosts, _, _ := unstructured.NestedStringSlice(item.Object, "spec", "hosts")
+		gateways, _, _ := unstructured.NestedStringSlice(item.Object, "spec", "gateways")
+		line := fmt.Sprintf("%s/%s hosts=[%s]", item.GetNamespace(), item.GetName(), strings.Join(hosts, ", "))
+		if len(gateways) > 0 {
+			line += fmt.Sprintf(" gateways=[%s]", strings.Join(gateways, ", "))
+		}
+		lines = append(lines, line)
+	}
+
+	return textResult(fmt.Sprintf("Found %d VirtualServices:\n%s", len(lines), strings.Join(lines, "\n"))), nil
+}
+
+func (s *Server) createVirtualServiceTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	host, err := stringArg(args, "host")
+	if err != nil {
+		return nil, err
+	}
+	destinationHost, err := stringArg(args, "destination_host")
+	if err != nil {
+		return nil, err
+	}
+	destinationPort, err := intArg(args, "destination_port", 0)
+	if err != nil {
+		return nil, err
+	}
+	if destinationPort <= 0 {
+		return nil, fmt.Errorf("destination_port is required")
+	}
+
+	routes, err := buildVirtualServiceRoutes(args["routes"], destinationHost, int64(destinationPort))
+	if err != nil {
+		return nil, err
+	}
+
+	virtualService := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "networking.istio.io/v1alpha3",
+			"kind":       "VirtualService",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"spec": map[string]interface{}{
+				"hosts": []interface{}{host},
+				"http": []interface{}{
+					map[string]interface{}{
+						"route": routes,
+					},
+				},
+			},
+		},
+	}
+
+	_, err = s.dynamicClient.Resource(virtualServiceGVR).Namespace(namespace).Create(context.Background(), virtualService, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Successfully created VirtualService '%s' in namespace '%s' routing %s to %s:%d with %d route(s)",
+		name, namespace, host, destinationHost, destinationPort, len(routes))), nil
+}
+
+// buildVirtualServiceRoutes converts the optional routes argument into HTTP route destinations
+func buildVirtualServiceRoutes(raw interface{}, destinationHost string, port int64) ([]interface{}, error) {
+	destination := func(subset string) map[string]interface{} {
+		dest := map[string]interface{}{
+			"host": destinationHost,
+			"port": map[string]interface{}{"number": port},
+		}
+		if subset != "" {
+			dest["subset"] = subset
+		}
+		return dest
+	}
+
+	items, ok := raw.([]interface{})
+	if !ok || len(items) == 0 {
+		return []interface{}{
+			map[string]interface{}{"destination": destination("")},
+		}, nil
+	}
+
+	var routes []interface{}
+	total := 0
+	for i, item := range items {
+		route, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("routes[%d] must be an object", i)
+		}
+		subset, err := stringArg(route, "subset")
+		if err != nil {
+			return nil, fmt.Errorf("routes[%d]: %w", i, err)
+		}
+		weight, err := intArg(route, "weight", 0)
+		if err != nil {
+			return nil, fmt.Errorf("routes[%d]: %w", i, err)
+		}
+		total += weight
+		routes = append(routes, map[string]interface{}{
+			"destination": destination(subset),
+			"weight":      int64(weight),
+		})
+	}
+
+	if total != 100 {
+		return nil, fmt.Errorf("route weights must add up to 100, got %d", total)
+	}
+
+	return routes, nil
+}
+
+func (s *Server) listDestinationRulesTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "")
+
+	list, err := s.dynamicClient.Resource(destinationRuleGVR).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, item := range list.Items {
+		host, _, _ := unstructured.NestedString(item.Object, "spec", "host")
+		subsets, _, _ := unstructured.NestedSlice(item.Object, "spec", "subsets")
+
+		var subsetNames []string
+		for _, subset := range subsets {
+			if subsetMap, ok := subset.(map[string]interface{}); ok {
+				if subsetName, ok := subsetMap["name"].(string); ok {
+					subsetNames = append(subsetNames, subsetName)
+				}
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("%s/%s host=%s subsets=[%s]",
+			item.GetNamespace(), item.GetName(), host, strings.Join(subsetNames, ", ")))
+	}
+
+	return textResult(fmt.Sprintf("Found %d DestinationRules:\n%s", len(lines), strings.Join(lines, "\n"))), nil
+}
+
+func (s *Server) getServiceMeshStatusTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	ctx := context.Background()
+	status := map[string]interface{}{
+		"installed": false,
+	}
+
+	_, err := s.clientset.CoreV1().Namespaces().Get(ctx, "istio-system", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		status["message"] = "Istio is not installed: namespace istio-system not found"
+		return jsonResult(status)
+	} else if err != nil {
+		return nil, err
+	}
+
+	istiod, err := s.clientset.AppsV1().Deployments("istio-system").Get(ctx, "istiod", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		status["message"] = "Namespace istio-system exists but the istiod deployment was not found"
+		return jsonResult(status)
+	} else if err != nil {
+		return nil, err
+	}
+
+	version := "unknown"
+	if containers := istiod.Spec.Template.Spec.Containers; len(containers) > 0 {
+		if idx := strings.LastIndex(containers[0].Image, ":"); idx != -1 {
+			version = containers[0].Image[idx+1:]
+		}
+	}
+
+	injected, err := s.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{
+		LabelSelector: "istio-injection=enabled",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var injectedNamespaces []string
+	for _, ns := range injected.Items {
+		injectedNamespaces = append(injectedNamespaces, ns.Name)
+	}
+
+	status["installed"] = true
+	status["version"] = version
+	status["istiod_ready_replicas"] = istiod.Status.ReadyReplicas
+	status["istiod_replicas"] = istiod.Status.Replicas
+	status["healthy"] = istiod.Status.ReadyReplicas > 0
+	status["injection_enabled_namespaces"] = injectedNamespaces
+
+	return jsonResult(status)
+}
@@ -0,0 +1,145 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"github.com/sirupsen/logrus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// sourceDeploymentLabel marks Jobs created from a deployment's pod template with the deployment's name
+const sourceDeploymentLabel = "mcp-servers.io/source-deployment"
+
+// jobFromDeploymentTools returns the one-off Job tool definitions
+func jobFromDeploymentTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "create_job_from_deployment",
+			Description: "Create a one-off Job, e.g. a database migration or data fix, that runs one container of a deployment with the same image, environment, volumes and service account, optionally with another command; probes are removed and the pod is not retried",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"deployment_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment whose pod template to copy",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment and the Job (defaults to default)",
+					},
+					"job_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the Job to create",
+					},
+					"container_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Container to run (optional when the pod has a single container); the other containers are left out so the Job can finish",
+					},
+					"command": map[string]interface{}{
+						"type":        "array",
+						"description": "Command to run instead of the container's own, e.g. [\"./manage.py\", \"migrate\"]",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+				},
+				"required": []string{"deployment_name", "job_name"},
+			},
+		},
+	}
+}
+
+func (s *Server) createJobFromDeploymentTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	deploymentName, err := stringArg(args, "deployment_name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	jobName, err := stringArg(args, "job_name")
+	if err != nil {
+		return nil, err
+	}
+	var command []string
+	if _, ok := args["command"]; ok {
+		if command, err = stringSliceArg(args, "command"); err != nil {
+			return nil, err
+		}
+	}
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	container, err := rolloutContainer(deployment, optionalStringArg(args, "container_name", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	// A sidecar that never exits would keep the Job from completing, so only the chosen container runs
+	podSpec := *deployment.Spec.Template.Spec.DeepCopy()
+	var dropped []string
+	for _, other := range podSpec.Containers {
+		if other.Name != container.Name {
+			dropped = append(dropped, other.Name)
+		}
+	}
+	jobContainer := *container.DeepCopy()
+	jobContainer.LivenessProbe = nil
+	jobContainer.ReadinessProbe = nil
+	jobContainer.StartupProbe = nil
+	if len(command) > 0 {
+		// The image's arguments belong to its own command, not the override
+		jobContainer.Command = command
+		jobContainer.Args = nil
+	}
+	podSpec.Containers = []corev1.Container{jobContainer}
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+
+	// The template's labels are replaced so the deployment's Services never send traffic to the Job's pod
+	jobLabels := map[string]string{sourceDeploymentLabel: deploymentName}
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: namespace,
+			Labels:    jobLabels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      jobLabels,
+					Annotations: deployment.Spec.Template.Annotations,
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+
+	created, err := s.clientset.BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	s.audit("create_job_from_deployment", logrus.Fields{
+		"job":        namespace + "/" + created.Name,
+		"deployment": deploymentName,
+		"container":  container.Name,
+		"command":    strings.Join(command, " "),
+	})
+
+	text := fmt.Sprintf("Successfully created Job '%s' in namespace '%s' running container %s of deployment %s", created.Name, namespace, container.Name, deploymentName)
+	if len(command) > 0 {
+		text += fmt.Sprintf(" with command %q", strings.Join(command, " "))
+	}
+	if len(dropped) > 0 {
+		text += fmt.Sprintf("; left out container(s) %s", strings.Join(dropped, ", "))
+	}
+	text += fmt.Sprintf(". Follow it with kubectl logs -f job/%s -n %s", created.Name, namespace)
+	return textResult(text), nil
+}
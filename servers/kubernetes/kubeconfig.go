@@ -0,0 +1,126 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// defaultTokenExpirySeconds is the service account token lifetime used when none is given
+const defaultTokenExpirySeconds = 3600
+
+// kubeconfigTools returns the kubeconfig tool definitions
+func kubeconfigTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "generate_kubeconfig",
+			Description: "Create a short-lived token for a service account and return a kubeconfig that uses it",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"serviceaccount_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the service account",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the service account (defaults to default)",
+					},
+					"expiry_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Token lifetime in seconds (defaults to 3600; the API server may shorten it)",
+					},
+				},
+				"required": []string{"serviceaccount_name"},
+			},
+		},
+	}
+}
+
+func (s *Server) generateKubeconfigTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "serviceaccount_name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	expiry, err := intArg(args, "expiry_seconds", defaultTokenExpirySeconds)
+	if err != nil {
+		return nil, err
+	}
+	if expiry < 600 {
+		return nil, fmt.Errorf("expiry_seconds must be at least 600")
+	}
+	ctx := context.Background()
+
+	expirySeconds := int64(expiry)
+	tokenRequest, err := s.clientset.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, name, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{ExpirationSeconds: &expirySeconds},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	server, caData, err := s.clusterInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contextName := fmt.Sprintf("%s@%s", name, namespace)
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters["cluster"] = &clientcmdapi.Cluster{
+		Server:                   server,
+		CertificateAuthorityData: caData,
+	}
+	kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: tokenRequest.Status.Token}
+	kubeconfig.Contexts[contextName] = &clientcmdapi.Context{
+		Cluster:   "cluster",
+		AuthInfo:  name,
+		Namespace: namespace,
+	}
+	kubeconfig.CurrentContext = contextName
+
+	data, err := clientcmd.Write(*kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize kubeconfig: %w", err)
+	}
+
+	return &mcp.ToolResult{
+		Content: []mcp.ToolResultContent{
+			{Type: "text/x-yaml", Text: string(data)},
+			{Type: "text", Text: fmt.Sprintf("Token for service account '%s' in namespace '%s' expires at %s", name, namespace, tokenRequest.Status.ExpirationTimestamp.UTC().Format("2006-01-02 15:04:05 MST"))},
+		},
+	}, nil
+}
+
+// clusterInfo returns the API server URL and CA bundle published in kube-public/cluster-info,
+// falling back to the server's own connection settings
+func (s *Server) clusterInfo(ctx context.Context) (string, []byte, error) {
+	server, caData := s.config.Host, s.config.CAData
+	if len(caData) == 0 && s.config.CAFile != "" {
+		caData, _ = os.ReadFile(s.config.CAFile)
+	}
+
+	configMap, err := s.clientset.CoreV1().ConfigMaps("kube-public").Get(ctx, "cluster-info", metav1.GetOptions{})
+	if err == nil {
+		if published, err := clientcmd.Load([]byte(configMap.Data["kubeconfig"])); err == nil {
+			for _, cluster := range published.Clusters {
+				server, caData = cluster.Server, cluster.CertificateAuthorityData
+				break
+			}
+		}
+	}
+
+	if server == "" {
+		return "", nil, fmt.Errorf("could not determine the API server address")
+	}
+	if len(caData) == 0 {
+		s.logger.Warn("No cluster CA found in kube-public/cluster-info; the kubeconfig will rely on system trust")
+	}
+	return server, caData, nil
+}
@@ -0,0 +1,200 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+)
+
+// kubectlTimeout bounds how long a passthrough kubectl invocation may run
+const kubectlTimeout = 60 * time.Second
+
+// allowedKubectlSubcommands are the kubectl subcommands exec_kubectl may run
+var allowedKubectlSubcommands = map[string]bool{
+	"get":      true,
+	"describe": true,
+	"logs":     true,
+	"top":      true,
+	"rollout":  true,
+}
+
+// deniedKubectlFlags are long flags exec_kubectl refuses: they would point kubectl at another server or identity,
+// sending the server's credentials along, or make it read or write files on the server host
+var deniedKubectlFlags = map[string]bool{
+	"server":                   true,
+	"token":                    true,
+	"kubeconfig":               true,
+	"context":                  true,
+	"cluster":                  true,
+	"user":                     true,
+	"username":                 true,
+	"password":                 true,
+	"insecure-skip-tls-verify": true,
+	"certificate-authority":    true,
+	"tls-server-name":          true,
+	"filename":                 true,
+	"kustomize":                true,
+	"profile":                  true,
+	"profile-output":           true,
+	"log-file":                 true,
+	"log-dir":                  true,
+	"cache-dir":                true,
+}
+
+// deniedKubectlShorthands are the shorthands of denied flags: -s for --server, -f for --filename, -k for --kustomize
+const deniedKubectlShorthands = "sfk"
+
+// valueKubectlShorthands are shorthands that take a value, so the rest of a -xvalue argument is that value
+const valueKubectlShorthands = "nolcL"
+
+// kubectlTools returns the kubectl passthrough tool definitions
+func kubectlTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "exec_kubectl",
+			Description: "Run kubectl for operations not covered by other tools. Only get, describe, logs, top and rollout are permitted, without flags that change the server, credentials or identity (such as --server, --token, --kubeconfig, --context, --as), read files (-f, -k, -o *-file) or follow logs",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"args": map[string]interface{}{
+						"type":        "array",
+						"description": "Arguments passed to kubectl, starting with the subcommand",
+						"items": map[string]interface{}{
+							"type": "string",
+						},
+					},
+				},
+				"required": []string{"args"},
+			},
+		},
+	}
+}
+
+func (s *Server) execKubectlTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	rawArgs, ok := args["args"].([]interface{})
+	if !ok || len(rawArgs) == 0 {
+		return nil, fmt.Errorf("args must be a non-empty array of strings")
+	}
+
+	kubectlArgs := make([]string, 0, len(rawArgs))
+	for i, raw := range rawArgs {
+		arg, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("args[%d] must be a string", i)
+		}
+		kubectlArgs = append(kubectlArgs, arg)
+	}
+
+	if !allowedKubectlSubcommands[kubectlArgs[0]] {
+		return nil, fmt.Errorf("kubectl subcommand %q is not allowed", kubectlArgs[0])
+	}
+	if err := checkKubectlFlags(kubectlArgs[1:]); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), kubectlTimeout)
+	defer cancel()
+
+	stdout, stderr, err := s.runKubectl(ctx, kubectlArgs...)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("kubectl exited with code %d: %s", exitErr.ExitCode(), strings.TrimSpace(stderr))
+		}
+		return nil, fmt.Errorf("failed to run kubectl: %w", err)
+	}
+
+	return &mcp.ToolResult{
+		Content: []mcp.ToolResultContent{
+			{
+				Type: "text",
+				Text: stdout,
+			},
+			{
+				Type: "text",
+				Text: stderr,
+			},
+		},
+	}, nil
+}
+
+// checkKubectlFlags rejects the flags in deniedKubectlFlags, in any spelling, and output formats that read a file
+func checkKubectlFlags(args []string) error {
+	outputNext := false
+	for _, arg := range args {
+		if outputNext {
+			outputNext = false
+			if err := checkKubectlOutput(arg); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(arg, "--") && arg != "--":
+			name, value, hasValue := strings.Cut(arg[2:], "=")
+			if deniedKubectlFlags[name] || strings.HasPrefix(name, "as") || strings.HasPrefix(name, "client-") {
+				return fmt.Errorf("kubectl flag --%s is not allowed", name)
+			}
+			if name == "output" {
+				if !hasValue {
+					outputNext = true
+				} else if err := checkKubectlOutput(value); err != nil {
+					return err
+				}
+			}
+		case strings.HasPrefix(arg, "-") && len(arg) > 1:
+			// Shorthands can be grouped, as in -Ao wide, until one that takes a value
+			for i, shorthand := range arg[1:] {
+				if strings.ContainsRune(deniedKubectlShorthands, shorthand) {
+					return fmt.Errorf("kubectl flag -%c is not allowed", shorthand)
+				}
+				if !strings.ContainsRune(valueKubectlShorthands, shorthand) {
+					continue
+				}
+				value := strings.TrimPrefix(arg[i+2:], "=")
+				if shorthand == 'o' {
+					if value == "" {
+						outputNext = true
+					} else if err := checkKubectlOutput(value); err != nil {
+						return err
+					}
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// checkKubectlOutput rejects output formats that read a template from a file, such as go-template-file
+func checkKubectlOutput(output string) error {
+	format, _, _ := strings.Cut(output, "=")
+	if strings.HasSuffix(format, "-file") {
+		return fmt.Errorf("kubectl output format %s is not allowed", format)
+	}
+	return nil
+}
+
+// runKubectl runs kubectl against the server's kubeconfig and returns its output
+func (s *Server) runKubectl(ctx context.Context, kubectlArgs ...string) (string, string, error) {
+	if s.kubeconfig != "" {
+		// Global flags go first so they never end up after a -- meant for a pod command
+		kubectlArgs = append([]string{"--kubeconfig", s.kubeconfig}, kubectlArgs...)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.kubectlPath, kubectlArgs...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	s.logger.Infof("Running %s %s", s.kubectlPath, strings.Join(kubectlArgs, " "))
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
@@ -0,0 +1,1096 @@
+package kubernetes
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// kustomizationFileNames are the file names a kustomization directory may use, in the order kustomize looks for them
+var kustomizationFileNames = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// kustomizeTools returns the Kustomize tool definitions
+func kustomizeTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "apply_kustomization",
+			Description: "Build a Kustomize overlay or base and server-side apply the result, like kubectl apply -k. Supports resources, bases, namespace, namePrefix, nameSuffix, commonLabels, commonAnnotations, images, replicas, patchesStrategicMerge, patches and configMap/secret generators. Remote (URL or git) resources and bases are not fetched",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"kustomize_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Directory on the server containing kustomization.yaml",
+					},
+					"kustomization_yaml": map[string]interface{}{
+						"type":        "string",
+						"description": "Inline kustomization.yaml, used instead of kustomize_dir",
+					},
+					"resources": map[string]interface{}{
+						"type":                 "object",
+						"additionalProperties": map[string]interface{}{"type": "string"},
+						"description":          "Files the inline kustomization refers to, by relative path (e.g. {\"deployment.yaml\": \"...\", \"base/kustomization.yaml\": \"...\"})",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace for namespaced resources the kustomization does not place (defaults to default)",
+					},
+				},
+			},
+		},
+	}
+}
+
+// kustomization is the part of kustomization.yaml the in-process build supports; other fields are rejected
+type kustomization struct {
+	APIVersion            string                  `json:"apiVersion,omitempty"`
+	Kind                  string                  `json:"kind,omitempty"`
+	Resources             []string                `json:"resources,omitempty"`
+	Bases                 []string                `json:"bases,omitempty"`
+	Namespace             string                  `json:"namespace,omitempty"`
+	NamePrefix            string                  `json:"namePrefix,omitempty"`
+	NameSuffix            string                  `json:"nameSuffix,omitempty"`
+	CommonLabels          map[string]string       `json:"commonLabels,omitempty"`
+	CommonAnnotations     map[string]string       `json:"commonAnnotations,omitempty"`
+	Images                []kustomizeImage        `json:"images,omitempty"`
+	Replicas              []kustomizeReplicas     `json:"replicas,omitempty"`
+	PatchesStrategicMerge []string                `json:"patchesStrategicMerge,omitempty"`
+	Patches               []kustomizePatch        `json:"patches,omitempty"`
+	ConfigMapGenerator    []kustomizeGenerator    `json:"configMapGenerator,omitempty"`
+	SecretGenerator       []kustomizeGenerator    `json:"secretGenerator,omitempty"`
+	GeneratorOptions      *kustomizeGeneratorOpts `json:"generatorOptions,omitempty"`
+}
+
+// kustomizeImage overrides the name, tag or digest of matching container images
+type kustomizeImage struct {
+	Name    string `json:"name"`
+	NewName string `json:"newName,omitempty"`
+	NewTag  string `json:"newTag,omitempty"`
+	Digest  string `json:"digest,omitempty"`
+}
+
+// kustomizeReplicas sets the replica count of a workload
+type kustomizeReplicas struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// kustomizePatch is a strategic merge or JSON 6902 patch, from a file or inline, for its target or the resource it names
+type kustomizePatch struct {
+	Path    string           `json:"path,omitempty"`
+	Patch   string           `json:"patch,omitempty"`
+	Target  *kustomizeTarget `json:"target,omitempty"`
+	Options map[string]bool  `json:"options,omitempty"`
+}
+
+// kustomizeTarget selects the resources a patch applies to; name and namespace are regular expressions
+type kustomizeTarget struct {
+	Group         string `json:"group,omitempty"`
+	Version       string `json:"version,omitempty"`
+	Kind          string `json:"kind,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Namespace     string `json:"namespace,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// kustomizeGenerator generates a ConfigMap or Secret from literals, files and env files
+type kustomizeGenerator struct {
+	Name      string                  `json:"name"`
+	Namespace string                  `json:"namespace,omitempty"`
+	Behavior  string                  `json:"behavior,omitempty"`
+	Literals  []string                `json:"literals,omitempty"`
+	Files     []string                `json:"files,omitempty"`
+	Envs      []string                `json:"envs,omitempty"`
+	Env       string                  `json:"env,omitempty"`
+	Type      string                  `json:"type,omitempty"`
+	Options   *kustomizeGeneratorOpts `json:"options,omitempty"`
+}
+
+// kustomizeGeneratorOpts are generatorOptions, or the options of a single generator
+type kustomizeGeneratorOpts struct {
+	DisableNameSuffixHash bool              `json:"disableNameSuffixHash,omitempty"`
+	Labels                map[string]string `json:"labels,omitempty"`
+	Annotations           map[string]string `json:"annotations,omitempty"`
+}
+
+// kustomizeFiles reads the files of a kustomization, from the server's disk or from the inline resources of a request
+type kustomizeFiles interface {
+	readFile(name string) ([]byte, error)
+	isDir(name string) bool
+}
+
+// diskFiles reads kustomizations from the server's file system
+type diskFiles struct{}
+
+func (diskFiles) readFile(name string) ([]byte, error) {
+	return os.ReadFile(filepath.FromSlash(name))
+}
+
+func (diskFiles) isDir(name string) bool {
+	info, err := os.Stat(filepath.FromSlash(name))
+	return err == nil && info.IsDir()
+}
+
+// inlineFiles holds the files of an inline kustomization by cleaned relative path
+type inlineFiles map[string]string
+
+func (f inlineFiles) readFile(name string) ([]byte, error) {
+	content, ok := f[path.Clean(name)]
+	if !ok {
+		return nil, fmt.Errorf("%s is not in resources", name)
+	}
+	return []byte(content), nil
+}
+
+func (f inlineFiles) isDir(name string) bool {
+	dir := path.Clean(name)
+	if dir == "." {
+		return true
+	}
+	for file := range f {
+		if strings.HasPrefix(file, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// kustomizeFailure is a resource apply_kustomization could not apply
+type kustomizeFailure struct {
+	Resource string `json:"resource"`
+	Error    string `json:"error"`
+}
+
+func (s *Server) applyKustomizationTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "default")
+
+	var files kustomizeFiles
+	root := optionalStringArg(args, "kustomize_dir", "")
+	if inline := optionalStringArg(args, "kustomization_yaml", ""); inline != "" {
+		resources := inlineFiles{}
+		if raw, ok := args["resources"].(map[string]interface{}); ok {
+			for name, content := range raw {
+				text, ok := content.(string)
+				if !ok {
+					return nil, fmt.Errorf("resources[%s] must be a string", name)
+				}
+				resources[path.Clean(name)] = text
+			}
+		}
+		resources["kustomization.yaml"] = inline
+		files, root = resources, "."
+	} else if root != "" {
+		files, root = diskFiles{}, filepath.ToSlash(root)
+	} else {
+		return nil, fmt.Errorf("kustomize_dir or kustomization_yaml is required")
+	}
+
+	ctx := context.Background()
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(s.clientset.Discovery()))
+	builder := &kustomizeBuilder{
+		files:    files,
+		visiting: map[string]bool{},
+		hashed:   map[*unstructured.Unstructured]bool{},
+		namespaced: func(gvk schema.GroupVersionKind) bool {
+			mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+			// Kinds the cluster does not know yet, such as custom resources whose CRD is in the build, are assumed namespaced
+			return err != nil || mapping.Scope.Name() == meta.RESTScopeNameNamespace
+		},
+	}
+	objects, err := builder.build(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kustomization: %w", err)
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("kustomization produced no resources")
+	}
+	sortForRestore(objects)
+
+	applied := []string{}
+	failed := []kustomizeFailure{}
+	for _, obj := range objects {
+		line, err := s.applyObject(ctx, mapper, obj, namespace)
+		if err != nil {
+			failed = append(failed, kustomizeFailure{Resource: obj.GetKind() + "/" + obj.GetName(), Error: err.Error()})
+			continue
+		}
+		applied = append(applied, line)
+	}
+
+	return jsonResult(map[string]interface{}{
+		"applied":   len(applied),
+		"resources": applied,
+		"failed":    failed,
+	})
+}
+
+// kustomizeBuilder builds kustomizations in-process, following kustomize's order: resources, generators, patches,
+// then the image, replica, namespace, name, label and annotation transformers, and finally name reference fixes
+type kustomizeBuilder struct {
+	files      kustomizeFiles
+	namespaced func(schema.GroupVersionKind) bool
+
+	// visiting holds the directories being built, to report cycles between kustomizations
+	visiting map[string]bool
+	// hashed holds the generated objects whose names take a content hash suffix once the whole build is done
+	hashed map[*unstructured.Unstructured]bool
+}
+
+// build builds the kustomization in root and then, as kustomize does, suffixes generated names with a hash of their
+// final content, so the outer overlays' prefixes and suffixes come before the hash
+func (b *kustomizeBuilder) build(root string) ([]*unstructured.Unstructured, error) {
+	objects, err := b.buildDir(root)
+	if err != nil {
+		return nil, err
+	}
+
+	renames := map[string]map[string]string{}
+	for _, obj := range objects {
+		if !b.hashed[obj] {
+			continue
+		}
+		hash, err := contentHash(obj)
+		if err != nil {
+			return nil, err
+		}
+		if renames[obj.GetKind()] == nil {
+			renames[obj.GetKind()] = map[string]string{}
+		}
+		renames[obj.GetKind()][obj.GetName()] = obj.GetName() + "-" + hash
+		obj.SetName(obj.GetName() + "-" + hash)
+	}
+	for _, obj := range objects {
+		fixNameReferences(obj, renames, "")
+	}
+	return objects, nil
+}
+
+// buildDir builds the kustomization in dir, leaving generated names unhashed
+func (b *kustomizeBuilder) buildDir(dir string) ([]*unstructured.Unstructured, error) {
+	if b.visiting[dir] {
+		return nil, fmt.Errorf("%s is included in a cycle of kustomizations", dir)
+	}
+	b.visiting[dir] = true
+	defer delete(b.visiting, dir)
+
+	k, err := b.load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, resource := range append(append([]string{}, k.Resources...), k.Bases...) {
+		if remoteKustomizeResource(resource) {
+			return nil, fmt.Errorf("%s: remote resources and bases are not supported, only files in the kustomization", resource)
+		}
+		resourcePath := path.Join(dir, resource)
+		if b.files.isDir(resourcePath) {
+			built, err := b.buildDir(resourcePath)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, built...)
+			continue
+		}
+		data, err := b.files.readFile(resourcePath)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := decodeManifest(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", resourcePath, err)
+		}
+		objects = append(objects, decoded...)
+	}
+
+	// Names before this kustomization's transformers, which its references use
+	original := map[*unstructured.Unstructured]string{}
+	for _, obj := range objects {
+		original[obj] = obj.GetName()
+	}
+
+	for _, generator := range k.ConfigMapGenerator {
+		obj, hash, err := b.generate(dir, "ConfigMap", generator, k.GeneratorOptions)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+		original[obj] = obj.GetName()
+		b.hashed[obj] = hash
+	}
+	for _, generator := range k.SecretGenerator {
+		obj, hash, err := b.generate(dir, "Secret", generator, k.GeneratorOptions)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, obj)
+		original[obj] = obj.GetName()
+		b.hashed[obj] = hash
+	}
+
+	if objects, err = b.applyPatches(dir, k, objects); err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objects {
+		setImages(obj, k.Images)
+		setReplicas(obj, k.Replicas, original[obj])
+
+		if k.Namespace != "" && b.namespaced(obj.GroupVersionKind()) {
+			obj.SetNamespace(k.Namespace)
+		}
+		// Namespaces and CRDs keep their names, as in kustomize
+		if obj.GetKind() != "Namespace" && obj.GetKind() != "CustomResourceDefinition" {
+			obj.SetName(k.NamePrefix + obj.GetName() + k.NameSuffix)
+		}
+
+		setCommonLabels(obj, k.CommonLabels)
+		setCommonAnnotations(obj, k.CommonAnnotations)
+	}
+
+	renames := map[string]map[string]string{}
+	for _, obj := range objects {
+		// Service accounts moved to the kustomization's namespace are recorded too, so bindings follow them
+		moved := obj.GetKind() == "ServiceAccount" && k.Namespace != ""
+		if obj.GetName() == original[obj] && !moved {
+			continue
+		}
+		if renames[obj.GetKind()] == nil {
+			renames[obj.GetKind()] = map[string]string{}
+		}
+		renames[obj.GetKind()][original[obj]] = obj.GetName()
+	}
+	for _, obj := range objects {
+		fixNameReferences(obj, renames, k.Namespace)
+	}
+
+	return objects, nil
+}
+
+// remoteKustomizeResource reports whether a resources or bases entry is a URL or git repository, which kustomize
+// would fetch; the build only reads the kustomization's own files
+func remoteKustomizeResource(resource string) bool {
+	if strings.Contains(resource, "://") || strings.Contains(resource, "?ref=") {
+		return true
+	}
+	for _, prefix := range []string{"git@", "git::", "github.com/", "gitlab.com/", "bitbucket.org/"} {
+		if strings.HasPrefix(resource, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// load reads and strictly decodes the kustomization file in dir
+func (b *kustomizeBuilder) load(dir string) (*kustomization, error) {
+	for _, name := range kustomizationFileNames {
+		file := path.Join(dir, name)
+		data, err := b.files.readFile(file)
+		if err != nil {
+			continue
+		}
+		k := &kustomization{}
+		if err := yaml.UnmarshalStrict(data, k); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if k.Kind != "" && k.Kind != "Kustomization" {
+			return nil, fmt.Errorf("%s: kind %s is not supported, only Kustomization", file, k.Kind)
+		}
+		return k, nil
+	}
+	return nil, fmt.Errorf("no kustomization.yaml found in %s", dir)
+}
+
+// generate builds a ConfigMap or Secret from a generator and reports whether its name takes a content hash suffix
+func (b *kustomizeBuilder) generate(dir, kind string, generator kustomizeGenerator, defaults *kustomizeGeneratorOpts) (*unstructured.Unstructured, bool, error) {
+	if generator.Name == "" {
+		return nil, false, fmt.Errorf("%s generator without a name", kind)
+	}
+	if generator.Behavior != "" && generator.Behavior != "create" {
+		return nil, false, fmt.Errorf("%s generator %s: behavior %s is not supported, only create", kind, generator.Name, generator.Behavior)
+	}
+
+	values := map[string][]byte{}
+	for _, literal := range generator.Literals {
+		key, value, ok := strings.Cut(literal, "=")
+		if !ok || key == "" {
+			return nil, false, fmt.Errorf("%s generator %s: literal %q is not key=value", kind, generator.Name, literal)
+		}
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = []byte(value)
+	}
+	for _, file := range generator.Files {
+		key, source, ok := strings.Cut(file, "=")
+		if !ok {
+			key, source = path.Base(file), file
+		}
+		data, err := b.files.readFile(path.Join(dir, source))
+		if err != nil {
+			return nil, false, err
+		}
+		values[key] = data
+	}
+	envs := generator.Envs
+	if generator.Env != "" {
+		envs = append(envs, generator.Env)
+	}
+	for _, env := range envs {
+		data, err := b.files.readFile(path.Join(dir, env))
+		if err != nil {
+			return nil, false, err
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, false, fmt.Errorf("%s: line %q is not KEY=VALUE", env, line)
+			}
+			values[key] = []byte(value)
+		}
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetAPIVersion("v1")
+	obj.SetKind(kind)
+	obj.SetName(generator.Name)
+	if generator.Namespace != "" {
+		obj.SetNamespace(generator.Namespace)
+	}
+
+	data := map[string]interface{}{}
+	binaryData := map[string]interface{}{}
+	for key, value := range values {
+		switch {
+		case kind == "Secret":
+			data[key] = base64.StdEncoding.EncodeToString(value)
+		case utf8.Valid(value):
+			data[key] = string(value)
+		default:
+			binaryData[key] = base64.StdEncoding.EncodeToString(value)
+		}
+	}
+	if len(data) > 0 {
+		obj.Object["data"] = data
+	}
+	if len(binaryData) > 0 {
+		obj.Object["binaryData"] = binaryData
+	}
+	if kind == "Secret" {
+		secretType := generator.Type
+		if secretType == "" {
+			secretType = "Opaque"
+		}
+		obj.Object["type"] = secretType
+	}
+
+	hash := true
+	for _, options := range []*kustomizeGeneratorOpts{defaults, generator.Options} {
+		if options == nil {
+			continue
+		}
+		if options.DisableNameSuffixHash {
+			hash = false
+		}
+		if len(options.Labels) > 0 {
+			obj.SetLabels(mergeStringMaps(obj.GetLabels(), options.Labels))
+		}
+		if len(options.Annotations) > 0 {
+			obj.SetAnnotations(mergeStringMaps(obj.GetAnnotations(), options.Annotations))
+		}
+	}
+	return obj, hash, nil
+}
+
+// contentHash returns kustomize's name suffix hash of a generated ConfigMap or Secret, so generated names match
+// kubectl apply -k and changed content gets a new name that rolls out the workloads referring to it
+func contentHash(obj *unstructured.Unstructured) (string, error) {
+	// kustomize hashes the JSON of these fields, with data always present and the others only when set
+	fields := map[string]interface{}{
+		"kind": obj.GetKind(),
+		"name": obj.GetName(),
+		"data": map[string]interface{}{},
+	}
+	if data, ok := obj.Object["data"].(map[string]interface{}); ok {
+		fields["data"] = data
+	}
+	switch obj.GetKind() {
+	case "ConfigMap":
+		if binaryData, ok := obj.Object["binaryData"].(map[string]interface{}); ok && len(binaryData) > 0 {
+			fields["binaryData"] = binaryData
+		}
+	case "Secret":
+		fields["type"], _ = obj.Object["type"].(string)
+		if stringData, ok := obj.Object["stringData"].(map[string]interface{}); ok && len(stringData) > 0 {
+			fields["stringData"] = stringData
+		}
+	}
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(encoded)
+
+	// kustomize keeps ten hex digits and swaps those that could spell words for letters that cannot
+	hash := []byte(hex.EncodeToString(sum[:])[:10])
+	for i, c := range hash {
+		switch c {
+		case '0':
+			hash[i] = 'g'
+		case '1':
+			hash[i] = 'h'
+		case '3':
+			hash[i] = 'k'
+		case 'a':
+			hash[i] = 'm'
+		case 'e':
+			hash[i] = 't'
+		}
+	}
+	return string(hash), nil
+}
+
+// applyPatches applies patchesStrategicMerge and patches in order
+func (b *kustomizeBuilder) applyPatches(dir string, k *kustomization, objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	var patches []kustomizePatch
+	for _, entry := range k.PatchesStrategicMerge {
+		// Entries are file paths or inline YAML
+		if strings.Contains(entry, "\n") {
+			patches = append(patches, kustomizePatch{Patch: entry})
+		} else {
+			patches = append(patches, kustomizePatch{Path: entry})
+		}
+	}
+	patches = append(patches, k.Patches...)
+
+	for _, patch := range patches {
+		content := patch.Patch
+		if patch.Path != "" {
+			data, err := b.files.readFile(path.Join(dir, patch.Path))
+			if err != nil {
+				return nil, err
+			}
+			content = string(data)
+		}
+		name := patch.Path
+		if name == "" {
+			name = "inline patch"
+		}
+		if len(patch.Options) > 0 {
+			return nil, fmt.Errorf("%s: patch options are not supported", name)
+		}
+
+		data, err := yaml.YAMLToJSON([]byte(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+			if patch.Target == nil {
+				return nil, fmt.Errorf("%s: a JSON 6902 patch needs a target", name)
+			}
+			var operations []map[string]interface{}
+			if err := json.Unmarshal(data, &operations); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			targets, err := patch.Target.matching(objects)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			for _, obj := range targets {
+				if err := applyJSON6902(obj, operations); err != nil {
+					return nil, fmt.Errorf("%s: %s/%s: %w", name, obj.GetKind(), obj.GetName(), err)
+				}
+			}
+			continue
+		}
+
+		documents, err := decodeManifest(content)
+		if err != nil && patch.Target == nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if patch.Target != nil {
+			// With a target the patch need not name its resource
+			var raw map[string]interface{}
+			if err := json.Unmarshal(data, &raw); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			documents = []*unstructured.Unstructured{{Object: raw}}
+		}
+
+		for _, document := range documents {
+			targets := []*unstructured.Unstructured{}
+			if patch.Target != nil {
+				if targets, err = patch.Target.matching(objects); err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+			} else {
+				for _, obj := range objects {
+					if obj.GetKind() == document.GetKind() && obj.GetName() == document.GetName() &&
+						obj.GroupVersionKind().Group == document.GroupVersionKind().Group &&
+						(document.GetNamespace() == "" || obj.GetNamespace() == document.GetNamespace()) {
+						targets = append(targets, obj)
+					}
+				}
+				if len(targets) == 0 {
+					return nil, fmt.Errorf("%s: no resource matches %s/%s", name, document.GetKind(), document.GetName())
+				}
+			}
+
+			if directive, _ := document.Object["$patch"].(string); directive == "delete" {
+				objects = removeObjects(objects, targets)
+				continue
+			}
+			for _, obj := range targets {
+				if err := applyStrategicMerge(obj, document.Object); err != nil {
+					return nil, fmt.Errorf("%s: %s/%s: %w", name, obj.GetKind(), obj.GetName(), err)
+				}
+			}
+		}
+	}
+	return objects, nil
+}
+
+// matching returns the objects a patch target selects
+func (t *kustomizeTarget) matching(objects []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	var nameRe, namespaceRe *regexp.Regexp
+	var err error
+	if t.Name != "" {
+		if nameRe, err = regexp.Compile("^(?:" + t.Name + ")$"); err != nil {
+			return nil, fmt.Errorf("invalid target name: %w", err)
+		}
+	}
+	if t.Namespace != "" {
+		if namespaceRe, err = regexp.Compile("^(?:" + t.Namespace + ")$"); err != nil {
+			return nil, fmt.Errorf("invalid target namespace: %w", err)
+		}
+	}
+	selector := labels.Everything()
+	if t.LabelSelector != "" {
+		if selector, err = labels.Parse(t.LabelSelector); err != nil {
+			return nil, fmt.Errorf("invalid target labelSelector: %w", err)
+		}
+	}
+
+	var matched []*unstructured.Unstructured
+	for _, obj := range objects {
+		gvk := obj.GroupVersionKind()
+		if (t.Group != "" && gvk.Group != t.Group) || (t.Version != "" && gvk.Version != t.Version) || (t.Kind != "" && gvk.Kind != t.Kind) {
+			continue
+		}
+		if (nameRe != nil && !nameRe.MatchString(obj.GetName())) || (namespaceRe != nil && !namespaceRe.MatchString(obj.GetNamespace())) {
+			continue
+		}
+		if !selector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		matched = append(matched, obj)
+	}
+	return matched, nil
+}
+
+// removeObjects returns objects without the removed ones
+func removeObjects(objects, removed []*unstructured.Unstructured) []*unstructured.Unstructured {
+	drop := map[*unstructured.Unstructured]bool{}
+	for _, obj := range removed {
+		drop[obj] = true
+	}
+	kept := objects[:0]
+	for _, obj := range objects {
+		if !drop[obj] {
+			kept = append(kept, obj)
+		}
+	}
+	return kept
+}
+
+// applyStrategicMerge patches obj with a strategic merge patch for built-in kinds, or a JSON merge patch for other kinds
+func applyStrategicMerge(obj *unstructured.Unstructured, patch map[string]interface{}) error {
+	original, err := json.Marshal(obj.Object)
+	if err != nil {
+		return err
+	}
+	patchData, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+
+	var merged []byte
+	if typed, err := scheme.Scheme.New(obj.GroupVersionKind()); err == nil {
+		if merged, err = strategicpatch.StrategicMergePatch(original, patchData, typed); err != nil {
+			return err
+		}
+	} else {
+		var document interface{}
+		if err := json.Unmarshal(original, &document); err != nil {
+			return err
+		}
+		if merged, err = json.Marshal(mergePatch(document, patch)); err != nil {
+			return err
+		}
+	}
+	return replaceObject(obj, merged)
+}
+
+// mergePatch applies an RFC 7386 JSON merge patch: objects merge, null deletes and anything else replaces
+func mergePatch(target, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	targetMap, ok := target.(map[string]interface{})
+	if !ok {
+		targetMap = map[string]interface{}{}
+	}
+	for key, value := range patchMap {
+		if value == nil {
+			delete(targetMap, key)
+			continue
+		}
+		targetMap[key] = mergePatch(targetMap[key], value)
+	}
+	return targetMap
+}
+
+// applyJSON6902 applies the add, remove and replace operations of a JSON patch to obj
+func applyJSON6902(obj *unstructured.Unstructured, operations []map[string]interface{}) error {
+	var document interface{} = obj.Object
+	for _, operation := range operations {
+		op, _ := operation["op"].(string)
+		pointer, _ := operation["path"].(string)
+		if op != "add" && op != "remove" && op != "replace" {
+			return fmt.Errorf("op %q is not supported, only add, remove and replace", op)
+		}
+		if !strings.HasPrefix(pointer, "/") {
+			return fmt.Errorf("path %q must start with /", pointer)
+		}
+
+		tokens := strings.Split(pointer[1:], "/")
+		for i, token := range tokens {
+			tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		}
+		var err error
+		if document, err = patchAt(document, tokens, op, operation["value"]); err != nil {
+			return fmt.Errorf("%s %s: %w", op, pointer, err)
+		}
+	}
+
+	// Round trip through JSON so values from the patch take the types the rest of the object has
+	data, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return replaceObject(obj, data)
+}
+
+// patchAt applies one JSON patch operation at the pointer tokens below node and returns the updated node
+func patchAt(node interface{}, tokens []string, op string, value interface{}) (interface{}, error) {
+	token, last := tokens[0], len(tokens) == 1
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, exists := n[token]
+		if !last {
+			if !exists {
+				return nil, fmt.Errorf("%s not found", token)
+			}
+			updated, err := patchAt(child, tokens[1:], op, value)
+			n[token] = updated
+			return n, err
+		}
+		if op != "add" && !exists {
+			return nil, fmt.Errorf("%s not found", token)
+		}
+		if op == "remove" {
+			delete(n, token)
+		} else {
+			n[token] = value
+		}
+		return n, nil
+	case []interface{}:
+		if token == "-" && last && op == "add" {
+			return append(n, value), nil
+		}
+		index, err := strconv.Atoi(token)
+		limit := len(n)
+		if op == "add" && last {
+			limit++
+		}
+		if err != nil || index < 0 || index >= limit {
+			return nil, fmt.Errorf("index %s out of range", token)
+		}
+		if !last {
+			updated, err := patchAt(n[index], tokens[1:], op, value)
+			n[index] = updated
+			return n, err
+		}
+		switch op {
+		case "add":
+			n = append(n[:index], append([]interface{}{value}, n[index:]...)...)
+		case "remove":
+			n = append(n[:index], n[index+1:]...)
+		default:
+			n[index] = value
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("%s is not inside an object or list", token)
+}
+
+// replaceObject replaces the content of obj with a JSON document
+func replaceObject(obj *unstructured.Unstructured, data []byte) error {
+	replacement := &unstructured.Unstructured{}
+	if err := replacement.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	obj.Object = replacement.Object
+	return nil
+}
+
+// setImages applies image overrides to the containers of obj
+func setImages(obj *unstructured.Unstructured, images []kustomizeImage) {
+	if len(images) == 0 {
+		return
+	}
+	for _, container := range podContainers(obj) {
+		image, _ := container["image"].(string)
+		name, suffix := image, ""
+		if at := strings.Index(name, "@"); at >= 0 {
+			name, suffix = name[:at], name[at:]
+		} else if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") {
+			name, suffix = name[:colon], name[colon:]
+		}
+
+		for _, override := range images {
+			if override.Name != name {
+				continue
+			}
+			if override.NewName != "" {
+				name = override.NewName
+			}
+			switch {
+			case override.Digest != "":
+				suffix = "@" + override.Digest
+			case override.NewTag != "":
+				suffix = ":" + override.NewTag
+			}
+			container["image"] = name + suffix
+			break
+		}
+	}
+}
+
+// podContainers returns the containers and init containers of a pod or of a workload's pod template
+func podContainers(obj *unstructured.Unstructured) []map[string]interface{} {
+	var containers []map[string]interface{}
+	for _, spec := range podSpecs(obj) {
+		for _, list := range []string{"initContainers", "containers"} {
+			items, _ := spec[list].([]interface{})
+			for _, item := range items {
+				if container, ok := item.(map[string]interface{}); ok {
+					containers = append(containers, container)
+				}
+			}
+		}
+	}
+	return containers
+}
+
+// podSpecs returns the pod specs inside obj, without copying them
+func podSpecs(obj *unstructured.Unstructured) []map[string]interface{} {
+	var specs []map[string]interface{}
+	for _, fields := range podSpecPaths {
+		if spec := nestedMapRef(obj.Object, fields...); spec != nil {
+			if _, ok := spec["containers"]; ok {
+				specs = append(specs, spec)
+			}
+		}
+	}
+	return specs
+}
+
+// nestedMapRef returns the map at fields below m, or nil; unlike unstructured.NestedMap it does not copy
+func nestedMapRef(m map[string]interface{}, fields ...string) map[string]interface{} {
+	for _, field := range fields {
+		next, ok := m[field].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		m = next
+	}
+	return m
+}
+
+// setReplicas applies replica overrides to a workload by its name in the kustomization
+func setReplicas(obj *unstructured.Unstructured, replicas []kustomizeReplicas, name string) {
+	switch obj.GetKind() {
+	case "Deployment", "StatefulSet", "ReplicaSet", "ReplicationController":
+	default:
+		return
+	}
+	for _, override := range replicas {
+		if override.Name == name {
+			unstructured.SetNestedField(obj.Object, override.Count, "spec", "replicas")
+		}
+	}
+}
+
+// setCommonLabels adds labels to obj and, as kustomize does, to the selectors and pod templates that must agree with them
+func setCommonLabels(obj *unstructured.Unstructured, commonLabels map[string]string) {
+	if len(commonLabels) == 0 {
+		return
+	}
+	obj.SetLabels(mergeStringMaps(obj.GetLabels(), commonLabels))
+
+	var paths [][]string
+	switch obj.GetKind() {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet":
+		paths = [][]string{{"spec", "selector", "matchLabels"}, {"spec", "template", "metadata", "labels"}}
+	case "Job":
+		paths = [][]string{{"spec", "template", "metadata", "labels"}}
+	case "CronJob":
+		paths = [][]string{{"spec", "jobTemplate", "metadata", "labels"}, {"spec", "jobTemplate", "spec", "template", "metadata", "labels"}}
+	case "Service", "ReplicationController":
+		paths = [][]string{{"spec", "selector"}}
+	case "PodDisruptionBudget":
+		paths = [][]string{{"spec", "selector", "matchLabels"}}
+	}
+	for _, fields := range paths {
+		existing, _, _ := unstructured.NestedStringMap(obj.Object, fields...)
+		unstructured.SetNestedStringMap(obj.Object, mergeStringMaps(existing, commonLabels), fields...)
+	}
+}
+
+// setCommonAnnotations adds annotations to obj and to its pod template
+func setCommonAnnotations(obj *unstructured.Unstructured, commonAnnotations map[string]string) {
+	if len(commonAnnotations) == 0 {
+		return
+	}
+	obj.SetAnnotations(mergeStringMaps(obj.GetAnnotations(), commonAnnotations))
+
+	for _, fields := range [][]string{{"spec", "template", "metadata", "annotations"}, {"spec", "jobTemplate", "spec", "template", "metadata", "annotations"}} {
+		if nestedMapRef(obj.Object, fields[:len(fields)-2]...) == nil {
+			continue
+		}
+		existing, _, _ := unstructured.NestedStringMap(obj.Object, fields...)
+		unstructured.SetNestedStringMap(obj.Object, mergeStringMaps(existing, commonAnnotations), fields...)
+	}
+}
+
+// mergeStringMaps returns base with the entries of overrides added
+func mergeStringMaps(base, overrides map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(overrides))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
+// fixNameReferences points references in obj at resources this kustomization renamed, by kind and old name
+func fixNameReferences(obj *unstructured.Unstructured, renames map[string]map[string]string, namespace string) {
+	rename := func(m map[string]interface{}, field, kind string) {
+		if m == nil {
+			return
+		}
+		if name, ok := m[field].(string); ok {
+			if renamed, ok := renames[kind][name]; ok {
+				m[field] = renamed
+			}
+		}
+	}
+	each := func(m map[string]interface{}, field string, fn func(map[string]interface{})) {
+		items, _ := m[field].([]interface{})
+		for _, item := range items {
+			if entry, ok := item.(map[string]interface{}); ok {
+				fn(entry)
+			}
+		}
+	}
+
+	for _, spec := range podSpecs(obj) {
+		rename(spec, "serviceAccountName", "ServiceAccount")
+		each(spec, "imagePullSecrets", func(secret map[string]interface{}) { rename(secret, "name", "Secret") })
+		each(spec, "volumes", func(volume map[string]interface{}) {
+			rename(nestedMapRef(volume, "configMap"), "name", "ConfigMap")
+			rename(nestedMapRef(volume, "secret"), "secretName", "Secret")
+			rename(nestedMapRef(volume, "persistentVolumeClaim"), "claimName", "PersistentVolumeClaim")
+			if projected := nestedMapRef(volume, "projected"); projected != nil {
+				each(projected, "sources", func(source map[string]interface{}) {
+					rename(nestedMapRef(source, "configMap"), "name", "ConfigMap")
+					rename(nestedMapRef(source, "secret"), "name", "Secret")
+				})
+			}
+		})
+	}
+	for _, container := range podContainers(obj) {
+		each(container, "envFrom", func(source map[string]interface{}) {
+			rename(nestedMapRef(source, "configMapRef"), "name", "ConfigMap")
+			rename(nestedMapRef(source, "secretRef"), "name", "Secret")
+		})
+		each(container, "env", func(env map[string]interface{}) {
+			rename(nestedMapRef(env, "valueFrom", "configMapKeyRef"), "name", "ConfigMap")
+			rename(nestedMapRef(env, "valueFrom", "secretKeyRef"), "name", "Secret")
+		})
+	}
+
+	switch obj.GetKind() {
+	case "StatefulSet":
+		rename(nestedMapRef(obj.Object, "spec"), "serviceName", "Service")
+	case "Ingress":
+		rename(nestedMapRef(obj.Object, "spec", "defaultBackend", "service"), "name", "Service")
+		if spec := nestedMapRef(obj.Object, "spec"); spec != nil {
+			each(spec, "rules", func(rule map[string]interface{}) {
+				if http := nestedMapRef(rule, "http"); http != nil {
+					each(http, "paths", func(p map[string]interface{}) {
+						rename(nestedMapRef(p, "backend", "service"), "name", "Service")
+					})
+				}
+			})
+			each(spec, "tls", func(tls map[string]interface{}) { rename(tls, "secretName", "Secret") })
+		}
+	case "RoleBinding", "ClusterRoleBinding":
+		if roleRef := nestedMapRef(obj.Object, "roleRef"); roleRef != nil {
+			kind, _ := roleRef["kind"].(string)
+			rename(roleRef, "name", kind)
+		}
+		each(obj.Object, "subjects", func(subject map[string]interface{}) {
+			if subject["kind"] != "ServiceAccount" {
+				return
+			}
+			name, _ := subject["name"].(string)
+			if renamed, ok := renames["ServiceAccount"][name]; ok {
+				subject["name"] = renamed
+				// The service account moved with the kustomization's namespace
+				if namespace != "" {
+					subject["namespace"] = namespace
+				}
+			}
+		})
+	}
+}
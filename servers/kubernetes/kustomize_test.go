@@ -0,0 +1,92 @@
+package kubernetes
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// newTestBuilder returns a builder over inline files that treats every kind as namespaced
+func newTestBuilder(files inlineFiles) *kustomizeBuilder {
+	return &kustomizeBuilder{
+		files:      files,
+		visiting:   map[string]bool{},
+		hashed:     map[*unstructured.Unstructured]bool{},
+		namespaced: func(schema.GroupVersionKind) bool { return true },
+	}
+}
+
+func TestContentHashMatchesKustomize(t *testing.T) {
+	// Expected values are kustomize's own hasher test cases
+	cases := []struct {
+		name string
+		obj  map[string]interface{}
+		want string
+	}{
+		{"empty configmap", map[string]interface{}{"kind": "ConfigMap", "data": map[string]interface{}{}}, "42745tchd9"},
+		{"one key", map[string]interface{}{"kind": "ConfigMap", "data": map[string]interface{}{"one": ""}}, "9g67k2htb6"},
+		{"three keys", map[string]interface{}{"kind": "ConfigMap", "data": map[string]interface{}{"two": "2", "one": "", "three": "3"}}, "f5h7t85m9b"},
+		{"empty secret", map[string]interface{}{"kind": "Secret", "type": "my-type", "data": map[string]interface{}{}}, "t75bgf6ctb"},
+	}
+	for _, c := range cases {
+		got, err := contentHash(&unstructured.Unstructured{Object: c.obj})
+		if err != nil {
+			t.Fatalf("%s: contentHash: %v", c.name, err)
+		}
+		if got != c.want {
+			t.Errorf("%s: got hash %s, want %s", c.name, got, c.want)
+		}
+	}
+}
+
+func TestBuildHashesAfterOuterPrefix(t *testing.T) {
+	builder := newTestBuilder(inlineFiles{
+		"kustomization.yaml": "namePrefix: prod-\nresources:\n- base\n",
+		"base/kustomization.yaml": "resources:\n- deployment.yaml\n" +
+			"configMapGenerator:\n- name: config\n  literals:\n  - mode=fast\n",
+		"base/deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n" +
+			"spec:\n  template:\n    spec:\n      containers:\n      - name: web\n        image: nginx\n" +
+			"        envFrom:\n        - configMapRef:\n            name: config\n",
+	})
+	objects, err := builder.build(".")
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	var configMap, deployment *unstructured.Unstructured
+	for _, obj := range objects {
+		switch obj.GetKind() {
+		case "ConfigMap":
+			configMap = obj
+		case "Deployment":
+			deployment = obj
+		}
+	}
+	if configMap == nil || deployment == nil {
+		t.Fatalf("build produced %d objects, want the ConfigMap and the Deployment", len(objects))
+	}
+	if !strings.HasPrefix(configMap.GetName(), "prod-config-") || len(configMap.GetName()) != len("prod-config-")+10 {
+		t.Errorf("ConfigMap is named %s, want prod-config-<hash>", configMap.GetName())
+	}
+
+	containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	ref, _, _ := unstructured.NestedString(containers[0].(map[string]interface{})["envFrom"].([]interface{})[0].(map[string]interface{}), "configMapRef", "name")
+	if ref != configMap.GetName() {
+		t.Errorf("Deployment refers to ConfigMap %s, want %s", ref, configMap.GetName())
+	}
+}
+
+func TestBuildRejectsRemoteResources(t *testing.T) {
+	for _, resource := range []string{
+		"https://github.com/example/app//deploy?ref=v1",
+		"github.com/example/app/deploy",
+		"git@github.com:example/app.git",
+	} {
+		builder := newTestBuilder(inlineFiles{"kustomization.yaml": "bases:\n- " + resource + "\n"})
+		if _, err := builder.build("."); err == nil || !strings.Contains(err.Error(), "remote") {
+			t.Errorf("%s: got error %v, want remote resources rejected", resource, err)
+		}
+	}
+}
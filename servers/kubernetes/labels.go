@@ -0,0 +1,177 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// labelTools returns the label tool definitions
+func labelTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "sync_labels",
+			Description: "Copy selected labels from one resource to another, e.g. version or team from a deployment to its service",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"source_type": map[string]interface{}{
+						"type":        "string",
+						"description": "Resource type of the source (e.g. deployment, service, pod)",
+					},
+					"source_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the source resource",
+					},
+					"source_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the source resource (defaults to default)",
+					},
+					"target_type": map[string]interface{}{
+						"type":        "string",
+						"description": "Resource type of the target",
+					},
+					"target_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the target resource",
+					},
+					"target_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the target resource (defaults to the source namespace)",
+					},
+					"label_keys": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Label keys to copy from the source",
+					},
+				},
+				"required": []string{"source_type", "source_name", "target_type", "target_name", "label_keys"},
+			},
+		},
+	}
+}
+
+// stringSliceArg extracts a required, non-empty array of strings from the arguments
+func stringSliceArg(args map[string]interface{}, key string) ([]string, error) {
+	raw, ok := args[key].([]interface{})
+	if !ok || len(raw) == 0 {
+		return nil, fmt.Errorf("%s is required and must be a non-empty array", key)
+	}
+
+	values := make([]string, 0, len(raw))
+	for _, item := range raw {
+		value, ok := item.(string)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%s must contain only non-empty strings", key)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// resourceClientFor resolves a resource type such as "deployment" or "svc" to a dynamic client
+func (s *Server) resourceClientFor(mapper meta.RESTMapper, resourceType, namespace string) (dynamic.ResourceInterface, error) {
+	gvk, err := mapper.KindFor(schema.GroupVersionResource{Resource: strings.ToLower(resourceType)})
+	if err != nil {
+		return nil, fmt.Errorf("unknown resource type '%s': %w", resourceType, err)
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map %s: %w", gvk.String(), err)
+	}
+
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return s.dynamicClient.Resource(mapping.Resource).Namespace(namespace), nil
+	}
+	return s.dynamicClient.Resource(mapping.Resource), nil
+}
+
+func (s *Server) syncLabelsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	sourceType, err := stringArg(args, "source_type")
+	if err != nil {
+		return nil, err
+	}
+	sourceName, err := stringArg(args, "source_name")
+	if err != nil {
+		return nil, err
+	}
+	targetType, err := stringArg(args, "target_type")
+	if err != nil {
+		return nil, err
+	}
+	targetName, err := stringArg(args, "target_name")
+	if err != nil {
+		return nil, err
+	}
+	labelKeys, err := stringSliceArg(args, "label_keys")
+	if err != nil {
+		return nil, err
+	}
+	sourceNamespace := optionalStringArg(args, "source_namespace", "default")
+	targetNamespace := optionalStringArg(args, "target_namespace", sourceNamespace)
+
+	ctx := context.Background()
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(s.clientset.Discovery()))
+
+	sourceClient, err := s.resourceClientFor(mapper, sourceType, sourceNamespace)
+	if err != nil {
+		return nil, err
+	}
+	targetClient, err := s.resourceClientFor(mapper, targetType, targetNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := sourceClient.Get(ctx, sourceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	sourceLabels := source.GetLabels()
+	labels := make(map[string]string, len(labelKeys))
+	var missing []string
+	for _, key := range labelKeys {
+		if value, ok := sourceLabels[key]; ok {
+			labels[key] = value
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("%s '%s' has none of the labels: %s", sourceType, sourceName, strings.Join(labelKeys, ", "))
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"labels": labels},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build label patch: %w", err)
+	}
+	if _, err := targetClient.Patch(ctx, targetName, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, err
+	}
+
+	synced := make([]string, 0, len(labels))
+	for key, value := range labels {
+		synced = append(synced, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(synced)
+
+	message := fmt.Sprintf("Successfully synced labels %s from %s '%s' to %s '%s'",
+		strings.Join(synced, ", "), sourceType, sourceName, targetType, targetName)
+	if len(missing) > 0 {
+		message += fmt.Sprintf("\nSkipped labels missing on the source: %s", strings.Join(missing, ", "))
+	}
+	return textResult(message), nil
+}
@@ -0,0 +1,223 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// limitRangeTools returns the LimitRange tool definitions
+func limitRangeTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "list_limitranges",
+			Description: "List LimitRanges per namespace with their default, default request, min and max values for Container, Pod and PersistentVolumeClaim",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to list (omit for all namespaces)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "create_limitrange",
+			Description: "Create a LimitRange so containers without resource requests and limits get bounded defaults",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the LimitRange",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the LimitRange",
+					},
+					"limits": map[string]interface{}{
+						"type":        "array",
+						"description": "Limits to enforce",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"type": map[string]interface{}{
+									"type":        "string",
+									"enum":        []string{"Container", "Pod", "PersistentVolumeClaim"},
+									"description": "Kind of object the limit applies to",
+								},
+								"default_cpu": map[string]interface{}{
+									"type":        "string",
+									"description": "Default CPU limit, e.g. 500m (Container only)",
+								},
+								"default_memory": map[string]interface{}{
+									"type":        "string",
+									"description": "Default memory limit, e.g. 512Mi (Container only)",
+								},
+								"max_cpu": map[string]interface{}{
+									"type":        "string",
+									"description": "Maximum CPU",
+								},
+								"max_memory": map[string]interface{}{
+									"type":        "string",
+									"description": "Maximum memory",
+								},
+								"max_storage": map[string]interface{}{
+									"type":        "string",
+									"description": "Maximum storage request (PersistentVolumeClaim only)",
+								},
+							},
+							"required": []string{"type"},
+						},
+					},
+				},
+				"required": []string{"name", "namespace", "limits"},
+			},
+		},
+	}
+}
+
+// limitRangeSummary is one LimitRange and its limit items
+type limitRangeSummary struct {
+	Name   string            `json:"name"`
+	Limits []limitRangeLimit `json:"limits"`
+}
+
+// limitRangeLimit holds the values of one LimitRange item
+type limitRangeLimit struct {
+	Type           string            `json:"type"`
+	Default        map[string]string `json:"default,omitempty"`
+	DefaultRequest map[string]string `json:"default_request,omitempty"`
+	Min            map[string]string `json:"min,omitempty"`
+	Max            map[string]string `json:"max,omitempty"`
+}
+
+func (s *Server) listLimitRangesTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "")
+
+	list, err := s.clientset.CoreV1().LimitRanges(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string][]limitRangeSummary{}
+	for _, limitRange := range list.Items {
+		summary := limitRangeSummary{Name: limitRange.Name}
+		for _, item := range limitRange.Spec.Limits {
+			summary.Limits = append(summary.Limits, limitRangeLimit{
+				Type:           string(item.Type),
+				Default:        resourceListStrings(item.Default),
+				DefaultRequest: resourceListStrings(item.DefaultRequest),
+				Min:            resourceListStrings(item.Min),
+				Max:            resourceListStrings(item.Max),
+			})
+		}
+		namespaces[limitRange.Namespace] = append(namespaces[limitRange.Namespace], summary)
+	}
+	for _, summaries := range namespaces {
+		sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	}
+
+	return jsonResult(map[string]interface{}{
+		"namespaces": namespaces,
+		"count":      len(list.Items),
+	})
+}
+
+// resourceListStrings formats a resource list as name to quantity strings
+func resourceListStrings(list corev1.ResourceList) map[string]string {
+	if len(list) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(list))
+	for name, quantity := range list {
+		out[string(name)] = quantity.String()
+	}
+	return out
+}
+
+// limitRangeTypes are the LimitRange item types accepted by create_limitrange
+var limitRangeTypes = map[string]corev1.LimitType{
+	"Container":             corev1.LimitTypeContainer,
+	"Pod":                   corev1.LimitTypePod,
+	"PersistentVolumeClaim": corev1.LimitTypePersistentVolumeClaim,
+}
+
+func (s *Server) createLimitRangeTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	rawLimits, ok := args["limits"].([]interface{})
+	if !ok || len(rawLimits) == 0 {
+		return nil, fmt.Errorf("at least one limit is required")
+	}
+
+	var items []corev1.LimitRangeItem
+	for i, raw := range rawLimits {
+		limit, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("limits[%d] must be an object", i)
+		}
+		limitType, ok := limitRangeTypes[optionalStringArg(limit, "type", "")]
+		if !ok {
+			return nil, fmt.Errorf("limits[%d].type must be Container, Pod or PersistentVolumeClaim", i)
+		}
+
+		item := corev1.LimitRangeItem{Type: limitType}
+		if item.Default, err = quantityList(limit, i, map[string]corev1.ResourceName{
+			"default_cpu":    corev1.ResourceCPU,
+			"default_memory": corev1.ResourceMemory,
+		}); err != nil {
+			return nil, err
+		}
+		if item.Max, err = quantityList(limit, i, map[string]corev1.ResourceName{
+			"max_cpu":     corev1.ResourceCPU,
+			"max_memory":  corev1.ResourceMemory,
+			"max_storage": corev1.ResourceStorage,
+		}); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       corev1.LimitRangeSpec{Limits: items},
+	}
+	if _, err := s.clientset.CoreV1().LimitRanges(namespace).Create(context.Background(), limitRange, metav1.CreateOptions{}); err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Successfully created LimitRange '%s' in namespace '%s' with %d limit(s)", name, namespace, len(items))), nil
+}
+
+// quantityList parses the quantity arguments of limits[index] present in fields into a resource list
+func quantityList(limit map[string]interface{}, index int, fields map[string]corev1.ResourceName) (corev1.ResourceList, error) {
+	var list corev1.ResourceList
+	for arg, resourceName := range fields {
+		value := optionalStringArg(limit, arg, "")
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("limits[%d].%s: invalid quantity %q: %w", index, arg, value, err)
+		}
+		if list == nil {
+			list = corev1.ResourceList{}
+		}
+		list[resourceName] = quantity
+	}
+	return list, nil
+}
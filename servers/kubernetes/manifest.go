@@ -0,0 +1,168 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+)
+
+// fieldManager identifies this server in server-side apply
+const fieldManager = "kubernetes-mcp-server"
+
+// manifestTools returns the manifest tool definitions
+func manifestTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "apply_manifest",
+			Description: "Apply a YAML or JSON manifest containing one or more Kubernetes resources using server-side apply",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"manifest": map[string]interface{}{
+						"type":        "string",
+						"description": "Manifest to apply; multiple documents are separated by ---",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace for namespaced resources that do not set one (defaults to default)",
+					},
+				},
+				"required": []string{"manifest"},
+			},
+		},
+	}
+}
+
+func (s *Server) applyManifestTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	manifest, err := stringArg(args, "manifest")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("manifest contains no resources")
+	}
+
+	applied, err := s.applyObjects(context.Background(), objects, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Applied %d resource(s):\n%s", len(applied), strings.Join(applied, "\n"))), nil
+}
+
+// decodeManifest decodes every non-empty YAML or JSON document into an unstructured object
+func decodeManifest(manifest string) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+
+	var objects []*unstructured.Unstructured
+	for {
+		var raw map[string]interface{}
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to decode manifest: %w", err)
+		}
+		if len(raw) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{Object: raw}
+		if obj.GetAPIVersion() == "" || obj.GetKind() == "" {
+			return nil, fmt.Errorf("manifest document %d is missing apiVersion or kind", len(objects)+1)
+		}
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+// applyObjects server-side applies each object and returns a line describing each
+func (s *Server) applyObjects(ctx context.Context, objects []*unstructured.Unstructured, defaultNamespace string) ([]string, error) {
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(s.clientset.Discovery()))
+
+	var applied []string
+	for _, obj := range objects {
+		line, err := s.applyObject(ctx, mapper, obj, defaultNamespace)
+		if err != nil {
+			return applied, err
+		}
+		applied = append(applied, line)
+	}
+
+	return applied, nil
+}
+
+// applyObject server-side applies one object and returns a line describing it
+func (s *Server) applyObject(ctx context.Context, mapper meta.RESTMapper, obj *unstructured.Unstructured, defaultNamespace string) (string, error) {
+	result, err := s.serverSideApply(ctx, mapper, obj, defaultNamespace)
+	if err != nil {
+		return "", err
+	}
+	return appliedLine(result), nil
+}
+
+// serverSideApply server-side applies one object, setting defaultNamespace on namespaced objects without one, and
+// returns the object as stored
+func (s *Server) serverSideApply(ctx context.Context, mapper meta.RESTMapper, obj *unstructured.Unstructured, defaultNamespace string) (*unstructured.Unstructured, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to map %s: %w", gvk.String(), err)
+	}
+
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	resource := s.dynamicClient.Resource(mapping.Resource)
+	options := metav1.PatchOptions{FieldManager: fieldManager, Force: boolPtr(true)}
+
+	var result *unstructured.Unstructured
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(defaultNamespace)
+			data, _ = json.Marshal(obj.Object)
+		}
+		result, err = resource.Namespace(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.ApplyPatchType, data, options)
+	} else {
+		result, err = resource.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, options)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply %s/%s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+
+	return result, nil
+}
+
+// appliedLine describes an applied object as Kind/name, with its namespace if it has one
+func appliedLine(obj *unstructured.Unstructured) string {
+	line := fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+	if obj.GetNamespace() != "" {
+		line += " in namespace " + obj.GetNamespace()
+	}
+	return line
+}
+
+// boolPtr returns a pointer to b
+func boolPtr(b bool) *bool {
+	return &b
+}
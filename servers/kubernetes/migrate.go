@@ -0,0 +1,244 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Deployment migration settings
+const (
+	defaultMigrationTimeout = 5 * time.Minute
+	migrationPollInterval   = 2 * time.Second
+)
+
+// migrationDroppedAnnotations are annotations that describe the source object rather than the workload
+var migrationDroppedAnnotations = []string{
+	"deployment.kubernetes.io/revision",
+	"kubectl.kubernetes.io/last-applied-configuration",
+}
+
+// migrateTools returns the deployment migration tool definitions
+func migrateTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "migrate_deployment",
+			Description: "Move a deployment to another namespace, copying the ConfigMaps and Secrets it references, waiting for it to become available and optionally deleting the source",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"source_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace the deployment is in",
+					},
+					"destination_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to move the deployment to",
+					},
+					"delete_source": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Delete the source deployment once the copy is available",
+					},
+					"timeout_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "How long to wait for the migrated deployment to become available (defaults to 300)",
+					},
+				},
+				"required": []string{"name", "source_namespace", "destination_namespace"},
+			},
+		},
+	}
+}
+
+// migratedConfig is the outcome for one ConfigMap or Secret referenced by a migrated deployment
+type migratedConfig struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+func (s *Server) migrateDeploymentTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	srcNamespace, err := stringArg(args, "source_namespace")
+	if err != nil {
+		return nil, err
+	}
+	dstNamespace, err := stringArg(args, "destination_namespace")
+	if err != nil {
+		return nil, err
+	}
+	if srcNamespace == dstNamespace {
+		return nil, fmt.Errorf("source and destination namespaces must differ")
+	}
+	deleteSource := boolArg(args, "delete_source")
+	timeoutSeconds, err := intArg(args, "timeout_seconds", int(defaultMigrationTimeout/time.Second))
+	if err != nil {
+		return nil, err
+	}
+	if timeoutSeconds <= 0 {
+		return nil, fmt.Errorf("timeout_seconds must be positive")
+	}
+	timeout := time.Duration(timeoutSeconds) * time.Second
+	ctx := context.Background()
+
+	src, err := s.clientset.AppsV1().Deployments(srcNamespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := s.migrateDeploymentConfig(ctx, src, dstNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := copiedObjectMeta(src.ObjectMeta, dstNamespace)
+	meta.Annotations = make(map[string]string, len(src.Annotations))
+	for key, value := range src.Annotations {
+		meta.Annotations[key] = value
+	}
+	for _, key := range migrationDroppedAnnotations {
+		delete(meta.Annotations, key)
+	}
+	dst := &appsv1.Deployment{ObjectMeta: meta, Spec: src.Spec}
+	if _, err := s.clientset.AppsV1().Deployments(dstNamespace).Create(ctx, dst, metav1.CreateOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to create deployment in namespace %s: %w", dstNamespace, err)
+	}
+
+	start := time.Now()
+	migrated, available, err := s.waitForDeploymentAvailable(ctx, dstNamespace, name, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceDeleted := false
+	if deleteSource && available {
+		if err := s.clientset.AppsV1().Deployments(srcNamespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
+			return nil, fmt.Errorf("migrated deployment is available but deleting the source failed: %w", err)
+		}
+		sourceDeleted = true
+	}
+
+	replicas := int32(1)
+	if migrated.Spec.Replicas != nil {
+		replicas = *migrated.Spec.Replicas
+	}
+	report := map[string]interface{}{
+		"deployment":            name,
+		"source_namespace":      srcNamespace,
+		"destination_namespace": dstNamespace,
+		"replicas":              replicas,
+		"available_replicas":    migrated.Status.AvailableReplicas,
+		"available":             available,
+		"wait_time":             time.Since(start).Round(time.Second).String(),
+		"config":                configs,
+		"source_deleted":        sourceDeleted,
+	}
+	if !available {
+		report["note"] = fmt.Sprintf("deployment did not become available within %s; the source was kept", timeout)
+	}
+	return jsonResult(report)
+}
+
+// migrateDeploymentConfig copies the ConfigMaps, Secrets and image pull Secrets a deployment references into namespace.
+// Objects that already exist there are left alone, and missing ones are reported rather than treated as failures.
+func (s *Server) migrateDeploymentConfig(ctx context.Context, deployment *appsv1.Deployment, namespace string) ([]migratedConfig, error) {
+	refs := map[string]map[string]bool{"ConfigMap": {}, "Secret": {}}
+	visitConfigReferences(&deployment.Spec.Template.Spec, func(kind, name, _ string) {
+		refs[kind][name] = true
+	})
+	for _, secret := range deployment.Spec.Template.Spec.ImagePullSecrets {
+		refs["Secret"][secret.Name] = true
+	}
+
+	var results []migratedConfig
+	for _, kind := range []string{"ConfigMap", "Secret"} {
+		names := make([]string, 0, len(refs[kind]))
+		for name := range refs[kind] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			result := migratedConfig{Kind: kind, Name: name}
+			exists, err := s.configExists(ctx, kind, namespace, name)
+			if err != nil {
+				return results, err
+			}
+			if exists {
+				result.Status = "exists"
+				results = append(results, result)
+				continue
+			}
+
+			copyArgs := map[string]interface{}{
+				"src_name":      name,
+				"src_namespace": deployment.Namespace,
+				"dst_namespace": namespace,
+			}
+			if kind == "ConfigMap" {
+				_, err = s.copyConfigMapTool(copyArgs)
+			} else {
+				_, err = s.copySecretTool(copyArgs)
+			}
+			switch {
+			case apierrors.IsNotFound(err):
+				result.Status = "missing"
+			case err != nil:
+				return results, fmt.Errorf("failed to copy %s %s: %w", kind, name, err)
+			default:
+				result.Status = "copied"
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// configExists reports whether a ConfigMap or Secret exists
+func (s *Server) configExists(ctx context.Context, kind, namespace, name string) (bool, error) {
+	var err error
+	if kind == "ConfigMap" {
+		_, err = s.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	} else {
+		_, err = s.clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// waitForDeploymentAvailable polls a deployment until all desired replicas are available or timeout expires
+func (s *Server) waitForDeploymentAvailable(ctx context.Context, namespace, name string, timeout time.Duration) (*appsv1.Deployment, bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, false, err
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		if deployment.Status.ObservedGeneration >= deployment.Generation && deployment.Status.AvailableReplicas == replicas {
+			return deployment, true, nil
+		}
+		if time.Now().After(deadline) {
+			return deployment, false, nil
+		}
+		time.Sleep(migrationPollInterval)
+	}
+}
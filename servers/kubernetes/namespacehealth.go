@@ -0,0 +1,312 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HealthScoreWeights weights the parts of the health_score_namespace score; only their ratios matter
+type HealthScoreWeights struct {
+	Pods        float64
+	Deployments float64
+	Events      float64
+	Quota       float64
+}
+
+// DefaultHealthScoreWeights favours pod and deployment health over events and quota pressure
+var DefaultHealthScoreWeights = HealthScoreWeights{Pods: 40, Deployments: 30, Events: 15, Quota: 15}
+
+// Namespace health settings
+const (
+	// namespaceEventWindow is how far back warning events count against the score
+	namespaceEventWindow = time.Hour
+
+	// quotaPressureThreshold is the quota utilisation at which the quota score starts to drop, reaching zero when a quota is full
+	quotaPressureThreshold = 0.8
+)
+
+// namespaceHealthTools returns the namespace health tool definitions
+func namespaceHealthTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "health_score_namespace",
+			Description: "Score the health of a namespace from 0 to 100 from unhealthy pods, unavailable deployment replicas, recent warning events and quota utilisation, listing the issues found; a first check when something is wrong in a namespace",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to score",
+					},
+				},
+				"required": []string{"namespace"},
+			},
+		},
+	}
+}
+
+// healthPart is one scored part of a namespace's health
+type healthPart struct {
+	Score   int     `json:"score"`
+	Weight  float64 `json:"weight"`
+	Summary string  `json:"summary,omitempty"`
+	Error   string  `json:"error,omitempty"`
+
+	issues []string
+}
+
+func (s *Server) healthScoreNamespaceTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	var pods, deployments, events, quota *healthPart
+	var wg sync.WaitGroup
+	for _, check := range []struct {
+		part **healthPart
+		run  func(context.Context, string) (*healthPart, error)
+	}{
+		{&pods, s.podHealth},
+		{&deployments, s.deploymentsHealth},
+		{&events, s.eventsHealth},
+		{&quota, s.quotaHealth},
+	} {
+		wg.Add(1)
+		go func(part **healthPart, run func(context.Context, string) (*healthPart, error)) {
+			defer wg.Done()
+			result, err := run(ctx, namespace)
+			if err != nil {
+				result = &healthPart{Error: err.Error()}
+			}
+			*part = result
+		}(check.part, check.run)
+	}
+	wg.Wait()
+
+	weights := s.healthWeights
+	pods.Weight, deployments.Weight, events.Weight, quota.Weight = weights.Pods, weights.Deployments, weights.Events, weights.Quota
+
+	// Parts that could not be fetched do not count toward the score
+	var weighted, total float64
+	issues := []string{}
+	var failures []string
+	for _, part := range []struct {
+		name string
+		*healthPart
+	}{{"pods", pods}, {"deployments", deployments}, {"events", events}, {"quota", quota}} {
+		if part.Error != "" {
+			failures = append(failures, fmt.Sprintf("%s: %s", part.name, part.Error))
+			continue
+		}
+		weighted += float64(part.Score) * part.Weight
+		total += part.Weight
+		issues = append(issues, part.issues...)
+	}
+	if total == 0 {
+		if len(failures) > 0 {
+			return nil, fmt.Errorf("failed to check namespace %s: %s", namespace, strings.Join(failures, "; "))
+		}
+		return nil, fmt.Errorf("health score weights are all zero")
+	}
+
+	return jsonResult(map[string]interface{}{
+		"namespace": namespace,
+		"score":     int(math.Round(weighted / total)),
+		"breakdown": map[string]*healthPart{
+			"pods":        pods,
+			"deployments": deployments,
+			"events":      events,
+			"quota":       quota,
+		},
+		"issues": issues,
+	})
+}
+
+// podHealth scores the share of pods that are running normally
+func (s *Server) podHealth(ctx context.Context, namespace string) (*healthPart, error) {
+	list, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var pending, failed, unhealthy int
+	waiting := map[string]int{}
+	for _, pod := range list.Items {
+		bad := false
+		switch pod.Status.Phase {
+		case corev1.PodPending:
+			pending++
+			bad = true
+		case corev1.PodFailed:
+			failed++
+			bad = true
+		}
+		for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+			if status.State.Waiting != nil && status.State.Waiting.Reason != "" && status.State.Waiting.Reason != "ContainerCreating" && status.State.Waiting.Reason != "PodInitializing" {
+				waiting[status.State.Waiting.Reason]++
+				bad = true
+				break
+			}
+		}
+		if bad {
+			unhealthy++
+		}
+	}
+
+	part := &healthPart{
+		Score:   percentScore(len(list.Items)-unhealthy, len(list.Items)),
+		Summary: fmt.Sprintf("%d of %d pods unhealthy (%d pending, %d failed)", unhealthy, len(list.Items), pending, failed),
+	}
+	for _, reason := range sortedCountKeys(waiting) {
+		part.issues = append(part.issues, fmt.Sprintf("%s in %s", countNoun(waiting[reason], "pod"), reason))
+	}
+	if pending > 0 {
+		part.issues = append(part.issues, fmt.Sprintf("%s Pending", countNoun(pending, "pod")))
+	}
+	if failed > 0 {
+		part.issues = append(part.issues, fmt.Sprintf("%s Failed", countNoun(failed, "pod")))
+	}
+	return part, nil
+}
+
+// deploymentsHealth scores the share of desired deployment replicas that are available
+func (s *Server) deploymentsHealth(ctx context.Context, namespace string) (*healthPart, error) {
+	list, err := s.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var desired, available, degraded int
+	part := &healthPart{}
+	for _, deployment := range list.Items {
+		want := 1
+		if deployment.Spec.Replicas != nil {
+			want = int(*deployment.Spec.Replicas)
+		}
+		have := min(int(deployment.Status.AvailableReplicas), want)
+		desired += want
+		available += have
+		if have < want {
+			degraded++
+			part.issues = append(part.issues, fmt.Sprintf("deployment %s has %d of %d replicas unavailable", deployment.Name, want-have, want))
+		}
+	}
+
+	part.Score = percentScore(available, desired)
+	part.Summary = fmt.Sprintf("%d of %d deployments degraded, %d of %d replicas unavailable", degraded, len(list.Items), desired-available, desired)
+	return part, nil
+}
+
+// eventsHealth takes warningEventPenalty off the score for each warning event seen within namespaceEventWindow
+func (s *Server) eventsHealth(ctx context.Context, namespace string) (*healthPart, error) {
+	list, err := s.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: "type=Warning"})
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().Add(-namespaceEventWindow)
+	total := 0
+	reasons := map[string]int{}
+	for _, event := range list.Items {
+		if eventTime(event).Before(since) {
+			continue
+		}
+		count := int(event.Count)
+		if count == 0 && event.Series != nil {
+			count = int(event.Series.Count)
+		}
+		if count == 0 {
+			count = 1
+		}
+		total += count
+		reasons[event.Reason] += count
+	}
+
+	part := &healthPart{
+		Score:   max(0, 100-warningEventPenalty*total),
+		Summary: fmt.Sprintf("%s in the last %.0f minutes", countNoun(total, "warning event"), namespaceEventWindow.Minutes()),
+	}
+	if total > 0 {
+		var top []string
+		for _, reason := range sortedCountKeys(reasons) {
+			top = append(top, fmt.Sprintf("%s x%d", reason, reasons[reason]))
+		}
+		part.issues = append(part.issues, fmt.Sprintf("%s in the last %.0f minutes (%s)", countNoun(total, "warning event"), namespaceEventWindow.Minutes(), strings.Join(top, ", ")))
+	}
+	return part, nil
+}
+
+// quotaHealth scores the most utilised quota resource, dropping from 100 at quotaPressureThreshold to 0 when full
+func (s *Server) quotaHealth(ctx context.Context, namespace string) (*healthPart, error) {
+	list, err := s.clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return &healthPart{Score: 100, Summary: "no resource quotas"}, nil
+	}
+
+	highest, highestName := 0.0, ""
+	part := &healthPart{}
+	for _, quota := range list.Items {
+		for name, hard := range quota.Spec.Hard {
+			limit := hard.AsApproximateFloat64()
+			if limit <= 0 {
+				continue
+			}
+			used := quota.Status.Used[name]
+			utilisation := used.AsApproximateFloat64() / limit
+			if utilisation > highest {
+				highest, highestName = utilisation, fmt.Sprintf("%s %s", quota.Name, name)
+			}
+			if utilisation >= quotaPressureThreshold {
+				part.issues = append(part.issues, fmt.Sprintf("quota %s: %s at %.0f%% (%s of %s)", quota.Name, name, utilisation*100, used.String(), hard.String()))
+			}
+		}
+	}
+	sort.Strings(part.issues)
+
+	part.Score = 100
+	if highest >= quotaPressureThreshold {
+		part.Score = max(0, int(math.Round(100*(1-highest)/(1-quotaPressureThreshold))))
+	}
+	part.Summary = "no quota in use"
+	if highestName != "" {
+		part.Summary = fmt.Sprintf("highest utilisation %.0f%% (%s)", highest*100, highestName)
+	}
+	return part, nil
+}
+
+// percentScore returns good as a 0-100 share of total; nothing to check scores 100
+func percentScore(good, total int) int {
+	if total == 0 {
+		return 100
+	}
+	return int(math.Round(100 * float64(good) / float64(total)))
+}
+
+// countNoun formats a count with a noun, pluralised with s
+func countNoun(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// sortedCountKeys returns the keys of counts, highest count first and then by name
+func sortedCountKeys(counts map[string]int) []string {
+	keys := sortedKeys(counts)
+	sort.SliceStable(keys, func(i, j int) bool { return counts[keys[i]] > counts[keys[j]] })
+	return keys
+}
@@ -0,0 +1,524 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// generatedLabelKeys are pod labels set by controllers that should not appear in policy selectors
+var generatedLabelKeys = map[string]bool{
+	"pod-template-hash":                  true,
+	"controller-revision-hash":           true,
+	"statefulset.kubernetes.io/pod-name": true,
+	"pod-template-generation":            true,
+}
+
+// networkPolicyTools returns the NetworkPolicy tool definitions
+func networkPolicyTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "analyze_and_generate_networkpolicy",
+			Description: "Infer traffic between pods in a namespace and generate least-privilege NetworkPolicy manifests allowing only that traffic",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to analyze (defaults to default)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "simulate_networkpolicy",
+			Description: "Decide whether NetworkPolicies would allow traffic from a source pod to a destination pod",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"policy": map[string]interface{}{
+						"type":        "string",
+						"description": "NetworkPolicy YAML; multiple documents are separated by ---",
+					},
+					"source_pod": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the pod sending traffic",
+					},
+					"source_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the source pod (defaults to default)",
+					},
+					"destination_pod": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the pod receiving traffic",
+					},
+					"destination_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the destination pod (defaults to the source namespace)",
+					},
+					"port": map[string]interface{}{
+						"type":        "integer",
+						"description": "Destination port (optional; without it port restrictions are reported but not enforced)",
+					},
+					"protocol": map[string]interface{}{
+						"type":        "string",
+						"description": "Protocol: TCP, UDP or SCTP (defaults to TCP)",
+					},
+					"include_existing": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Also apply the NetworkPolicies already in the cluster",
+					},
+				},
+				"required": []string{"policy", "source_pod", "destination_pod"},
+			},
+		},
+	}
+}
+
+// inferredFlow is traffic from client pods to the pods behind a service
+type inferredFlow struct {
+	service  corev1.Service
+	clients  map[string]map[string]string
+	evidence []string
+}
+
+func (s *Server) analyzeAndGenerateNetworkPolicyTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "default")
+	ctx := context.Background()
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	services, err := s.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Traffic logs are not reachable through the Kubernetes API, so infer flows from
+	// pods whose environment or arguments reference a service in the namespace
+	var flows []*inferredFlow
+	for _, service := range services.Items {
+		if len(service.Spec.Selector) == 0 {
+			continue
+		}
+		flow := &inferredFlow{service: service, clients: map[string]map[string]string{}}
+		for _, pod := range pods.Items {
+			if labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+			if reference := podReferencesService(pod, service.Name, namespace); reference != "" {
+				selector := policyLabels(pod.Labels)
+				if len(selector) == 0 {
+					continue
+				}
+				flow.clients[labels.Set(selector).String()] = selector
+				flow.evidence = append(flow.evidence, fmt.Sprintf("pod %s references service %s in %s", pod.Name, service.Name, reference))
+			}
+		}
+		flows = append(flows, flow)
+	}
+
+	policies := []networkingv1.NetworkPolicy{defaultDenyPolicy(namespace)}
+	var notes []string
+	notes = append(notes, "Traffic was inferred from service references in pod environment variables and arguments; DNS and audit logs are not available through the Kubernetes API.")
+	for _, flow := range flows {
+		policies = append(policies, servicePolicy(namespace, flow))
+		if len(flow.clients) == 0 {
+			notes = append(notes, fmt.Sprintf("service %s: no in-namespace clients found; ingress to it is denied", flow.service.Name))
+		}
+		notes = append(notes, flow.evidence...)
+	}
+
+	var documents []string
+	for i := range policies {
+		data, err := yaml.Marshal(&policies[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal network policy: %w", err)
+		}
+		documents = append(documents, string(data))
+	}
+
+	return &mcp.ToolResult{
+		Content: []mcp.ToolResultContent{
+			{Type: "text/x-yaml", Text: strings.Join(documents, "---\n")},
+			{Type: "text", Text: strings.Join(notes, "\n")},
+		},
+	}, nil
+}
+
+// podReferencesService returns where a pod refers to a service, or an empty string if it does not
+func podReferencesService(pod corev1.Pod, serviceName, namespace string) string {
+	envPrefix := serviceEnvPrefix(serviceName)
+	for _, container := range pod.Spec.Containers {
+		for _, env := range container.Env {
+			if strings.HasPrefix(env.Name, envPrefix) || mentionsService(env.Value, serviceName, namespace) {
+				return fmt.Sprintf("env %s of container %s", env.Name, container.Name)
+			}
+		}
+		for _, arg := range append(append([]string{}, container.Command...), container.Args...) {
+			if mentionsService(arg, serviceName, namespace) {
+				return fmt.Sprintf("arguments of container %s", container.Name)
+			}
+		}
+	}
+	return ""
+}
+
+// serviceEnvPrefix returns the prefix of the environment variables, such as DB_SERVICE_HOST, Kubernetes sets for a service
+func serviceEnvPrefix(serviceName string) string {
+	return strings.ToUpper(strings.ReplaceAll(serviceName, "-", "_")) + "_SERVICE_"
+}
+
+// mentionsService reports whether a value names a service by its DNS name or expands one of its $(NAME_SERVICE_*) variables
+func mentionsService(value, serviceName, namespace string) bool {
+	if strings.Contains(value, "$("+serviceEnvPrefix(serviceName)) {
+		return true
+	}
+	for _, field := range strings.FieldsFunc(value, func(r rune) bool {
+		return strings.ContainsRune(" \t\r\n/:=,;@\"'", r)
+	}) {
+		if field == serviceName || field == serviceName+"."+namespace ||
+			strings.HasPrefix(field, serviceName+"."+namespace+".svc") {
+			return true
+		}
+	}
+	return false
+}
+
+// policyLabels drops controller-generated labels so the selector matches every replica
+func policyLabels(podLabels map[string]string) map[string]string {
+	selector := map[string]string{}
+	for key, value := range podLabels {
+		if !generatedLabelKeys[key] {
+			selector[key] = value
+		}
+	}
+	return selector
+}
+
+// defaultDenyPolicy denies all ingress to pods in the namespace unless another policy allows it
+func defaultDenyPolicy(namespace string) networkingv1.NetworkPolicy {
+	return networkingv1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: "default-deny-ingress", Namespace: namespace},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
+
+// servicePolicy allows the inferred clients of a service to reach its target ports
+func servicePolicy(namespace string, flow *inferredFlow) networkingv1.NetworkPolicy {
+	var ports []networkingv1.NetworkPolicyPort
+	for _, servicePort := range flow.service.Spec.Ports {
+		protocol := servicePort.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		target := servicePort.TargetPort
+		if target.IntVal == 0 && target.StrVal == "" {
+			target.IntVal = servicePort.Port
+		}
+		ports = append(ports, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &target})
+	}
+
+	keys := make([]string, 0, len(flow.clients))
+	for key := range flow.clients {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var peers []networkingv1.NetworkPolicyPeer
+	for _, key := range keys {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{MatchLabels: flow.clients[key]},
+		})
+	}
+
+	var ingress []networkingv1.NetworkPolicyIngressRule
+	if len(peers) > 0 {
+		ingress = []networkingv1.NetworkPolicyIngressRule{{From: peers, Ports: ports}}
+	}
+
+	return networkingv1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-" + flow.service.Name, Namespace: namespace},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: flow.service.Spec.Selector},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     ingress,
+		},
+	}
+}
+
+// policyEndpoint is a pod and the labels of its namespace
+type policyEndpoint struct {
+	pod             *corev1.Pod
+	namespaceLabels map[string]string
+}
+
+func (s *Server) simulateNetworkPolicyTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	manifest, err := stringArg(args, "policy")
+	if err != nil {
+		return nil, err
+	}
+	sourceName, err := stringArg(args, "source_pod")
+	if err != nil {
+		return nil, err
+	}
+	destinationName, err := stringArg(args, "destination_pod")
+	if err != nil {
+		return nil, err
+	}
+	sourceNamespace := optionalStringArg(args, "source_namespace", "default")
+	destinationNamespace := optionalStringArg(args, "destination_namespace", sourceNamespace)
+	port, err := intArg(args, "port", 0)
+	if err != nil {
+		return nil, err
+	}
+	protocol := corev1.Protocol(strings.ToUpper(optionalStringArg(args, "protocol", "TCP")))
+	ctx := context.Background()
+
+	policies, err := decodeNetworkPolicies(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if boolArg(args, "include_existing") {
+		for _, namespace := range []string{sourceNamespace, destinationNamespace} {
+			existing, err := s.clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, existing.Items...)
+			if sourceNamespace == destinationNamespace {
+				break
+			}
+		}
+	}
+
+	source, err := s.policyEndpoint(ctx, sourceName, sourceNamespace)
+	if err != nil {
+		return nil, err
+	}
+	destination, err := s.policyEndpoint(ctx, destinationName, destinationNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var reasons []string
+	egressAllowed, egressReasons := evaluatePolicies(policies, networkingv1.PolicyTypeEgress, source, destination, port, protocol)
+	reasons = append(reasons, egressReasons...)
+	ingressAllowed, ingressReasons := evaluatePolicies(policies, networkingv1.PolicyTypeIngress, destination, source, port, protocol)
+	reasons = append(reasons, ingressReasons...)
+
+	return jsonResult(map[string]interface{}{
+		"source":          sourceNamespace + "/" + sourceName,
+		"destination":     destinationNamespace + "/" + destinationName,
+		"port":            port,
+		"protocol":        protocol,
+		"egress_allowed":  egressAllowed,
+		"ingress_allowed": ingressAllowed,
+		"allowed":         egressAllowed && ingressAllowed,
+		"reasons":         reasons,
+	})
+}
+
+// decodeNetworkPolicies decodes the NetworkPolicy documents in a manifest
+func decodeNetworkPolicies(manifest string) ([]networkingv1.NetworkPolicy, error) {
+	objects, err := decodeManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []networkingv1.NetworkPolicy
+	for _, obj := range objects {
+		if obj.GetKind() != "NetworkPolicy" {
+			return nil, fmt.Errorf("expected NetworkPolicy documents, got %s", obj.GetKind())
+		}
+		var policy networkingv1.NetworkPolicy
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &policy); err != nil {
+			return nil, fmt.Errorf("failed to decode NetworkPolicy %s: %w", obj.GetName(), err)
+		}
+		if policy.Namespace == "" {
+			policy.Namespace = "default"
+		}
+		policies = append(policies, policy)
+	}
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("policy contains no NetworkPolicy documents")
+	}
+	return policies, nil
+}
+
+// policyEndpoint fetches a pod and its namespace labels
+func (s *Server) policyEndpoint(ctx context.Context, name, namespace string) (*policyEndpoint, error) {
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ns, err := s.clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &policyEndpoint{pod: pod, namespaceLabels: ns.Labels}, nil
+}
+
+// evaluatePolicies decides whether the policies selecting subject allow traffic with peer in the given direction
+func evaluatePolicies(policies []networkingv1.NetworkPolicy, direction networkingv1.PolicyType, subject, peer *policyEndpoint, port int, protocol corev1.Protocol) (bool, []string) {
+	var reasons []string
+	selected := false
+
+	for _, policy := range policies {
+		if policy.Namespace != subject.pod.Namespace || !policyHasType(policy, direction) {
+			continue
+		}
+		podSelector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil || !podSelector.Matches(labels.Set(subject.pod.Labels)) {
+			continue
+		}
+		selected = true
+
+		if direction == networkingv1.PolicyTypeIngress {
+			for _, rule := range policy.Spec.Ingress {
+				if peersMatch(rule.From, policy.Namespace, peer) && portsMatch(rule.Ports, subject.pod, port, protocol) {
+					return true, append(reasons, fmt.Sprintf("ingress allowed by %s/%s", policy.Namespace, policy.Name))
+				}
+			}
+		} else {
+			for _, rule := range policy.Spec.Egress {
+				if peersMatch(rule.To, policy.Namespace, peer) && portsMatch(rule.Ports, peer.pod, port, protocol) {
+					return true, append(reasons, fmt.Sprintf("egress allowed by %s/%s", policy.Namespace, policy.Name))
+				}
+			}
+		}
+		reasons = append(reasons, fmt.Sprintf("%s/%s selects %s for %s but no rule matches", policy.Namespace, policy.Name, subject.pod.Name, strings.ToLower(string(direction))))
+	}
+
+	if !selected {
+		return true, append(reasons, fmt.Sprintf("no policy selects %s for %s, so it is allowed", subject.pod.Name, strings.ToLower(string(direction))))
+	}
+	return false, reasons
+}
+
+// policyHasType reports whether a policy applies to a direction, using the API defaults when policyTypes is empty
+func policyHasType(policy networkingv1.NetworkPolicy, direction networkingv1.PolicyType) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return direction == networkingv1.PolicyTypeIngress ||
+			(direction == networkingv1.PolicyTypeEgress && len(policy.Spec.Egress) > 0)
+	}
+	for _, policyType := range policy.Spec.PolicyTypes {
+		if policyType == direction {
+			return true
+		}
+	}
+	return false
+}
+
+// peersMatch reports whether any peer matches the endpoint; an empty list matches everything
+func peersMatch(peers []networkingv1.NetworkPolicyPeer, policyNamespace string, endpoint *policyEndpoint) bool {
+	if len(peers) == 0 {
+		return true
+	}
+
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			if ipBlockMatches(peer.IPBlock, endpoint.pod.Status.PodIP) {
+				return true
+			}
+			continue
+		}
+
+		if peer.NamespaceSelector != nil {
+			nsSelector, err := metav1.LabelSelectorAsSelector(peer.NamespaceSelector)
+			if err != nil || !nsSelector.Matches(labels.Set(endpoint.namespaceLabels)) {
+				continue
+			}
+		} else if endpoint.pod.Namespace != policyNamespace {
+			continue
+		}
+
+		if peer.PodSelector != nil {
+			podSelector, err := metav1.LabelSelectorAsSelector(peer.PodSelector)
+			if err != nil || !podSelector.Matches(labels.Set(endpoint.pod.Labels)) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// ipBlockMatches reports whether an IP falls inside the block and outside its exceptions
+func ipBlockMatches(block *networkingv1.IPBlock, ip string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	if _, cidr, err := net.ParseCIDR(block.CIDR); err != nil || !cidr.Contains(addr) {
+		return false
+	}
+	for _, except := range block.Except {
+		if _, cidr, err := net.ParseCIDR(except); err == nil && cidr.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// portsMatch reports whether a port and protocol match the rule ports; named ports resolve against the destination pod.
+// Without a port, only the protocol is compared.
+func portsMatch(ports []networkingv1.NetworkPolicyPort, destination *corev1.Pod, port int, protocol corev1.Protocol) bool {
+	if len(ports) == 0 {
+		return true
+	}
+
+	for _, rulePort := range ports {
+		ruleProtocol := corev1.ProtocolTCP
+		if rulePort.Protocol != nil {
+			ruleProtocol = *rulePort.Protocol
+		}
+		if ruleProtocol != protocol {
+			continue
+		}
+		if port == 0 || rulePort.Port == nil {
+			return true
+		}
+
+		start := int(rulePort.Port.IntVal)
+		if rulePort.Port.StrVal != "" {
+			start = namedContainerPort(destination, rulePort.Port.StrVal)
+		}
+		end := start
+		if rulePort.EndPort != nil {
+			end = int(*rulePort.EndPort)
+		}
+		if start != 0 && port >= start && port <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// namedContainerPort resolves a named container port, returning 0 if the pod does not declare it
+func namedContainerPort(pod *corev1.Pod, name string) int {
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == name {
+				return int(containerPort.ContainerPort)
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,168 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// oomKilledReason is the terminated reason the kubelet reports when the kernel OOM killer stops a container
+	oomKilledReason = "OOMKilled"
+	// oomLeakRestarts is the restart count above which repeated OOM kills point at a memory leak
+	oomLeakRestarts = 10
+)
+
+// oomTools returns the out-of-memory diagnosis tool definitions
+func oomTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "diagnose_oom_killed_pods",
+			Description: "Find containers in a namespace that were last stopped by the out-of-memory killer, with their memory request and limit, restarts and when the kill happened, and suggest a fix",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to diagnose (defaults to default)",
+					},
+				},
+			},
+		},
+	}
+}
+
+// oomKilledContainer is one container whose last termination was an OOM kill
+type oomKilledContainer struct {
+	PodName         string   `json:"pod_name"`
+	ContainerName   string   `json:"container_name"`
+	Workload        string   `json:"workload"`
+	MemoryLimit     string   `json:"memory_limit"`
+	MemoryRequested string   `json:"memory_requested"`
+	LastExitCode    int32    `json:"last_exit_code"`
+	RestartCount    int32    `json:"restart_count"`
+	OOMTimestamp    string   `json:"oom_timestamp"`
+	Suggestions     []string `json:"suggestions"`
+
+	killedAt time.Time
+}
+
+func (s *Server) diagnoseOOMKilledPodsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "default")
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	killed := []*oomKilledContainer{}
+	for _, pod := range pods.Items {
+		containers := map[string]corev1.Container{}
+		for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			containers[container.Name] = container
+		}
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			// A container that is not restarted, e.g. under restartPolicy Never, keeps its OOM kill as its current state
+			terminated := status.LastTerminationState.Terminated
+			if current := status.State.Terminated; current != nil && current.Reason == oomKilledReason {
+				terminated = current
+			}
+			if terminated == nil || terminated.Reason != oomKilledReason {
+				continue
+			}
+			killed = append(killed, oomDiagnosis(pod, containers[status.Name], status, terminated))
+		}
+	}
+	// Most recent kills first
+	sort.Slice(killed, func(i, j int) bool {
+		if !killed[i].killedAt.Equal(killed[j].killedAt) {
+			return killed[i].killedAt.After(killed[j].killedAt)
+		}
+		return killed[i].PodName+"/"+killed[i].ContainerName < killed[j].PodName+"/"+killed[j].ContainerName
+	})
+
+	result := map[string]interface{}{
+		"namespace":        namespace,
+		"oom_killed_count": len(killed),
+		"oom_killed_pods":  killed,
+	}
+	if len(killed) == 0 {
+		result["message"] = fmt.Sprintf("No container in namespace %s was last terminated by the OOM killer", namespace)
+	}
+	return jsonResult(result)
+}
+
+// oomDiagnosis describes an OOM-killed container and suggests how to stop it recurring
+func oomDiagnosis(pod corev1.Pod, container corev1.Container, status corev1.ContainerStatus, terminated *corev1.ContainerStateTerminated) *oomKilledContainer {
+	diagnosis := &oomKilledContainer{
+		PodName:         pod.Name,
+		ContainerName:   status.Name,
+		Workload:        podWorkload(pod),
+		MemoryLimit:     "none",
+		MemoryRequested: "none",
+		LastExitCode:    terminated.ExitCode,
+		RestartCount:    status.RestartCount,
+		OOMTimestamp:    terminated.FinishedAt.UTC().Format(time.RFC3339),
+		killedAt:        terminated.FinishedAt.Time,
+	}
+	limit, hasLimit := container.Resources.Limits[corev1.ResourceMemory]
+	request, hasRequest := container.Resources.Requests[corev1.ResourceMemory]
+	if hasLimit {
+		diagnosis.MemoryLimit = limit.String()
+	}
+	if hasRequest {
+		diagnosis.MemoryRequested = request.String()
+	}
+
+	switch {
+	case !hasLimit:
+		// Without a limit the kernel only kills the container when the node itself runs out of memory
+		diagnosis.Suggestions = append(diagnosis.Suggestions, fmt.Sprintf("Container %s has no memory limit, so it was killed because node %s ran out of memory; set a memory request matching its real usage so the scheduler reserves it", status.Name, pod.Spec.NodeName))
+	case hasRequest && limit.Cmp(doubledQuantity(request)) < 0:
+		doubled := doubledQuantity(request)
+		suggestion := fmt.Sprintf("The memory limit %s leaves little headroom over the %s request; raise it, e.g. to %s", limit.String(), request.String(), doubled.String())
+		if workload := workloadResource(pod); workload != "" {
+			suggestion += fmt.Sprintf(" with kubectl set resources %s -c %s --limits=memory=%s", workload, status.Name, doubled.String())
+		}
+		diagnosis.Suggestions = append(diagnosis.Suggestions, suggestion)
+	default:
+		diagnosis.Suggestions = append(diagnosis.Suggestions, fmt.Sprintf("The memory limit %s is already generous; compare it with the container's peak usage before raising it further", limit.String()))
+	}
+	if status.RestartCount > oomLeakRestarts {
+		diagnosis.Suggestions = append(diagnosis.Suggestions, fmt.Sprintf("The container has restarted %d times; memory that keeps growing until each kill usually means a leak, so review the application's memory use, e.g. with a heap profile", status.RestartCount))
+	}
+	return diagnosis
+}
+
+// doubledQuantity returns twice a quantity in the same format
+func doubledQuantity(quantity resource.Quantity) resource.Quantity {
+	doubled := quantity.DeepCopy()
+	doubled.Add(quantity)
+	return doubled
+}
+
+// workloadResource names the kubectl resource whose pod template sets a pod's resources, or returns an empty string
+// when kubectl set resources cannot change them, as for bare pods and Jobs
+func workloadResource(pod corev1.Pod) string {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return ""
+	}
+	switch owner.Kind {
+	case "ReplicaSet":
+		if pod.Labels["pod-template-hash"] != "" {
+			return "deployment/" + podWorkload(pod)
+		}
+		return "replicaset/" + owner.Name
+	case "StatefulSet", "DaemonSet":
+		return strings.ToLower(owner.Kind) + "/" + owner.Name
+	}
+	return ""
+}
@@ -0,0 +1,241 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+)
+
+// buildOpenAPISpec generates an OpenAPI 3.0 document describing the MCP endpoint and every tool
+func buildOpenAPISpec(tools []mcp.Tool, serverName, serverVersion string) ([]byte, error) {
+	schemas := map[string]interface{}{
+		"Message": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"type":      map[string]interface{}{"type": "string"},
+				"id":        map[string]interface{}{"type": "string"},
+				"timestamp": map[string]interface{}{"type": "string", "format": "date-time"},
+				"data":      map[string]interface{}{"type": "object"},
+			},
+			"required": []string{"type"},
+		},
+		"InitializeRequest": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"protocolVersion": map[string]interface{}{"type": "string"},
+				"capabilities":    map[string]interface{}{"$ref": "#/components/schemas/Capabilities"},
+				"clientInfo":      map[string]interface{}{"$ref": "#/components/schemas/Info"},
+			},
+		},
+		"InitializationResponse": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"protocolVersion": map[string]interface{}{"type": "string"},
+				"capabilities":    map[string]interface{}{"$ref": "#/components/schemas/Capabilities"},
+				"serverInfo":      map[string]interface{}{"$ref": "#/components/schemas/Info"},
+			},
+		},
+		"Capabilities": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"resources": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"subscribe": map[string]interface{}{"type": "boolean"},
+					},
+				},
+				"tools": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"call": map[string]interface{}{"type": "boolean"},
+					},
+				},
+			},
+		},
+		"Info": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name":    map[string]interface{}{"type": "string"},
+				"version": map[string]interface{}{"type": "string"},
+			},
+		},
+		"ToolResult": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"content": map[string]interface{}{
+					"type": "array",
+					"items": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"type": map[string]interface{}{"type": "string"},
+							"text": map[string]interface{}{"type": "string"},
+						},
+					},
+				},
+				"isError":            map[string]interface{}{"type": "boolean"},
+				"recoverySuggestion": map[string]interface{}{"type": "string"},
+			},
+		},
+		"Error": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"type":    map[string]interface{}{"type": "string"},
+				"message": map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+
+	paths := map[string]interface{}{
+		"/mcp": map[string]interface{}{
+			"post": map[string]interface{}{
+				"operationId": "mcp",
+				"summary":     "Send an MCP protocol message",
+				"requestBody": jsonBody("#/components/schemas/Message"),
+				"responses":   jsonResponses("#/components/schemas/Message", "#/components/schemas/Error"),
+			},
+		},
+		"/mcp/events": map[string]interface{}{
+			"get": map[string]interface{}{
+				"operationId": "mcpEvents",
+				"summary":     "Stream MCP messages as server-sent events",
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": "Event stream; each data line is a JSON Message",
+						"content": map[string]interface{}{
+							"text/event-stream": map[string]interface{}{
+								"schema": map[string]interface{}{"type": "string"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tool := range tools {
+		inputName := toolSchemaName(tool.Name) + "Input"
+		schemas[inputName] = tool.InputSchema
+		paths["/tools/"+tool.Name] = map[string]interface{}{
+			"post": map[string]interface{}{
+				"operationId": tool.Name,
+				"summary":     tool.Description,
+				"tags":        []string{"tools"},
+				"requestBody": jsonBody("#/components/schemas/" + inputName),
+				"responses":   jsonResponses("#/components/schemas/ToolResult", "#/components/schemas/ToolResult"),
+			},
+		}
+	}
+
+	spec := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       serverName,
+			"version":     serverVersion,
+			"description": fmt.Sprintf("MCP protocol %s server. Tools can be called through /mcp or directly through /tools/{name}.", mcp.ProtocolVersion),
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+
+	return json.MarshalIndent(spec, "", "  ")
+}
+
+// jsonBody builds a required JSON request body referencing a schema
+func jsonBody(ref string) map[string]interface{} {
+	return map[string]interface{}{
+		"required": true,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": map[string]interface{}{"$ref": ref},
+			},
+		},
+	}
+}
+
+// jsonResponses builds the success response with schema ref and the error response with schema errorRef
+func jsonResponses(ref, errorRef string) map[string]interface{} {
+	return map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": "Success",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": map[string]interface{}{"$ref": ref},
+				},
+			},
+		},
+		"400": map[string]interface{}{
+			"description": "Invalid request or tool failure",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": map[string]interface{}{"$ref": errorRef},
+				},
+			},
+		},
+	}
+}
+
+// toolSchemaName converts a snake_case tool name to a PascalCase schema name
+func toolSchemaName(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// handleOpenAPI serves the generated OpenAPI document
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(s.openAPISpec)
+}
+
+// handleToolREST executes a tool directly, taking its arguments as the request body
+func (s *Server) handleToolREST(w http.ResponseWriter, r *http.Request) {
+	s.inFlight.Add(1)
+	s.activeCount.Add(1)
+	defer func() {
+		s.activeCount.Add(-1)
+		s.inFlight.Done()
+	}()
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/tools/")
+	args := map[string]interface{}{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	result, err := s.callTool(name, args)
+	if err != nil {
+		s.logger.Errorf("Error calling tool %s: %v", name, err)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(mcp.NewError("error", err.Error(), nil))
+		return
+	}
+
+	if result.IsError {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	json.NewEncoder(w).Encode(result)
+}
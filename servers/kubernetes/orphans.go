@@ -0,0 +1,483 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// zeroReplicaOrphanAge is how long a deployment must have been scaled to zero to count as orphaned
+const zeroReplicaOrphanAge = 7 * 24 * time.Hour
+
+// Orphaned resource types
+const (
+	orphanConfigMap        = "configmap"
+	orphanService          = "service"
+	orphanPVC              = "persistentvolumeclaim"
+	orphanPersistentVolume = "persistentvolume"
+	orphanDeployment       = "deployment"
+)
+
+// autoCreatedConfigMaps are created in every namespace by the control plane and never count as orphaned
+var autoCreatedConfigMaps = map[string]bool{
+	"kube-root-ca.crt": true,
+}
+
+// orphanTools returns the orphaned resource tool definitions
+func orphanTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "list_orphaned_resources",
+			Description: "Find resources in a namespace that nothing uses: ConfigMaps no pod or workload references, Services whose selector matches no pods or workload, released PersistentVolumes and lost PVCs, and Deployments scaled to zero for over 7 days",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to inspect",
+					},
+				},
+				"required": []string{"namespace"},
+			},
+		},
+		{
+			Name:        "cleanup_orphaned_resources",
+			Description: "Delete the named orphaned resources found by list_orphaned_resources; each is checked again before deletion so only resources still orphaned are removed. Released PersistentVolumes are cluster-scoped and never deleted here",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to clean up",
+					},
+					"resources": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Orphans to delete as type/name, e.g. configmap/old-settings",
+					},
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "List the resources that would be deleted without deleting them",
+					},
+				},
+				"required": []string{"namespace", "resources"},
+			},
+		},
+	}
+}
+
+// orphanedResource is a resource nothing in its namespace appears to use
+type orphanedResource struct {
+	Type            string `json:"type"`
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace,omitempty"`
+	ReasonForOrphan string `json:"reason_for_orphan"`
+}
+
+// key identifies the orphan as type/name
+func (o orphanedResource) key() string {
+	return o.Type + "/" + o.Name
+}
+
+func (s *Server) listOrphanedResourcesTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+
+	orphans, err := s.findOrphanedResources(context.Background(), namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := map[string][]orphanedResource{}
+	for _, orphan := range orphans {
+		categories[orphan.Type] = append(categories[orphan.Type], orphan)
+	}
+	return jsonResult(map[string]interface{}{
+		"namespace":  namespace,
+		"count":      len(orphans),
+		"categories": categories,
+	})
+}
+
+func (s *Server) cleanupOrphanedResourcesTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	// The heuristics can flag resources still in use, so only the ones named after review are deleted
+	requested, err := stringSliceArg(args, "resources")
+	if err != nil {
+		return nil, err
+	}
+	if len(requested) == 0 {
+		return nil, fmt.Errorf("resources must name at least one orphan as type/name; list them with list_orphaned_resources")
+	}
+	dryRun := boolArg(args, "dry_run")
+	ctx := context.Background()
+
+	// Orphans are found again here, so a resource that came into use since it was listed is kept
+	orphans, err := s.findOrphanedResources(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]orphanedResource, len(orphans))
+	for _, orphan := range orphans {
+		found[orphan.key()] = orphan
+	}
+
+	var selected []orphanedResource
+	skipped := []skippedResource{}
+	for _, key := range requested {
+		key = strings.ToLower(key)
+		orphan, ok := found[key]
+		switch {
+		case !ok:
+			skipped = append(skipped, skippedResource{Resource: key, Reason: "not orphaned"})
+		case orphan.Type == orphanPersistentVolume:
+			// A volume may hold the only copy of its data and does not belong to the namespace being cleaned up
+			skipped = append(skipped, skippedResource{Resource: key, Reason: "PersistentVolumes are cluster-scoped; check its data and delete it with kubectl delete pv " + orphan.Name})
+		default:
+			selected = append(selected, orphan)
+		}
+	}
+
+	deleted := []orphanedResource{}
+	for _, orphan := range selected {
+		if !dryRun {
+			if err := s.deleteOrphan(ctx, orphan); err != nil {
+				skipped = append(skipped, skippedResource{Resource: orphan.key(), Reason: fmt.Sprintf("delete failed: %v", err)})
+				continue
+			}
+		}
+		deleted = append(deleted, orphan)
+	}
+
+	result := map[string]interface{}{
+		"namespace": namespace,
+		"dry_run":   dryRun,
+		"skipped":   skipped,
+	}
+	if dryRun {
+		result["would_delete"] = deleted
+	} else {
+		result["deleted"] = deleted
+	}
+	return jsonResult(result)
+}
+
+// skippedResource records a requested resource that was not deleted
+type skippedResource struct {
+	Resource string `json:"resource"`
+	Reason   string `json:"reason"`
+}
+
+// deleteOrphan deletes an orphaned resource
+func (s *Server) deleteOrphan(ctx context.Context, orphan orphanedResource) error {
+	options := metav1.DeleteOptions{}
+	switch orphan.Type {
+	case orphanConfigMap:
+		return s.clientset.CoreV1().ConfigMaps(orphan.Namespace).Delete(ctx, orphan.Name, options)
+	case orphanService:
+		return s.clientset.CoreV1().Services(orphan.Namespace).Delete(ctx, orphan.Name, options)
+	case orphanPVC:
+		return s.clientset.CoreV1().PersistentVolumeClaims(orphan.Namespace).Delete(ctx, orphan.Name, options)
+	case orphanDeployment:
+		return s.clientset.AppsV1().Deployments(orphan.Namespace).Delete(ctx, orphan.Name, options)
+	}
+	return fmt.Errorf("unknown orphan type %s", orphan.Type)
+}
+
+// findOrphanedResources returns the orphans of a namespace, sorted by type and name
+func (s *Server) findOrphanedResources(ctx context.Context, namespace string) ([]orphanedResource, error) {
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	deployments, err := s.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	templates, err := s.podTemplates(ctx, namespace, deployments.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	orphans, err := s.orphanedConfigMaps(ctx, namespace, pods.Items, templates)
+	if err != nil {
+		return nil, err
+	}
+	services, err := s.orphanedServices(ctx, namespace, pods.Items, templates)
+	if err != nil {
+		return nil, err
+	}
+	volumes, err := s.orphanedVolumes(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	orphans = append(orphans, services...)
+	orphans = append(orphans, volumes...)
+	orphans = append(orphans, idleDeployments(deployments.Items, time.Now())...)
+
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].Type != orphans[j].Type {
+			return orphans[i].Type < orphans[j].Type
+		}
+		return orphans[i].Name < orphans[j].Name
+	})
+	return orphans, nil
+}
+
+// podTemplates returns the pod templates of the namespace's workloads, so resources used by a workload with no
+// running pods, such as a deployment scaled to zero or a Job between runs, are not taken for orphans
+func (s *Server) podTemplates(ctx context.Context, namespace string, deployments []appsv1.Deployment) ([]corev1.PodTemplateSpec, error) {
+	templates := make([]corev1.PodTemplateSpec, 0, len(deployments))
+	for _, deployment := range deployments {
+		templates = append(templates, deployment.Spec.Template)
+	}
+	statefulSets, err := s.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		templates = append(templates, statefulSet.Spec.Template)
+	}
+	daemonSets, err := s.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, daemonSet := range daemonSets.Items {
+		templates = append(templates, daemonSet.Spec.Template)
+	}
+	replicaSets, err := s.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	for _, replicaSet := range replicaSets.Items {
+		templates = append(templates, replicaSet.Spec.Template)
+	}
+	jobs, err := s.clientset.BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, job := range jobs.Items {
+		templates = append(templates, job.Spec.Template)
+	}
+	cronJobs, err := s.clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	for _, cronJob := range cronJobs.Items {
+		templates = append(templates, cronJob.Spec.JobTemplate.Spec.Template)
+	}
+	return templates, nil
+}
+
+// orphanedConfigMaps returns the ConfigMaps no pod, and no pod template of a workload, references through env, envFrom or a volume.
+// ConfigMaps owned by another object are managed by its controller and never count as orphaned.
+func (s *Server) orphanedConfigMaps(ctx context.Context, namespace string, pods []corev1.Pod, templates []corev1.PodTemplateSpec) ([]orphanedResource, error) {
+	configMaps, err := s.clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps: %w", err)
+	}
+
+	specs := make([]corev1.PodSpec, 0, len(pods)+len(templates))
+	for _, pod := range pods {
+		specs = append(specs, pod.Spec)
+	}
+	for _, template := range templates {
+		specs = append(specs, template.Spec)
+	}
+
+	referenced := map[string]bool{}
+	for _, spec := range specs {
+		for name := range configMapReferences(spec) {
+			referenced[name] = true
+		}
+	}
+
+	var orphans []orphanedResource
+	for _, configMap := range configMaps.Items {
+		if referenced[configMap.Name] || autoCreatedConfigMaps[configMap.Name] || len(configMap.OwnerReferences) > 0 {
+			continue
+		}
+		orphans = append(orphans, orphanedResource{
+			Type:            orphanConfigMap,
+			Name:            configMap.Name,
+			Namespace:       namespace,
+			ReasonForOrphan: "not referenced by any pod or workload env, envFrom or volume",
+		})
+	}
+	return orphans, nil
+}
+
+// configMapReferences returns the names of the ConfigMaps a pod spec uses
+func configMapReferences(spec corev1.PodSpec) map[string]bool {
+	names := map[string]bool{}
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, env := range container.Env {
+			if env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil {
+				names[env.ValueFrom.ConfigMapKeyRef.Name] = true
+			}
+		}
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				names[envFrom.ConfigMapRef.Name] = true
+			}
+		}
+	}
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			names[volume.ConfigMap.Name] = true
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					names[source.ConfigMap.Name] = true
+				}
+			}
+		}
+	}
+	return names
+}
+
+// orphanedServices returns the Services whose selector matches no pods and no workload pod template; Services without a selector
+// manage their own endpoints and are skipped
+func (s *Server) orphanedServices(ctx context.Context, namespace string, pods []corev1.Pod, templates []corev1.PodTemplateSpec) ([]orphanedResource, error) {
+	services, err := s.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	var orphans []orphanedResource
+	for _, service := range services.Items {
+		if len(service.Spec.Selector) == 0 {
+			continue
+		}
+		selector := labels.SelectorFromSet(service.Spec.Selector)
+		matched := false
+		for _, pod := range pods {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				matched = true
+				break
+			}
+		}
+		// A workload with no pods right now, e.g. scaled to zero, still backs the Service
+		for _, template := range templates {
+			if matched {
+				break
+			}
+			matched = selector.Matches(labels.Set(template.Labels))
+		}
+		if matched {
+			continue
+		}
+		orphans = append(orphans, orphanedResource{
+			Type:            orphanService,
+			Name:            service.Name,
+			Namespace:       namespace,
+			ReasonForOrphan: fmt.Sprintf("selector %s matches no pods or workload pod templates", selector.String()),
+		})
+	}
+	return orphans, nil
+}
+
+// orphanedVolumes returns the PVCs whose volume is lost and the PersistentVolumes left Released by a deleted claim from the namespace;
+// Released is a volume phase, so volumes are reported alongside the claims
+func (s *Server) orphanedVolumes(ctx context.Context, namespace string) ([]orphanedResource, error) {
+	claims, err := s.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistentvolumeclaims: %w", err)
+	}
+
+	var orphans []orphanedResource
+	for _, claim := range claims.Items {
+		if claim.Status.Phase != corev1.ClaimLost {
+			continue
+		}
+		orphans = append(orphans, orphanedResource{
+			Type:            orphanPVC,
+			Name:            claim.Name,
+			Namespace:       namespace,
+			ReasonForOrphan: fmt.Sprintf("phase Lost: volume %s no longer exists", claim.Spec.VolumeName),
+		})
+	}
+
+	volumes, err := s.clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		// Listing volumes needs cluster-wide access; without it only the claims are checked
+		s.logger.Debugf("Skipping released volumes of %s: %v", namespace, err)
+		return orphans, nil
+	}
+	for _, volume := range volumes.Items {
+		ref := volume.Spec.ClaimRef
+		if volume.Status.Phase != corev1.VolumeReleased || ref == nil || ref.Namespace != namespace {
+			continue
+		}
+		orphans = append(orphans, orphanedResource{
+			Type:            orphanPersistentVolume,
+			Name:            volume.Name,
+			ReasonForOrphan: fmt.Sprintf("phase Released: claim %s was deleted and reclaim policy %s keeps the volume", ref.Name, volume.Spec.PersistentVolumeReclaimPolicy),
+		})
+	}
+	return orphans, nil
+}
+
+// idleDeployments returns the deployments scaled to zero for longer than zeroReplicaOrphanAge
+func idleDeployments(deployments []appsv1.Deployment, now time.Time) []orphanedResource {
+	var orphans []orphanedResource
+	for _, deployment := range deployments {
+		if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+			continue
+		}
+		since := scaledToZeroSince(deployment)
+		if now.Sub(since) < zeroReplicaOrphanAge {
+			continue
+		}
+		orphans = append(orphans, orphanedResource{
+			Type:            orphanDeployment,
+			Name:            deployment.Name,
+			Namespace:       deployment.Namespace,
+			ReasonForOrphan: fmt.Sprintf("scaled to 0 replicas for %d days", int(now.Sub(since).Hours()/24)),
+		})
+	}
+	return orphans
+}
+
+// scaledToZeroSince estimates when a deployment was scaled to zero: the last write to spec.replicas recorded in its managed fields,
+// else its last progress update, else its creation
+func scaledToZeroSince(deployment appsv1.Deployment) time.Time {
+	var since time.Time
+	for _, entry := range deployment.ManagedFields {
+		if entry.Time == nil || entry.FieldsV1 == nil || (entry.Subresource != "" && entry.Subresource != "scale") {
+			continue
+		}
+		if strings.Contains(string(entry.FieldsV1.Raw), `"f:replicas"`) && entry.Time.After(since) {
+			since = entry.Time.Time
+		}
+	}
+	if !since.IsZero() {
+		return since
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.LastUpdateTime.After(since) {
+			since = condition.LastUpdateTime.Time
+		}
+	}
+	if !since.IsZero() {
+		return since
+	}
+	return deployment.CreationTimestamp.Time
+}
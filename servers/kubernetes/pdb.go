@@ -0,0 +1,131 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// pdbTools returns the PodDisruptionBudget tool definitions
+func pdbTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "list_pod_disruptions",
+			Description: "List the PodDisruptionBudgets of a namespace with their limits, healthy pod counts, allowed disruptions and the live pods they cover, highlighting budgets that allow no disruption and so block node drains",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to list PodDisruptionBudgets in (defaults to default)",
+					},
+				},
+			},
+		},
+	}
+}
+
+// pdbStatus is the state of one PodDisruptionBudget
+type pdbStatus struct {
+	Name               string   `json:"name"`
+	MinAvailable       string   `json:"min_available,omitempty"`
+	MaxUnavailable     string   `json:"max_unavailable,omitempty"`
+	CurrentHealthy     int32    `json:"current_healthy"`
+	DesiredHealthy     int32    `json:"desired_healthy"`
+	ExpectedPods       int32    `json:"expected_pods"`
+	DisruptionsAllowed int32    `json:"disruptions_allowed"`
+	PodsCovered        int      `json:"pods_covered"`
+	Pods               []string `json:"pods"`
+	BlocksDrains       bool     `json:"blocks_drains"`
+	Reason             string   `json:"reason,omitempty"`
+}
+
+func (s *Server) listPodDisruptionsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "default")
+	ctx := context.Background()
+
+	pdbs, err := s.clientset.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PodDisruptionBudgets: %w", err)
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	budgets := make([]pdbStatus, 0, len(pdbs.Items))
+	blocking := []string{}
+	for _, pdb := range pdbs.Items {
+		status := pdbStatus{
+			Name:               pdb.Name,
+			CurrentHealthy:     pdb.Status.CurrentHealthy,
+			DesiredHealthy:     pdb.Status.DesiredHealthy,
+			ExpectedPods:       pdb.Status.ExpectedPods,
+			DisruptionsAllowed: pdb.Status.DisruptionsAllowed,
+			Pods:               []string{},
+		}
+		if pdb.Spec.MinAvailable != nil {
+			status.MinAvailable = pdb.Spec.MinAvailable.String()
+		}
+		if pdb.Spec.MaxUnavailable != nil {
+			status.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+		}
+
+		// In policy/v1 an empty selector covers every pod in the namespace and a missing one covers none
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			status.Reason = fmt.Sprintf("invalid selector: %v", err)
+		} else {
+			for _, pod := range pods.Items {
+				if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+					continue
+				}
+				if selector.Matches(labels.Set(pod.Labels)) {
+					status.Pods = append(status.Pods, pod.Name)
+				}
+			}
+			sort.Strings(status.Pods)
+		}
+		status.PodsCovered = len(status.Pods)
+
+		if status.DisruptionsAllowed == 0 && status.PodsCovered > 0 {
+			status.BlocksDrains = true
+			status.Reason = pdbBlockReason(pdb)
+			blocking = append(blocking, pdb.Name)
+		}
+		budgets = append(budgets, status)
+	}
+	sort.Slice(budgets, func(i, j int) bool { return budgets[i].Name < budgets[j].Name })
+	sort.Strings(blocking)
+
+	result := map[string]interface{}{
+		"namespace":              namespace,
+		"pod_disruption_budgets": budgets,
+		"blocking_drains":        blocking,
+		"drains_blocked":         len(blocking) > 0,
+	}
+	if len(blocking) > 0 {
+		result["warning"] = fmt.Sprintf("%d PodDisruptionBudget(s) allow no disruption, so draining a node running their pods will wait until they allow one: %v", len(blocking), blocking)
+	}
+	return jsonResult(result)
+}
+
+// pdbBlockReason explains why a budget allows no disruption
+func pdbBlockReason(pdb policyv1.PodDisruptionBudget) string {
+	switch {
+	case pdb.Status.CurrentHealthy < pdb.Status.DesiredHealthy:
+		return fmt.Sprintf("only %d of the %d required healthy pods are healthy; fix the unhealthy pods first", pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy)
+	case pdb.Spec.MaxUnavailable != nil && (pdb.Spec.MaxUnavailable.String() == "0" || pdb.Spec.MaxUnavailable.String() == "0%"):
+		return "maxUnavailable is 0, so the budget never allows an eviction"
+	case pdb.Status.DesiredHealthy >= pdb.Status.ExpectedPods:
+		return fmt.Sprintf("minAvailable requires all %d pods to stay healthy; add replicas or lower minAvailable", pdb.Status.ExpectedPods)
+	default:
+		return "the disruption controller has not yet allowed a disruption"
+	}
+}
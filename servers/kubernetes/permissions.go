@@ -0,0 +1,96 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// permissionTools returns the RBAC pre-flight tool definitions
+func permissionTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "verify_permissions",
+			Description: "Check whether the current kubeconfig user or service account may perform a verb on a resource before attempting it",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"verb": map[string]interface{}{
+						"type":        "string",
+						"description": "API verb such as get, list, create, update, patch or delete",
+					},
+					"resource": map[string]interface{}{
+						"type":        "string",
+						"description": "Resource name such as deployments, optionally with a subresource (deployments/scale)",
+					},
+					"group": map[string]interface{}{
+						"type":        "string",
+						"description": "API group of the resource (empty for the core group)",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the operation (omit for cluster-scoped or all-namespace checks)",
+					},
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of a specific object (optional)",
+					},
+				},
+				"required": []string{"verb", "resource"},
+			},
+		},
+	}
+}
+
+func (s *Server) verifyPermissionsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	verb, err := stringArg(args, "verb")
+	if err != nil {
+		return nil, err
+	}
+	resource, err := stringArg(args, "resource")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "")
+
+	attributes := &authorizationv1.ResourceAttributes{
+		Verb:      verb,
+		Group:     optionalStringArg(args, "group", ""),
+		Resource:  resource,
+		Namespace: namespace,
+		Name:      optionalStringArg(args, "name", ""),
+	}
+	if base, sub, ok := strings.Cut(resource, "/"); ok {
+		attributes.Resource, attributes.Subresource = base, sub
+	}
+
+	review, err := s.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(context.Background(), &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attributes},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{
+		"allowed":   review.Status.Allowed,
+		"verb":      verb,
+		"resource":  resource,
+		"namespace": namespace,
+	}
+	if review.Status.Reason != "" {
+		result["reason"] = review.Status.Reason
+	}
+	if !review.Status.Allowed {
+		scope := "cluster-wide"
+		if namespace != "" {
+			scope = "in namespace " + namespace
+		}
+		result["guidance"] = fmt.Sprintf("You are not allowed to %s %s %s. Ask a cluster administrator for a Role or ClusterRole granting %q on %q and a binding to your user or service account.", verb, resource, scope, verb, resource)
+	}
+
+	return jsonResult(result)
+}
@@ -0,0 +1,83 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ToolPlugin is a custom tool compiled into the server by an operator
+type ToolPlugin interface {
+	// Name is the tool name clients call; it must not clash with a built-in tool
+	Name() string
+	// Description explains the tool to clients and LLMs
+	Description() string
+	// InputSchema is the JSON schema of the tool arguments
+	InputSchema() map[string]interface{}
+	// Execute runs the tool
+	Execute(ctx context.Context, args map[string]interface{}) (*mcp.ToolResult, error)
+}
+
+// RegisterPlugin adds a plugin tool. Plugins must be registered before Start so they appear in the OpenAPI spec.
+func (s *Server) RegisterPlugin(p ToolPlugin) error {
+	name := p.Name()
+	if name == "" {
+		return fmt.Errorf("plugin name must not be empty")
+	}
+	for _, tool := range s.listTools() {
+		if tool.Name == name {
+			return fmt.Errorf("tool %s is already registered", name)
+		}
+	}
+
+	s.pluginsMu.Lock()
+	defer s.pluginsMu.Unlock()
+	s.plugins[name] = p
+	s.logger.Infof("Registered plugin tool %s", name)
+	return nil
+}
+
+// plugin returns the plugin registered under name
+func (s *Server) plugin(name string) (ToolPlugin, bool) {
+	s.pluginsMu.RLock()
+	defer s.pluginsMu.RUnlock()
+	p, ok := s.plugins[name]
+	return p, ok
+}
+
+// pluginTools returns the tool definitions of all plugins, sorted by name
+func (s *Server) pluginTools() []mcp.Tool {
+	s.pluginsMu.RLock()
+	defer s.pluginsMu.RUnlock()
+
+	tools := make([]mcp.Tool, 0, len(s.plugins))
+	for _, p := range s.plugins {
+		tools = append(tools, mcp.Tool{
+			Name:        p.Name(),
+			Description: p.Description(),
+			InputSchema: p.InputSchema(),
+		})
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools
+}
+
+// Clientset returns the Kubernetes client so plugins can be constructed with it
+func (s *Server) Clientset() kubernetes.Interface {
+	return s.clientset
+}
+
+// DynamicClient returns the dynamic client so plugins can be constructed with it
+func (s *Server) DynamicClient() dynamic.Interface {
+	return s.dynamicClient
+}
+
+// Discovery returns the discovery client so plugins can be constructed with it
+func (s *Server) Discovery() discovery.DiscoveryInterface {
+	return s.clientset.Discovery()
+}
@@ -0,0 +1,65 @@
+// Package example is a sample ToolPlugin for the Kubernetes MCP server that reports the cluster version.
+//
+// Register it before starting the server:
+//
+//	server.RegisterPlugin(example.NewClusterVersionPlugin(server.Discovery()))
+package example
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"k8s.io/client-go/discovery"
+)
+
+// ClusterVersionPlugin returns the API server version
+type ClusterVersionPlugin struct {
+	discovery discovery.ServerVersionInterface
+}
+
+// NewClusterVersionPlugin creates the plugin
+func NewClusterVersionPlugin(d discovery.ServerVersionInterface) *ClusterVersionPlugin {
+	return &ClusterVersionPlugin{discovery: d}
+}
+
+// Name returns the tool name
+func (p *ClusterVersionPlugin) Name() string {
+	return "example_cluster_version"
+}
+
+// Description describes the tool
+func (p *ClusterVersionPlugin) Description() string {
+	return "Example plugin: report the Kubernetes API server version"
+}
+
+// InputSchema returns the tool's argument schema; it takes no arguments
+func (p *ClusterVersionPlugin) InputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	}
+}
+
+// Execute fetches the server version
+func (p *ClusterVersionPlugin) Execute(ctx context.Context, args map[string]interface{}) (*mcp.ToolResult, error) {
+	version, err := p.discovery.ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server version: %w", err)
+	}
+
+	data, err := json.MarshalIndent(map[string]string{
+		"git_version": version.GitVersion,
+		"platform":    version.Platform,
+		"build_date":  version.BuildDate,
+		"go_version":  version.GoVersion,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal version: %w", err)
+	}
+
+	return &mcp.ToolResult{
+		Content: []mcp.ToolResultContent{{Type: "text", Text: string(data)}},
+	}, nil
+}
@@ -0,0 +1,238 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Pod Security Standards levels, from least to most restrictive
+const (
+	pssPrivileged = "privileged"
+	pssBaseline   = "baseline"
+	pssRestricted = "restricted"
+)
+
+// baselineCapabilities are the capabilities the baseline standard allows containers to add
+var baselineCapabilities = map[corev1.Capability]bool{
+	"AUDIT_WRITE": true, "CHOWN": true, "DAC_OVERRIDE": true, "FOWNER": true, "FSETID": true, "KILL": true, "MKNOD": true,
+	"NET_BIND_SERVICE": true, "SETFCAP": true, "SETGID": true, "SETPCAP": true, "SETUID": true, "SYS_CHROOT": true,
+}
+
+// podSecurityTools returns the pod security analysis tool definitions
+func podSecurityTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "analyze_pod_security",
+			Description: "Check a pod's security contexts against the Pod Security Standards (privileged, baseline, restricted) and suggest a fix for each violation",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the pod",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the pod (defaults to default)",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
+	}
+}
+
+// securityViolation is one failed Pod Security Standards check
+type securityViolation struct {
+	Standard  string `json:"standard"`
+	Container string `json:"container,omitempty"`
+	Check     string `json:"check"`
+	Message   string `json:"message"`
+	Fix       string `json:"fix"`
+
+	// KubeScore names the equivalent kube-score check, where there is one
+	KubeScore string `json:"kube_score,omitempty"`
+}
+
+// podSecurityReport is the analyze_pod_security result
+type podSecurityReport struct {
+	Pod        string              `json:"pod"`
+	Namespace  string              `json:"namespace"`
+	Compliant  string              `json:"compliant_with"`
+	Violations []securityViolation `json:"violations"`
+	Hints      []string            `json:"hints"`
+}
+
+func (s *Server) analyzePodSecurityTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+
+	pod, err := s.clientset.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	violations := checkPodSecurity(&pod.Spec)
+	report := podSecurityReport{
+		Pod:        name,
+		Namespace:  namespace,
+		Compliant:  pssRestricted,
+		Violations: violations,
+		Hints: []string{
+			fmt.Sprintf("Enforce a level for the whole namespace with: kubectl label namespace %s pod-security.kubernetes.io/enforce=<level>", namespace),
+			fmt.Sprintf("Find who can create pods that bypass these checks with: kubectl who-can create pods -n %s", namespace),
+			"Run kube-score on the owning workload's manifest to catch these checks before deploying",
+		},
+	}
+	for _, v := range violations {
+		if v.Standard == pssBaseline {
+			report.Compliant = pssPrivileged
+			break
+		}
+		report.Compliant = pssBaseline
+	}
+
+	return jsonResult(report)
+}
+
+// checkPodSecurity returns every baseline and restricted violation in a pod spec
+func checkPodSecurity(spec *corev1.PodSpec) []securityViolation {
+	violations := []securityViolation{}
+	add := func(standard, container, check, message, fix, kubeScore string) {
+		violations = append(violations, securityViolation{
+			Standard: standard, Container: container, Check: check, Message: message, Fix: fix, KubeScore: kubeScore,
+		})
+	}
+
+	if spec.HostNetwork {
+		add(pssBaseline, "", "hostNetwork", "hostNetwork: true shares the node's network namespace", "Remove hostNetwork from the pod spec", "")
+	}
+	if spec.HostPID {
+		add(pssBaseline, "", "hostPID", "hostPID: true shares the node's process namespace", "Remove hostPID from the pod spec", "")
+	}
+	if spec.HostIPC {
+		add(pssBaseline, "", "hostIPC", "hostIPC: true shares the node's IPC namespace", "Remove hostIPC from the pod spec", "")
+	}
+
+	for _, volume := range spec.Volumes {
+		if volume.HostPath != nil {
+			add(pssBaseline, "", "hostPath", fmt.Sprintf("volume %s mounts host path %s", volume.Name, volume.HostPath.Path),
+				"Replace the hostPath volume with an emptyDir, configMap or persistentVolumeClaim", "")
+		} else if !restrictedVolume(volume) {
+			add(pssRestricted, "", "volumeTypes", fmt.Sprintf("volume %s uses a type the restricted standard does not allow", volume.Name),
+				"Use configMap, csi, downwardAPI, emptyDir, ephemeral, persistentVolumeClaim, projected or secret volumes", "")
+		}
+	}
+
+	podContext := spec.SecurityContext
+	if podContext == nil {
+		podContext = &corev1.PodSecurityContext{}
+	}
+	if isUnconfinedSeccomp(podContext.SeccompProfile) {
+		add(pssBaseline, "", "seccompProfile", "the pod's seccompProfile is Unconfined", "Set securityContext.seccompProfile.type: RuntimeDefault on the pod", "")
+	}
+	if podContext.RunAsUser != nil && *podContext.RunAsUser == 0 {
+		add(pssRestricted, "", "runAsUser", "the pod sets runAsUser: 0 (root)", "Set securityContext.runAsUser to a non-zero UID such as 10001 on the pod", "container-security-context-user-group-id")
+	}
+
+	containers := append([]corev1.Container{}, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	for _, container := range containers {
+		checkContainerSecurity(container, podContext, add)
+	}
+
+	return violations
+}
+
+// checkContainerSecurity reports the violations of one container, taking pod-level defaults into account
+func checkContainerSecurity(container corev1.Container, podContext *corev1.PodSecurityContext, add func(standard, container, check, message, fix, kubeScore string)) {
+	name := container.Name
+	sc := container.SecurityContext
+	if sc == nil {
+		sc = &corev1.SecurityContext{}
+	}
+
+	if sc.Privileged != nil && *sc.Privileged {
+		add(pssBaseline, name, "privileged", "privileged: true gives the container full access to the node",
+			"Set securityContext.privileged: false and grant only the capabilities it needs", "container-security-context-privileged")
+	}
+	for _, port := range container.Ports {
+		if port.HostPort != 0 {
+			add(pssBaseline, name, "hostPorts", fmt.Sprintf("port %d is bound to host port %d", port.ContainerPort, port.HostPort),
+				"Remove hostPort and expose the container through a Service", "")
+		}
+	}
+	if sc.ProcMount != nil && *sc.ProcMount != corev1.DefaultProcMount {
+		add(pssBaseline, name, "procMount", fmt.Sprintf("procMount is %s", *sc.ProcMount), "Remove securityContext.procMount or set it to Default", "")
+	}
+	if isUnconfinedSeccomp(sc.SeccompProfile) {
+		add(pssBaseline, name, "seccompProfile", "seccompProfile is Unconfined", "Set securityContext.seccompProfile.type: RuntimeDefault", "")
+	}
+
+	dropsAll := false
+	if sc.Capabilities != nil {
+		for _, capability := range sc.Capabilities.Add {
+			if !baselineCapabilities[capability] {
+				add(pssBaseline, name, "capabilities", fmt.Sprintf("adds capability %s", capability),
+					fmt.Sprintf("Remove %s from securityContext.capabilities.add", capability), "")
+			} else if capability != "NET_BIND_SERVICE" {
+				add(pssRestricted, name, "capabilities", fmt.Sprintf("adds capability %s", capability),
+					fmt.Sprintf("Remove %s from securityContext.capabilities.add; only NET_BIND_SERVICE is allowed", capability), "")
+			}
+		}
+		for _, capability := range sc.Capabilities.Drop {
+			if capability == "ALL" {
+				dropsAll = true
+			}
+		}
+	}
+	if !dropsAll {
+		add(pssRestricted, name, "capabilities", "does not drop ALL capabilities", "Set securityContext.capabilities.drop: [\"ALL\"]", "")
+	}
+
+	if sc.AllowPrivilegeEscalation == nil || *sc.AllowPrivilegeEscalation {
+		add(pssRestricted, name, "allowPrivilegeEscalation", "allowPrivilegeEscalation is not false",
+			"Set securityContext.allowPrivilegeEscalation: false", "")
+	}
+
+	runAsNonRoot := podContext.RunAsNonRoot
+	if sc.RunAsNonRoot != nil {
+		runAsNonRoot = sc.RunAsNonRoot
+	}
+	if runAsNonRoot == nil || !*runAsNonRoot {
+		add(pssRestricted, name, "runAsNonRoot", "may run as root: runAsNonRoot is not true",
+			"Set securityContext.runAsNonRoot: true and run the image as a non-root user", "container-security-context-user-group-id")
+	}
+	if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+		add(pssRestricted, name, "runAsUser", "runAsUser: 0 runs the container as root",
+			"Set securityContext.runAsUser to a non-zero UID such as 10001", "container-security-context-user-group-id")
+	}
+
+	seccomp := podContext.SeccompProfile
+	if sc.SeccompProfile != nil {
+		seccomp = sc.SeccompProfile
+	}
+	if seccomp == nil {
+		add(pssRestricted, name, "seccompProfile", "missing seccompProfile",
+			"Set securityContext.seccompProfile.type: RuntimeDefault on the pod or container", "")
+	}
+}
+
+// isUnconfinedSeccomp reports whether a seccomp profile disables seccomp filtering
+func isUnconfinedSeccomp(profile *corev1.SeccompProfile) bool {
+	return profile != nil && profile.Type == corev1.SeccompProfileTypeUnconfined
+}
+
+// restrictedVolume reports whether a volume's type is allowed by the restricted standard
+func restrictedVolume(volume corev1.Volume) bool {
+	source := volume.VolumeSource
+	return source.ConfigMap != nil || source.CSI != nil || source.DownwardAPI != nil || source.EmptyDir != nil ||
+		source.Ephemeral != nil || source.PersistentVolumeClaim != nil || source.Projected != nil || source.Secret != nil
+}
@@ -0,0 +1,361 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// namespacePolicyConfigMap is the ConfigMap holding a namespace's policy; ConfigMap names cannot contain a slash,
+	// so the policy is found by this name and marked with namespacePolicyLabel
+	namespacePolicyConfigMap = "mcp-servers-namespace-policy"
+
+	// namespacePolicyLabel marks namespace policy ConfigMaps
+	namespacePolicyLabel = "mcp-servers.io/namespace-policy"
+
+	// namespacePolicyKey is the ConfigMap data key holding the policy as JSON
+	namespacePolicyKey = "policy.json"
+)
+
+// namespacePolicy is the governance policy deployments created or scaled by this server must satisfy
+type namespacePolicy struct {
+	MaxReplicasPerDeployment int32    `json:"max_replicas_per_deployment,omitempty"`
+	RequiredLabels           []string `json:"required_labels,omitempty"`
+	DisallowedImages         []string `json:"disallowed_images,omitempty"`
+	MaxPodCPU                string   `json:"max_pod_cpu,omitempty"`
+	MaxPodMemory             string   `json:"max_pod_memory,omitempty"`
+}
+
+// policyTools returns the namespace policy tool definitions
+func policyTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "apply_namespace_policy",
+			Description: "Set the governance policy of a namespace, enforced when deployments are created or scaled up: a replica cap, required labels, disallowed images and per-pod CPU and memory limits. An empty policy removes it",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace the policy governs",
+					},
+					"policy": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"max_replicas_per_deployment": map[string]interface{}{
+								"type":        "integer",
+								"description": "Most replicas any deployment may have",
+							},
+							"required_labels": map[string]interface{}{
+								"type":        "array",
+								"items":       map[string]interface{}{"type": "string"},
+								"description": "Label keys every deployment must set, e.g. [\"owner\"]",
+							},
+							"disallowed_images": map[string]interface{}{
+								"type":        "array",
+								"items":       map[string]interface{}{"type": "string"},
+								"description": "Image globs no container may use; * matches any run of characters, e.g. [\"*:latest\", \"docker.io/*\"]",
+							},
+							"max_pod_cpu": map[string]interface{}{
+								"type":        "string",
+								"description": "Largest CPU limit of a pod, e.g. 2 or 500m",
+							},
+							"max_pod_memory": map[string]interface{}{
+								"type":        "string",
+								"description": "Largest memory limit of a pod, e.g. 1Gi",
+							},
+						},
+					},
+				},
+				"required": []string{"namespace", "policy"},
+			},
+		},
+	}
+}
+
+func (s *Server) applyNamespacePolicyTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	rawPolicy, ok := args["policy"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("policy must be an object")
+	}
+	policy, err := parseNamespacePolicy(rawPolicy)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	configMaps := s.clientset.CoreV1().ConfigMaps(namespace)
+
+	if policy.empty() {
+		err := configMaps.Delete(ctx, namespacePolicyConfigMap, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return textResult(fmt.Sprintf("Removed the policy of namespace '%s'", namespace)), nil
+	}
+
+	data, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode policy: %w", err)
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespacePolicyConfigMap,
+			Namespace: namespace,
+			Labels:    map[string]string{namespacePolicyLabel: "true"},
+		},
+		Data: map[string]string{namespacePolicyKey: string(data)},
+	}
+
+	existing, err := configMaps.Get(ctx, namespacePolicyConfigMap, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		_, err = configMaps.Create(ctx, configMap, metav1.CreateOptions{})
+	case err == nil:
+		configMap.ResourceVersion = existing.ResourceVersion
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResult(map[string]interface{}{
+		"namespace":  namespace,
+		"config_map": namespacePolicyConfigMap,
+		"policy":     policy,
+	})
+}
+
+// parseNamespacePolicy decodes and checks a policy argument, rejecting unknown fields so typos do not silently disable a rule
+func parseNamespacePolicy(raw map[string]interface{}) (*namespacePolicy, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	policy := &namespacePolicy{}
+	if err := decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// validate checks the policy's rules
+func (p *namespacePolicy) validate() error {
+	if p.MaxReplicasPerDeployment < 0 {
+		return fmt.Errorf("max_replicas_per_deployment must not be negative")
+	}
+	for _, quantity := range []struct{ key, value string }{
+		{"max_pod_cpu", p.MaxPodCPU},
+		{"max_pod_memory", p.MaxPodMemory},
+	} {
+		if quantity.value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(quantity.value); err != nil {
+			return fmt.Errorf("%s: invalid quantity '%s': %w", quantity.key, quantity.value, err)
+		}
+	}
+	for _, label := range p.RequiredLabels {
+		if label == "" {
+			return fmt.Errorf("required_labels must not contain empty keys")
+		}
+	}
+	for _, pattern := range p.DisallowedImages {
+		if pattern == "" {
+			return fmt.Errorf("disallowed_images must not contain empty patterns")
+		}
+	}
+	return nil
+}
+
+// empty reports whether the policy sets no rule
+func (p *namespacePolicy) empty() bool {
+	return p.MaxReplicasPerDeployment == 0 && len(p.RequiredLabels) == 0 && len(p.DisallowedImages) == 0 &&
+		p.MaxPodCPU == "" && p.MaxPodMemory == ""
+}
+
+// namespacePolicy returns the policy of a namespace, or nil if it has none
+func (s *Server) namespacePolicy(ctx context.Context, namespace string) (*namespacePolicy, error) {
+	configMap, err := s.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, namespacePolicyConfigMap, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the policy of namespace %s: %w", namespace, err)
+	}
+
+	policy := &namespacePolicy{}
+	err = json.Unmarshal([]byte(configMap.Data[namespacePolicyKey]), policy)
+	if err == nil {
+		err = policy.validate()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("the policy of namespace %s in ConfigMap %s is invalid: %w", namespace, namespacePolicyConfigMap, err)
+	}
+	return policy, nil
+}
+
+// enforceNamespacePolicy returns an error listing every rule of the namespace's policy the deployment breaks
+func (s *Server) enforceNamespacePolicy(ctx context.Context, deployment *appsv1.Deployment) error {
+	policy, err := s.namespacePolicy(ctx, deployment.Namespace)
+	if err != nil || policy == nil {
+		return err
+	}
+	return policy.check(deployment)
+}
+
+// check returns an error listing every rule the deployment breaks
+func (p *namespacePolicy) check(deployment *appsv1.Deployment) error {
+	if violations := p.violations(deployment); len(violations) > 0 {
+		return fmt.Errorf("namespace policy of %s forbids deployment %s: %s", deployment.Namespace, deployment.Name, strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// violations describes each rule the deployment breaks
+func (p *namespacePolicy) violations(deployment *appsv1.Deployment) []string {
+	var violations []string
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if p.MaxReplicasPerDeployment > 0 && replicas > p.MaxReplicasPerDeployment {
+		violations = append(violations, fmt.Sprintf("%d replicas exceed the maximum of %d", replicas, p.MaxReplicasPerDeployment))
+	}
+
+	for _, label := range p.RequiredLabels {
+		if _, ok := deployment.Labels[label]; !ok {
+			violations = append(violations, fmt.Sprintf("missing required label %s", label))
+		}
+	}
+
+	podSpec := deployment.Spec.Template.Spec
+	for _, container := range append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...) {
+		for _, pattern := range p.DisallowedImages {
+			if imageMatches(pattern, container.Image) {
+				violations = append(violations, fmt.Sprintf("container %s uses image %s, which matches disallowed pattern %s", container.Name, container.Image, pattern))
+				break
+			}
+		}
+	}
+
+	for _, limit := range []struct {
+		name corev1.ResourceName
+		max  string
+	}{
+		{corev1.ResourceCPU, p.MaxPodCPU},
+		{corev1.ResourceMemory, p.MaxPodMemory},
+	} {
+		if limit.max == "" {
+			continue
+		}
+		maximum := resource.MustParse(limit.max)
+		total, unlimited := podLimit(podSpec, limit.name)
+		switch {
+		case len(unlimited) > 0:
+			violations = append(violations, fmt.Sprintf("containers %s set no %s limit, but pods may use at most %s", strings.Join(unlimited, ", "), limit.name, limit.max))
+		case total.Cmp(maximum) > 0:
+			violations = append(violations, fmt.Sprintf("pod %s limit %s exceeds the maximum of %s", limit.name, total.String(), limit.max))
+		}
+	}
+
+	return violations
+}
+
+// podLimit returns a pod's effective limit for a resource, the larger of its containers' sum and its largest init
+// container, as the scheduler computes it, along with the containers that set no limit
+func podLimit(podSpec corev1.PodSpec, name corev1.ResourceName) (resource.Quantity, []string) {
+	var total resource.Quantity
+	var unlimited []string
+	for _, container := range podSpec.Containers {
+		limit, ok := container.Resources.Limits[name]
+		if !ok {
+			unlimited = append(unlimited, container.Name)
+			continue
+		}
+		total.Add(limit)
+	}
+	for _, container := range podSpec.InitContainers {
+		limit, ok := container.Resources.Limits[name]
+		if !ok {
+			unlimited = append(unlimited, container.Name)
+			continue
+		}
+		if limit.Cmp(total) > 0 {
+			total = limit
+		}
+	}
+	return total, unlimited
+}
+
+// defaultLimits gives the containers of a pod that sets no CPU or memory limit an even share of the policy's per-pod
+// maximum, the way a LimitRange default would, and returns the resources it set limits for
+func (p *namespacePolicy) defaultLimits(podSpec *corev1.PodSpec) []string {
+	var defaulted []string
+	for _, limit := range []struct {
+		name corev1.ResourceName
+		max  string
+	}{
+		{corev1.ResourceCPU, p.MaxPodCPU},
+		{corev1.ResourceMemory, p.MaxPodMemory},
+	} {
+		if limit.max == "" || len(podSpec.Containers) == 0 {
+			continue
+		}
+		if _, unlimited := podLimit(*podSpec, limit.name); len(unlimited) != len(podSpec.Containers)+len(podSpec.InitContainers) {
+			// Containers that set some limits are left to the policy check
+			continue
+		}
+
+		maximum := resource.MustParse(limit.max)
+		count := int64(len(podSpec.Containers))
+		share := resource.NewMilliQuantity(maximum.MilliValue()/count, maximum.Format)
+		if limit.name == corev1.ResourceMemory {
+			share = resource.NewQuantity(maximum.Value()/count, maximum.Format)
+		}
+		for i := range podSpec.Containers {
+			container := &podSpec.Containers[i]
+			if container.Resources.Limits == nil {
+				container.Resources.Limits = corev1.ResourceList{}
+			}
+			container.Resources.Limits[limit.name] = share.DeepCopy()
+		}
+		defaulted = append(defaulted, fmt.Sprintf("%s %s", limit.name, limit.max))
+	}
+	return defaulted
+}
+
+// imageMatches reports whether an image matches a glob in which * matches any run of characters, including slashes.
+// An image without a tag or digest also matches as image:latest.
+func imageMatches(pattern, image string) bool {
+	expression := "^" + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*") + "$"
+	matcher, err := regexp.Compile(expression)
+	if err != nil {
+		return false
+	}
+	if matcher.MatchString(image) {
+		return true
+	}
+	_, _, tag, digest := splitImage(image)
+	return tag == "latest" && digest == "" && !strings.HasSuffix(image, ":latest") && matcher.MatchString(image+":latest")
+}
@@ -0,0 +1,242 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+)
+
+// defaultPrometheusRange is how far back query_prometheus looks when no start is given
+const defaultPrometheusRange = time.Hour
+
+// prometheusTools returns the Prometheus tool definitions
+func prometheusTools() []mcp.Tool {
+	endpointProperty := map[string]interface{}{
+		"type":        "string",
+		"description": "Prometheus base URL (defaults to the server's configured Prometheus endpoint)",
+	}
+
+	return []mcp.Tool{
+		{
+			Name:        "query_prometheus",
+			Description: "Run a PromQL range query against Prometheus and return the resulting time series",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"endpoint": endpointProperty,
+					"promql": map[string]interface{}{
+						"type":        "string",
+						"description": "PromQL expression, e.g. sum(rate(container_cpu_usage_seconds_total{pod=~\"nginx-.*\"}[5m]))",
+					},
+					"step": map[string]interface{}{
+						"type":        "string",
+						"description": "Resolution step as a duration such as 30s or 5m (defaults to 60s)",
+					},
+					"start": map[string]interface{}{
+						"type":        "string",
+						"description": "Range start as an RFC3339 timestamp (defaults to one hour before end)",
+					},
+					"end": map[string]interface{}{
+						"type":        "string",
+						"description": "Range end as an RFC3339 timestamp (defaults to now)",
+					},
+				},
+				"required": []string{"promql"},
+			},
+		},
+		{
+			Name:        "list_prometheus_metrics",
+			Description: "List the metric names known to Prometheus",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"endpoint": endpointProperty,
+				},
+			},
+		},
+	}
+}
+
+// prometheusSample is a single point of a simplified time series
+type prometheusSample struct {
+	Time  string    `json:"time"`
+	Value float64   `json:"value"`
+	at    time.Time `json:"-"`
+}
+
+// prometheusSeries is a simplified range query result
+type prometheusSeries struct {
+	Metric map[string]string  `json:"metric"`
+	Values []prometheusSample `json:"values"`
+}
+
+// prometheusEndpointArg returns the endpoint argument or the configured default
+func (s *Server) prometheusEndpointArg(args map[string]interface{}) (string, error) {
+	endpoint := optionalStringArg(args, "endpoint", s.prometheusEndpoint)
+	if endpoint == "" {
+		return "", fmt.Errorf("endpoint is required because no Prometheus endpoint is configured")
+	}
+	return strings.TrimSuffix(endpoint, "/"), nil
+}
+
+// prometheusGet calls a Prometheus HTTP API path and decodes the data field of a successful response
+func prometheusGet(endpoint, path string, params url.Values, data interface{}) error {
+	target := endpoint + path
+	if len(params) > 0 {
+		target += "?" + params.Encode()
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(target)
+	if err != nil {
+		return fmt.Errorf("failed to call Prometheus: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Status string          `json:"status"`
+		Data   json.RawMessage `json:"data"`
+		Error  string          `json:"error"`
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 32<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read Prometheus response: %w", err)
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return fmt.Errorf("Prometheus returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body[:min(len(body), 1024)])))
+	}
+	if envelope.Status != "success" {
+		return fmt.Errorf("Prometheus query failed: %s", envelope.Error)
+	}
+
+	if err := json.Unmarshal(envelope.Data, data); err != nil {
+		return fmt.Errorf("failed to decode Prometheus data: %w", err)
+	}
+	return nil
+}
+
+// prometheusQueryRange runs a range query and converts the result into simplified series, skipping malformed points
+func prometheusQueryRange(endpoint string, params url.Values) ([]prometheusSeries, error) {
+	var data struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][2]interface{}  `json:"values"`
+		} `json:"result"`
+	}
+	if err := prometheusGet(endpoint, "/api/v1/query_range", params, &data); err != nil {
+		return nil, err
+	}
+
+	series := make([]prometheusSeries, 0, len(data.Result))
+	for _, result := range data.Result {
+		samples := make([]prometheusSample, 0, len(result.Values))
+		for _, pair := range result.Values {
+			timestamp, ok := pair[0].(float64)
+			if !ok {
+				continue
+			}
+			raw, ok := pair[1].(string)
+			if !ok {
+				continue
+			}
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				continue
+			}
+			seconds := int64(timestamp)
+			nanos := int64((timestamp - float64(seconds)) * float64(time.Second))
+			at := time.Unix(seconds, nanos).UTC()
+			samples = append(samples, prometheusSample{
+				Time:  at.Format(time.RFC3339),
+				Value: value,
+				at:    at,
+			})
+		}
+		series = append(series, prometheusSeries{Metric: result.Metric, Values: samples})
+	}
+	return series, nil
+}
+
+// timeArg parses an optional RFC3339 timestamp argument
+func timeArg(args map[string]interface{}, key string, defaultValue time.Time) (time.Time, error) {
+	value := optionalStringArg(args, key, "")
+	if value == "" {
+		return defaultValue, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC3339 timestamp: %w", key, err)
+	}
+	return t, nil
+}
+
+func (s *Server) queryPrometheusTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	endpoint, err := s.prometheusEndpointArg(args)
+	if err != nil {
+		return nil, err
+	}
+	promql, err := stringArg(args, "promql")
+	if err != nil {
+		return nil, err
+	}
+
+	step, err := time.ParseDuration(optionalStringArg(args, "step", "60s"))
+	if err != nil || step <= 0 {
+		return nil, fmt.Errorf("step must be a positive duration such as 30s or 5m")
+	}
+	end, err := timeArg(args, "end", time.Now())
+	if err != nil {
+		return nil, err
+	}
+	start, err := timeArg(args, "start", end.Add(-defaultPrometheusRange))
+	if err != nil {
+		return nil, err
+	}
+	if !start.Before(end) {
+		return nil, fmt.Errorf("start must be before end")
+	}
+
+	params := url.Values{}
+	params.Set("query", promql)
+	params.Set("start", start.UTC().Format(time.RFC3339))
+	params.Set("end", end.UTC().Format(time.RFC3339))
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+
+	series, err := prometheusQueryRange(endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResult(map[string]interface{}{
+		"query":  promql,
+		"start":  start.UTC().Format(time.RFC3339),
+		"end":    end.UTC().Format(time.RFC3339),
+		"step":   step.String(),
+		"series": series,
+	})
+}
+
+func (s *Server) listPrometheusMetricsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	endpoint, err := s.prometheusEndpointArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if err := prometheusGet(endpoint, "/api/v1/label/__name__/values", nil, &names); err != nil {
+		return nil, err
+	}
+
+	return jsonResult(map[string]interface{}{
+		"count":   len(names),
+		"metrics": names,
+	})
+}
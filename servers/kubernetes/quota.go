@@ -0,0 +1,213 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// resourceQuotaTools returns the ResourceQuota tool definitions
+func resourceQuotaTools() []mcp.Tool {
+	quotaProperties := map[string]interface{}{
+		"name": map[string]interface{}{
+			"type":        "string",
+			"description": "Name of the ResourceQuota",
+		},
+		"namespace": map[string]interface{}{
+			"type":        "string",
+			"description": "Namespace of the ResourceQuota",
+		},
+	}
+
+	return []mcp.Tool{
+		{
+			Name:        "list_resource_quotas",
+			Description: "List ResourceQuotas with their hard limits and current usage",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to list (omit for all namespaces)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "create_resource_quota",
+			Description: "Create a ResourceQuota capping the total resources and object counts of a namespace",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name":      quotaProperties["name"],
+					"namespace": quotaProperties["namespace"],
+					"hard": map[string]interface{}{
+						"type":                 "object",
+						"description":          "Resource names mapped to quantities, e.g. {\"pods\":\"10\",\"requests.cpu\":\"4\",\"limits.memory\":\"8Gi\"}",
+						"additionalProperties": map[string]interface{}{"type": "string"},
+					},
+				},
+				"required": []string{"name", "namespace", "hard"},
+			},
+		},
+		{
+			Name:        "delete_resource_quota",
+			Description: "Delete a ResourceQuota",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": quotaProperties,
+				"required":   []string{"name", "namespace"},
+			},
+		},
+		{
+			Name:        "get_quota_usage",
+			Description: "Show the hard limit, used amount and remaining amount of each resource in a ResourceQuota",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": quotaProperties,
+				"required":   []string{"name", "namespace"},
+			},
+		},
+	}
+}
+
+// resourceQuotaSummary is one ResourceQuota and its limits
+type resourceQuotaSummary struct {
+	Name string            `json:"name"`
+	Hard map[string]string `json:"hard"`
+	Used map[string]string `json:"used,omitempty"`
+}
+
+// quotaUsage is the usage of one resource in a ResourceQuota
+type quotaUsage struct {
+	Resource  string `json:"resource"`
+	Hard      string `json:"hard"`
+	Used      string `json:"used"`
+	Available string `json:"available"`
+}
+
+func (s *Server) listResourceQuotasTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "")
+
+	list, err := s.clientset.CoreV1().ResourceQuotas(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := map[string][]resourceQuotaSummary{}
+	for _, quota := range list.Items {
+		namespaces[quota.Namespace] = append(namespaces[quota.Namespace], resourceQuotaSummary{
+			Name: quota.Name,
+			Hard: resourceListStrings(quota.Spec.Hard),
+			Used: resourceListStrings(quota.Status.Used),
+		})
+	}
+	for _, summaries := range namespaces {
+		sort.Slice(summaries, func(i, j int) bool { return summaries[i].Name < summaries[j].Name })
+	}
+
+	return jsonResult(map[string]interface{}{
+		"namespaces": namespaces,
+		"count":      len(list.Items),
+	})
+}
+
+func (s *Server) createResourceQuotaTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	rawHard, ok := args["hard"].(map[string]interface{})
+	if !ok || len(rawHard) == 0 {
+		return nil, fmt.Errorf("hard must map at least one resource name to a quantity")
+	}
+
+	hard := corev1.ResourceList{}
+	for resourceName, raw := range rawHard {
+		value, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("hard.%s must be a quantity string", resourceName)
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("hard.%s: invalid quantity %q: %w", resourceName, value, err)
+		}
+		hard[corev1.ResourceName(resourceName)] = quantity
+	}
+
+	quota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+	}
+	if _, err := s.clientset.CoreV1().ResourceQuotas(namespace).Create(context.Background(), quota, metav1.CreateOptions{}); err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Successfully created ResourceQuota '%s' in namespace '%s' with %d limit(s)", name, namespace, len(hard))), nil
+}
+
+func (s *Server) deleteResourceQuotaTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.clientset.CoreV1().ResourceQuotas(namespace).Delete(context.Background(), name, metav1.DeleteOptions{}); err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Successfully deleted ResourceQuota '%s' from namespace '%s'", name, namespace)), nil
+}
+
+func (s *Server) getQuotaUsageTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+
+	quota, err := s.clientset.CoreV1().ResourceQuotas(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make([]quotaUsage, 0, len(quota.Spec.Hard))
+	for resourceName, hard := range quota.Spec.Hard {
+		// Usage is missing until the quota controller has reconciled the quota
+		used := quota.Status.Used[resourceName]
+		available := hard.DeepCopy()
+		available.Sub(used)
+		if available.Sign() < 0 {
+			available = *resource.NewQuantity(0, hard.Format)
+		}
+		usage = append(usage, quotaUsage{
+			Resource:  string(resourceName),
+			Hard:      hard.String(),
+			Used:      used.String(),
+			Available: available.String(),
+		})
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Resource < usage[j].Resource })
+
+	return jsonResult(map[string]interface{}{
+		"name":      name,
+		"namespace": namespace,
+		"resources": usage,
+	})
+}
@@ -0,0 +1,407 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// discoveryPathPrefixes are non-resource URLs the default system:discovery and system:public-info-viewer
+// roles already grant to every authenticated user
+var discoveryPathPrefixes = []string{"/api", "/apis", "/healthz", "/livez", "/readyz", "/version", "/openapi"}
+
+// namedVerbs are the verbs RBAC can restrict to resourceNames
+var namedVerbs = map[string]bool{"get": true, "update": true, "patch": true, "delete": true}
+
+// rbacPolicyTools returns the RBAC policy generation tool definitions
+func rbacPolicyTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "generate_rbac_policy",
+			Description: "Generate a least-privilege Role or ClusterRole, with bindings, granting a service account exactly the verbs and resources it was observed using in API server audit logs read from a file or ConfigMap",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"service_account": map[string]interface{}{
+						"type":        "string",
+						"description": "Service account name, namespace:name or system:serviceaccount:namespace:name",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the service account when service_account is a bare name (defaults to default)",
+					},
+					"audit_log_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Relative path inside the server's working directory of a JSON audit log (one event per line, or an EventList)",
+					},
+					"configmap": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of a ConfigMap holding audit log JSON, used instead of audit_log_path",
+					},
+					"configmap_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the ConfigMap (defaults to default)",
+					},
+					"configmap_key": map[string]interface{}{
+						"type":        "string",
+						"description": "Key of the ConfigMap holding the log (defaults to every key)",
+					},
+					"restrict_to_names": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Restrict get, update, patch and delete to the object names that were observed",
+					},
+				},
+				"required": []string{"service_account"},
+			},
+		},
+	}
+}
+
+// auditEvent is the part of an audit.k8s.io/v1 Event, or EventList, the policy is generated from
+type auditEvent struct {
+	Kind       string `json:"kind"`
+	AuditID    string `json:"auditID"`
+	Verb       string `json:"verb"`
+	RequestURI string `json:"requestURI"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	ObjectRef *struct {
+		Resource    string `json:"resource"`
+		Subresource string `json:"subresource"`
+		Namespace   string `json:"namespace"`
+		Name        string `json:"name"`
+		APIGroup    string `json:"apiGroup"`
+	} `json:"objectRef"`
+	ResponseStatus *struct {
+		Code int32 `json:"code"`
+	} `json:"responseStatus"`
+	Items []auditEvent `json:"items"`
+}
+
+// observedResource is the access seen to one resource in one namespace, or cluster-wide when the namespace is empty
+type observedResource struct {
+	group    string
+	resource string
+	verbs    map[string]bool
+	names    map[string]bool
+	unnamed  bool
+}
+
+func (s *Server) generateRBACPolicyTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	account, err := stringArg(args, "service_account")
+	if err != nil {
+		return nil, err
+	}
+	saNamespace, saName, err := parseServiceAccount(account, optionalStringArg(args, "namespace", "default"))
+	if err != nil {
+		return nil, err
+	}
+	username := "system:serviceaccount:" + saNamespace + ":" + saName
+
+	logReader, source, err := s.openAuditLog(args)
+	if err != nil {
+		return nil, err
+	}
+	defer logReader.Close()
+
+	// Audit backends log one event per stage, so a request is counted once per audit ID
+	seen := map[string]bool{}
+	scanned, matched := 0, 0
+	var notes []string
+	resources := map[string]map[string]*observedResource{}
+	nonResource := map[string]map[string]bool{}
+	denied := map[string]bool{}
+	skippedDiscovery := 0
+	decoder := json.NewDecoder(logReader)
+	for {
+		var event auditEvent
+		err := decoder.Decode(&event)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if scanned == 0 {
+				return nil, fmt.Errorf("failed to parse audit log %s: %w", source, err)
+			}
+			// Rotated logs often end in a partly written line
+			notes = append(notes, fmt.Sprintf("Stopped reading %s at a malformed event after %d event(s): %v", source, scanned, err))
+			break
+		}
+
+		events := []auditEvent{event}
+		if event.Kind == "EventList" {
+			events = event.Items
+		}
+		for _, event := range events {
+			scanned++
+			if event.User.Username != username || event.Verb == "" {
+				continue
+			}
+			if event.AuditID != "" {
+				if seen[event.AuditID] {
+					continue
+				}
+				seen[event.AuditID] = true
+			}
+			matched++
+
+			if event.ResponseStatus != nil && event.ResponseStatus.Code == 403 {
+				// A denied request shows the account gets by without it, so it is only listed in the notes
+				denied[auditRequest(event)] = true
+				continue
+			}
+			if event.ObjectRef == nil {
+				path, _, _ := strings.Cut(event.RequestURI, "?")
+				if isDiscoveryPath(path) {
+					skippedDiscovery++
+					continue
+				}
+				if nonResource[path] == nil {
+					nonResource[path] = map[string]bool{}
+				}
+				nonResource[path][event.Verb] = true
+				continue
+			}
+
+			ref := event.ObjectRef
+			resource := ref.Resource
+			if ref.Subresource != "" {
+				resource += "/" + ref.Subresource
+			}
+			if resources[ref.Namespace] == nil {
+				resources[ref.Namespace] = map[string]*observedResource{}
+			}
+			key := ref.APIGroup + "/" + resource
+			observed := resources[ref.Namespace][key]
+			if observed == nil {
+				observed = &observedResource{group: ref.APIGroup, resource: resource, verbs: map[string]bool{}, names: map[string]bool{}}
+				resources[ref.Namespace][key] = observed
+			}
+			observed.verbs[event.Verb] = true
+			if ref.Name == "" || !namedVerbs[event.Verb] {
+				observed.unnamed = true
+			} else {
+				observed.names[ref.Name] = true
+			}
+		}
+	}
+	if matched == 0 {
+		return nil, fmt.Errorf("no audit events for %s among the %d event(s) in %s", username, scanned, source)
+	}
+
+	restrictNames := boolArg(args, "restrict_to_names")
+	roleName := saName + "-observed"
+	// Cluster-wide objects are not namespaced, so their name carries the service account's namespace
+	clusterRoleName := saNamespace + "-" + roleName
+	subject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: saName, Namespace: saNamespace}
+	var objects []interface{}
+
+	clusterRules := observedRules(resources[""], restrictNames)
+	paths := make([]string, 0, len(nonResource))
+	for path := range nonResource {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		clusterRules = append(clusterRules, rbacv1.PolicyRule{NonResourceURLs: []string{path}, Verbs: sortedKeys(nonResource[path])})
+	}
+	if len(clusterRules) > 0 {
+		objects = append(objects,
+			&rbacv1.ClusterRole{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+				ObjectMeta: metav1.ObjectMeta{Name: clusterRoleName},
+				Rules:      clusterRules,
+			},
+			&rbacv1.ClusterRoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: clusterRoleName},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: clusterRoleName},
+				Subjects:   []rbacv1.Subject{subject},
+			},
+		)
+	}
+	namespaces := make([]string, 0, len(resources))
+	for namespace := range resources {
+		if namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		objects = append(objects,
+			&rbacv1.Role{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+				ObjectMeta: metav1.ObjectMeta{Name: roleName, Namespace: namespace},
+				Rules:      observedRules(resources[namespace], restrictNames),
+			},
+			&rbacv1.RoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "RoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: roleName, Namespace: namespace},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: roleName},
+				Subjects:   []rbacv1.Subject{subject},
+			},
+		)
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("%s made no allowed requests other than discovery, which every authenticated user may already make", username)
+	}
+
+	var documents []string
+	for _, obj := range objects {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal RBAC policy: %w", err)
+		}
+		documents = append(documents, string(data))
+	}
+
+	notes = append(notes, fmt.Sprintf("Generated from %d request(s) by %s among %d audit event(s) in %s; requests the service account did not make during the logged period are not granted.", matched, username, scanned, source))
+	if skippedDiscovery > 0 {
+		notes = append(notes, fmt.Sprintf("%d discovery request(s) were left out because the default discovery roles already allow them.", skippedDiscovery))
+	}
+	if len(denied) > 0 {
+		notes = append(notes, "These requests were denied when logged and are not granted; add them only if the service account needs them: "+strings.Join(sortedKeys(denied), ", "))
+	}
+
+	return &mcp.ToolResult{
+		Content: []mcp.ToolResultContent{
+			{Type: "text/x-yaml", Text: strings.Join(documents, "---\n")},
+			{Type: "text", Text: strings.Join(notes, "\n")},
+		},
+	}, nil
+}
+
+// parseServiceAccount splits a service account reference into its namespace and name
+func parseServiceAccount(account, defaultNamespace string) (string, string, error) {
+	account = strings.TrimPrefix(account, "system:serviceaccount:")
+	namespace, name, found := strings.Cut(account, ":")
+	if !found {
+		namespace, name = defaultNamespace, account
+	}
+	if namespace == "" || name == "" || strings.Contains(name, ":") {
+		return "", "", fmt.Errorf("invalid service account %q, expected name, namespace:name or system:serviceaccount:namespace:name", account)
+	}
+	return namespace, name, nil
+}
+
+// openAuditLog opens the audit log named by the audit_log_path or configmap argument and describes where it came from
+func (s *Server) openAuditLog(args map[string]interface{}) (io.ReadCloser, string, error) {
+	path := optionalStringArg(args, "audit_log_path", "")
+	configMap := optionalStringArg(args, "configmap", "")
+	switch {
+	case path != "" && configMap != "":
+		return nil, "", fmt.Errorf("set either audit_log_path or configmap, not both")
+	case path != "":
+		// Only files below the working directory may be read
+		if !filepath.IsLocal(path) {
+			return nil, "", fmt.Errorf("audit_log_path must be a relative path inside the server's working directory")
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to open audit log: %w", err)
+		}
+		return file, path, nil
+	case configMap != "":
+		namespace := optionalStringArg(args, "configmap_namespace", "default")
+		cm, err := s.clientset.CoreV1().ConfigMaps(namespace).Get(context.Background(), configMap, metav1.GetOptions{})
+		if err != nil {
+			return nil, "", err
+		}
+		source := "configmap " + namespace + "/" + configMap
+		if key := optionalStringArg(args, "configmap_key", ""); key != "" {
+			data, ok := cm.Data[key]
+			if !ok {
+				return nil, "", fmt.Errorf("%s has no key %s", source, key)
+			}
+			return io.NopCloser(strings.NewReader(data)), source, nil
+		}
+		var b strings.Builder
+		for _, key := range sortedKeys(cm.Data) {
+			b.WriteString(cm.Data[key])
+			b.WriteString("\n")
+		}
+		return io.NopCloser(strings.NewReader(b.String())), source, nil
+	default:
+		return nil, "", fmt.Errorf("audit_log_path or configmap is required")
+	}
+}
+
+// observedRules turns the resources observed in one scope into policy rules, merging resources of a group that share
+// the same verbs
+func observedRules(observed map[string]*observedResource, restrictNames bool) []rbacv1.PolicyRule {
+	keys := make([]string, 0, len(observed))
+	for key := range observed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var rules []rbacv1.PolicyRule
+	merged := map[string]int{}
+	for _, key := range keys {
+		resource := observed[key]
+		verbs := sortedKeys(resource.verbs)
+		if restrictNames && !resource.unnamed {
+			rules = append(rules, rbacv1.PolicyRule{
+				APIGroups:     []string{resource.group},
+				Resources:     []string{resource.resource},
+				ResourceNames: sortedKeys(resource.names),
+				Verbs:         verbs,
+			})
+			continue
+		}
+		mergeKey := resource.group + "|" + strings.Join(verbs, ",")
+		if i, ok := merged[mergeKey]; ok {
+			rules[i].Resources = append(rules[i].Resources, resource.resource)
+			continue
+		}
+		merged[mergeKey] = len(rules)
+		rules = append(rules, rbacv1.PolicyRule{
+			APIGroups: []string{resource.group},
+			Resources: []string{resource.resource},
+			Verbs:     verbs,
+		})
+	}
+	return rules
+}
+
+// auditRequest describes an audited request for the notes
+func auditRequest(event auditEvent) string {
+	if event.ObjectRef == nil {
+		path, _, _ := strings.Cut(event.RequestURI, "?")
+		return event.Verb + " " + path
+	}
+	ref := event.ObjectRef
+	request := event.Verb + " " + ref.Resource
+	if ref.Subresource != "" {
+		request += "/" + ref.Subresource
+	}
+	if ref.APIGroup != "" {
+		request += "." + ref.APIGroup
+	}
+	if ref.Namespace != "" {
+		request += " in " + ref.Namespace
+	}
+	return request
+}
+
+// isDiscoveryPath reports whether a non-resource URL is covered by the default discovery roles
+func isDiscoveryPath(path string) bool {
+	for _, prefix := range discoveryPathPrefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// defaultRecentDeployments is how many deployments get_recent_deployments returns by default
+const defaultRecentDeployments = 5
+
+// recentTools returns the recently changed deployment tool definitions
+func recentTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_recent_deployments",
+			Description: "List the most recently created or rolled out deployments, newest first",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"n": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Number of deployments to return (defaults to %d)", defaultRecentDeployments),
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to list (omit for all namespaces)",
+					},
+				},
+			},
+		},
+	}
+}
+
+// recentDeployment is one deployment in the get_recent_deployments result
+type recentDeployment struct {
+	Name           string `json:"name"`
+	Namespace      string `json:"namespace"`
+	Image          string `json:"image"`
+	Replicas       int32  `json:"replicas"`
+	LastUpdated    string `json:"last_updated"`
+	LastUpdatedAgo string `json:"last_updated_ago"`
+
+	updated time.Time
+}
+
+func (s *Server) getRecentDeploymentsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	n, err := intArg(args, "n", defaultRecentDeployments)
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, fmt.Errorf("n must be positive")
+	}
+	namespace := optionalStringArg(args, "namespace", "")
+
+	list, err := s.clientset.AppsV1().Deployments(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	deployments := make([]recentDeployment, 0, len(list.Items))
+	for _, deployment := range list.Items {
+		images := make([]string, 0, len(deployment.Spec.Template.Spec.Containers))
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			images = append(images, container.Image)
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		updated := deploymentUpdateTime(deployment)
+		deployments = append(deployments, recentDeployment{
+			Name:           deployment.Name,
+			Namespace:      deployment.Namespace,
+			Image:          strings.Join(images, ","),
+			Replicas:       replicas,
+			LastUpdated:    updated.Format(time.RFC3339),
+			LastUpdatedAgo: duration.HumanDuration(now.Sub(updated)) + " ago",
+			updated:        updated,
+		})
+	}
+
+	sort.SliceStable(deployments, func(i, j int) bool {
+		return deployments[i].updated.After(deployments[j].updated)
+	})
+	if len(deployments) > n {
+		deployments = deployments[:n]
+	}
+
+	return jsonResult(deployments)
+}
+
+// deploymentUpdateTime returns when a deployment last changed: the Progressing condition's last update,
+// which moves on every rollout, or its creation time when it has not reported progress
+func deploymentUpdateTime(deployment appsv1.Deployment) time.Time {
+	updated := deployment.CreationTimestamp.Time
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.LastUpdateTime.After(updated) {
+			updated = condition.LastUpdateTime.Time
+		}
+	}
+	return updated
+}
@@ -0,0 +1,75 @@
+package kubernetes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// failureReason classifies a Kubernetes API error into a reason with a known recovery suggestion
+func failureReason(err error) string {
+	switch {
+	case apierrors.IsAlreadyExists(err):
+		return mcp.ReasonAlreadyExists
+	case apierrors.IsForbidden(err):
+		return mcp.ReasonForbidden
+	case apierrors.IsInvalid(err):
+		return mcp.ReasonInvalid
+	case apierrors.IsNotFound(err):
+		var status apierrors.APIStatus
+		if errors.As(err, &status) {
+			if details := status.Status().Details; details != nil && details.Kind == "namespaces" {
+				return mcp.ReasonNamespaceNotFound
+			}
+		}
+	}
+	return ""
+}
+
+// enrichKubernetesError prefixes a Kubernetes API error with actionable guidance.
+// resource names the object the tool worked on and is used when the API error does not identify it; other errors are returned unchanged.
+func enrichKubernetesError(err error, resource, namespace string) error {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) {
+		return err
+	}
+	if details := status.Status().Details; details != nil && details.Name != "" {
+		resource = details.Kind + "/" + details.Name
+		if details.Kind == "namespaces" {
+			namespace = ""
+		}
+	}
+	if resource == "" {
+		resource = "resource"
+	}
+	scope := ""
+	if namespace != "" {
+		scope = " in namespace " + namespace
+	}
+
+	var guidance string
+	switch {
+	case failureReason(err) == mcp.ReasonNamespaceNotFound:
+		guidance = fmt.Sprintf("Namespace %s not found. Create it first or check the namespace name.", strings.TrimPrefix(resource, "namespaces/"))
+	case apierrors.IsNotFound(err):
+		guidance = fmt.Sprintf("Resource %s not found%s. Check the name and namespace.", resource, scope)
+	case apierrors.IsAlreadyExists(err):
+		guidance = fmt.Sprintf("Resource %s already exists%s. Update the existing resource or choose another name.", resource, scope)
+	case apierrors.IsConflict(err):
+		guidance = "Retry: another client updated this resource concurrently."
+	case apierrors.IsForbidden(err):
+		guidance = fmt.Sprintf("Permission denied for %s%s. Check the RBAC rules bound to the server's service account.", resource, scope)
+	case apierrors.IsUnauthorized(err):
+		guidance = "The API server rejected the server's credentials. Check the kubeconfig or service account token."
+	case apierrors.IsInvalid(err):
+		guidance = fmt.Sprintf("Resource %s is invalid. Check the tool arguments against the resource schema.", resource)
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err), apierrors.IsTooManyRequests(err):
+		guidance = "The API server is busy or timed out. Retry shortly."
+	default:
+		return err
+	}
+	return fmt.Errorf("%s (%w)", guidance, err)
+}
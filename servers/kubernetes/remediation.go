@@ -0,0 +1,107 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// restartableReasons are container states that a pod restart may clear
+var restartableReasons = map[string]bool{
+	"CrashLoopBackOff": true,
+	"ImagePullBackOff": true,
+	"OOMKilled":        true,
+}
+
+// remediationTools returns the automated remediation tool definitions
+func remediationTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "restart_failed_pods",
+			Description: "Delete failed or crash-looping pods owned by a ReplicaSet or StatefulSet so their controller recreates them",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to remediate (defaults to default)",
+					},
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Report which pods would be deleted without deleting them",
+					},
+				},
+			},
+		},
+	}
+}
+
+// skippedPod records a failed pod that was left in place
+type skippedPod struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+func (s *Server) restartFailedPodsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "default")
+	dryRun := boolArg(args, "dry_run")
+	ctx := context.Background()
+
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := []string{}
+	skipped := []skippedPod{}
+	for _, pod := range pods.Items {
+		failure := podFailureReason(pod)
+		if failure == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		owner := metav1.GetControllerOf(&pod)
+		if owner == nil || (owner.Kind != "ReplicaSet" && owner.Kind != "StatefulSet") {
+			skipped = append(skipped, skippedPod{
+				Name:   pod.Name,
+				Reason: fmt.Sprintf("%s, but not owned by a ReplicaSet or StatefulSet so it would not be recreated", failure),
+			})
+			continue
+		}
+
+		if !dryRun {
+			if err := s.clientset.CoreV1().Pods(namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+				skipped = append(skipped, skippedPod{Name: pod.Name, Reason: fmt.Sprintf("delete failed: %v", err)})
+				continue
+			}
+		}
+		deleted = append(deleted, pod.Name)
+	}
+
+	return jsonResult(map[string]interface{}{
+		"namespace": namespace,
+		"dry_run":   dryRun,
+		"deleted":   deleted,
+		"skipped":   skipped,
+	})
+}
+
+// podFailureReason returns why a pod needs restarting, or an empty string if it is healthy
+func podFailureReason(pod corev1.Pod) string {
+	if pod.Status.Phase == corev1.PodFailed {
+		return "phase Failed"
+	}
+
+	for _, status := range pod.Status.ContainerStatuses {
+		if waiting := status.State.Waiting; waiting != nil && restartableReasons[waiting.Reason] {
+			return fmt.Sprintf("container %s is %s", status.Name, waiting.Reason)
+		}
+		if terminated := status.State.Terminated; terminated != nil && restartableReasons[terminated.Reason] {
+			return fmt.Sprintf("container %s was %s", status.Name, terminated.Reason)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// resourceTools returns the generic resource tool definitions
+func resourceTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_resource",
+			Description: "Get the live manifest of any resource as YAML, ready to edit and pass back to apply_manifest",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"resource_type": map[string]interface{}{
+						"type":        "string",
+						"description": "Resource type (e.g. deployment, service, configmap)",
+					},
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the resource",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the resource (defaults to default; ignored for cluster-scoped resources)",
+					},
+				},
+				"required": []string{"resource_type", "name"},
+			},
+		},
+	}
+}
+
+func (s *Server) getResourceTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	resourceType, err := stringArg(args, "resource_type")
+	if err != nil {
+		return nil, err
+	}
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(s.clientset.Discovery()))
+	client, err := s.resourceClientFor(mapper, resourceType, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := client.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// resourceVersion is kept so applying an edited copy fails if the resource changed in the meantime
+	unstructured.RemoveNestedField(obj.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(obj.Object, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	data, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s/%s: %w", resourceType, name, err)
+	}
+	return textResult(string(data)), nil
+}
@@ -0,0 +1,230 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	defaultRolloutWait  = 120 * time.Second
+	maxRolloutWait      = 10 * time.Minute
+	rolloutPollInterval = 2 * time.Second
+)
+
+// rollingUpdateTools returns the image rollout tool definitions
+func rollingUpdateTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "rolling_update_image",
+			Description: "Change the image of one container of a deployment, which rolls its pods without downtime, and wait for the rollout to finish",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"deployment_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment (defaults to default)",
+					},
+					"container_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Container whose image to change (optional when the pod has a single container)",
+					},
+					"new_image": map[string]interface{}{
+						"type":        "string",
+						"description": "Image to roll out, e.g. nginx:1.26",
+					},
+					"wait_seconds": map[string]interface{}{
+						"type":        "integer",
+						"description": "Seconds to wait for the rollout to complete (defaults to 120, at most 600; 0 returns right after the update)",
+					},
+				},
+				"required": []string{"deployment_name", "new_image"},
+			},
+		},
+	}
+}
+
+func (s *Server) rollingUpdateImageTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "deployment_name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	newImage, err := stringArg(args, "new_image")
+	if err != nil {
+		return nil, err
+	}
+	if strings.ContainsAny(newImage, " \t\n") {
+		return nil, fmt.Errorf("new_image %q must not contain whitespace", newImage)
+	}
+	waitSeconds, err := intArg(args, "wait_seconds", int(defaultRolloutWait/time.Second))
+	if err != nil {
+		return nil, err
+	}
+	wait := time.Duration(waitSeconds) * time.Second
+	if wait < 0 || wait > maxRolloutWait {
+		return nil, fmt.Errorf("wait_seconds must be between 0 and %d", int(maxRolloutWait/time.Second))
+	}
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	container, err := rolloutContainer(deployment, optionalStringArg(args, "container_name", ""))
+	if err != nil {
+		return nil, err
+	}
+	previousImage := container.Image
+
+	policy, err := s.namespacePolicy(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if policy != nil {
+		for _, pattern := range policy.DisallowedImages {
+			if imageMatches(pattern, newImage) {
+				return nil, fmt.Errorf("namespace policy of %s forbids image %s, which matches disallowed pattern %s", namespace, newImage, pattern)
+			}
+		}
+	}
+
+	if previousImage != newImage {
+		// containers merge on name, so only the image of the named container changes
+		patch, err := json.Marshal(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []map[string]interface{}{{"name": container.Name, "image": newImage}},
+					},
+				},
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal patch: %w", err)
+		}
+		deployment, err = s.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return nil, err
+		}
+		s.audit("rolling_update_image", logrus.Fields{
+			"deployment":     namespace + "/" + name,
+			"container":      container.Name,
+			"previous_image": previousImage,
+			"new_image":      newImage,
+		})
+	}
+
+	deployment, complete, reason, err := s.waitForRollout(ctx, deployment, wait)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{
+		"deployment":       name,
+		"namespace":        namespace,
+		"container":        container.Name,
+		"previous_image":   previousImage,
+		"new_image":        newImage,
+		"rollout_complete": complete,
+		"ready_replicas":   deployment.Status.ReadyReplicas,
+		"updated_replicas": deployment.Status.UpdatedReplicas,
+		"replicas":         deployment.Status.Replicas,
+	}
+	switch {
+	case previousImage == newImage:
+		result["message"] = fmt.Sprintf("Container %s already runs %s, so nothing was rolled out", container.Name, newImage)
+	case !complete:
+		result["message"] = fmt.Sprintf("%s; the old pods keep serving until the new ones are ready. Roll back with kubectl rollout undo deployment/%s -n %s", reason, name, namespace)
+	}
+	return jsonResult(result)
+}
+
+// rolloutContainer returns the container of a deployment to update, which may be omitted when there is only one
+func rolloutContainer(deployment *appsv1.Deployment, containerName string) (*corev1.Container, error) {
+	containers := deployment.Spec.Template.Spec.Containers
+	if containerName == "" {
+		if len(containers) != 1 {
+			names := make([]string, 0, len(containers))
+			for _, container := range containers {
+				names = append(names, container.Name)
+			}
+			return nil, fmt.Errorf("deployment %s has %d containers, set container_name to one of: %s", deployment.Name, len(containers), strings.Join(names, ", "))
+		}
+		return &containers[0], nil
+	}
+	for i := range containers {
+		if containers[i].Name == containerName {
+			return &containers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("deployment %s has no container named %s", deployment.Name, containerName)
+}
+
+// waitForRollout polls a deployment until every replica runs the current template, the rollout stalls or wait
+// expires, and returns the last state seen with the reason it is not complete
+func (s *Server) waitForRollout(ctx context.Context, deployment *appsv1.Deployment, wait time.Duration) (*appsv1.Deployment, bool, string, error) {
+	deadline := time.Now().Add(wait)
+	for {
+		complete, stalled, reason := rolloutStatus(deployment)
+		switch {
+		case complete:
+			return deployment, true, "", nil
+		case stalled:
+			return deployment, false, "Rollout stalled: " + reason, nil
+		case !time.Now().Before(deadline):
+			return deployment, false, fmt.Sprintf("Rollout not complete after %s: %s", wait, reason), nil
+		}
+		time.Sleep(rolloutPollInterval)
+
+		var err error
+		deployment, err = s.clientset.AppsV1().Deployments(deployment.Namespace).Get(ctx, deployment.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, false, "", err
+		}
+	}
+}
+
+// rolloutStatus reports whether a deployment's rollout is complete or has passed its progress deadline, following
+// kubectl rollout status, and what it waits for
+func rolloutStatus(deployment *appsv1.Deployment) (bool, bool, string) {
+	if deployment.Spec.Paused {
+		return false, true, fmt.Sprintf("deployment %s is paused; resume it with kubectl rollout resume", deployment.Name)
+	}
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return false, false, "the controller has not yet seen the update"
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return false, true, condition.Message
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	status := deployment.Status
+	switch {
+	case status.UpdatedReplicas < replicas:
+		return false, false, fmt.Sprintf("%d of %d replicas updated", status.UpdatedReplicas, replicas)
+	case status.Replicas > status.UpdatedReplicas:
+		return false, false, fmt.Sprintf("%d old replica(s) pending termination", status.Replicas-status.UpdatedReplicas)
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		return false, false, fmt.Sprintf("%d of %d updated replicas available", status.AvailableReplicas, status.UpdatedReplicas)
+	}
+	return true, false, ""
+}
@@ -0,0 +1,269 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// startupSamples is how many of the most recently started pods the startup time is averaged over
+	startupSamples = 5
+	// defaultPodStartup is assumed when no pod of the deployment has started cleanly yet
+	defaultPodStartup = 30 * time.Second
+	// defaultTerminationGrace is the pod terminationGracePeriodSeconds default
+	defaultTerminationGrace = 30 * time.Second
+)
+
+// scaleDurationTools returns the scaling time estimate tool definitions
+func scaleDurationTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "estimate_scale_duration",
+			Description: "Estimate how long a deployment takes to reach a replica count, from how long its recent pods took to become ready and how many pods its rollout strategy starts at once",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"deployment_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment (defaults to default)",
+					},
+					"target_replicas": map[string]interface{}{
+						"type":        "integer",
+						"description": "Replica count to scale to",
+					},
+				},
+				"required": []string{"deployment_name", "target_replicas"},
+			},
+		},
+	}
+}
+
+func (s *Server) estimateScaleDurationTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "deployment_name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	if _, ok := args["target_replicas"]; !ok {
+		return nil, fmt.Errorf("target_replicas is required")
+	}
+	target, err := intArg(args, "target_replicas", 0)
+	if err != nil {
+		return nil, err
+	}
+	if target < 0 {
+		return nil, fmt.Errorf("target_replicas must be 0 or more")
+	}
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment selector: %w", err)
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	current := 1
+	if deployment.Spec.Replicas != nil {
+		current = int(*deployment.Spec.Replicas)
+	}
+	delta := target - current
+	minReady := time.Duration(deployment.Spec.MinReadySeconds) * time.Second
+	startups := podStartupTimes(pods.Items, startupSamples)
+	startup := defaultPodStartup
+	if len(startups) > 0 {
+		var total time.Duration
+		for _, d := range startups {
+			total += d
+		}
+		startup = total / time.Duration(len(startups))
+	}
+	unschedulable := 0
+	for _, pod := range pods.Items {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+				unschedulable++
+			}
+		}
+	}
+
+	var notes []string
+	parallelism, waves := 0, 0
+	var estimate time.Duration
+	confidence := startupConfidence(startups)
+	complete, _, _ := rolloutStatus(deployment)
+	switch {
+	case delta == 0:
+		confidence = "high"
+		notes = append(notes, fmt.Sprintf("Deployment %s already has %d replica(s)", name, target))
+	case delta < 0:
+		// Removed pods stop together, each within its grace period
+		grace := defaultTerminationGrace
+		if seconds := deployment.Spec.Template.Spec.TerminationGracePeriodSeconds; seconds != nil {
+			grace = time.Duration(*seconds) * time.Second
+		}
+		parallelism, waves = -delta, 1
+		estimate = grace
+		confidence = "medium"
+		notes = append(notes, fmt.Sprintf("Scaling down removes %d pod(s) at once; the estimate is their %s termination grace period, which pods that exit promptly on SIGTERM do not use up", -delta, grace))
+	case !complete:
+		// While a rollout runs the controller adds new pods in steps bounded by the strategy
+		parallelism = rolloutParallelism(deployment.Spec.Strategy, target)
+		remaining := target - int(deployment.Status.UpdatedReplicas)
+		if remaining < 1 {
+			remaining = 1
+		}
+		waves = (remaining + parallelism - 1) / parallelism
+		estimate = time.Duration(waves) * (startup + minReady)
+		notes = append(notes, fmt.Sprintf("A rollout is in progress, so the %d replica(s) still to update start %d at a time as allowed by %s", remaining, parallelism, describeStrategy(&deployment.Spec.Strategy)))
+	default:
+		// Scaling outside a rollout creates every new pod at once; maxSurge and maxUnavailable only pace rollouts
+		parallelism, waves = delta, 1
+		estimate = startup + minReady
+		notes = append(notes, fmt.Sprintf("The ReplicaSet creates all %d new pod(s) at once; %s only paces rollouts", delta, describeStrategy(&deployment.Spec.Strategy)))
+	}
+
+	if delta > 0 {
+		if len(startups) == 0 {
+			confidence = "low"
+			notes = append(notes, fmt.Sprintf("No pod of the deployment has become ready without restarting, so a pod startup of %s is assumed", defaultPodStartup))
+		}
+		if unschedulable > 0 {
+			confidence = "low"
+			notes = append(notes, fmt.Sprintf("%d pod(s) are already unschedulable, so new pods may wait for nodes to be added", unschedulable))
+		} else {
+			notes = append(notes, "The estimate assumes the nodes have room for the new pods; waiting for the cluster autoscaler to add nodes usually takes minutes more")
+		}
+		if minReady > 0 {
+			notes = append(notes, fmt.Sprintf("Includes the deployment's minReadySeconds of %s", minReady))
+		}
+	}
+	if _, err := s.deploymentHPA(ctx, namespace, name); err == nil && delta != 0 {
+		notes = append(notes, "A HorizontalPodAutoscaler scales this deployment and will override a manual replica count")
+	}
+
+	sampleSeconds := make([]float64, 0, len(startups))
+	for _, d := range startups {
+		sampleSeconds = append(sampleSeconds, math.Round(d.Seconds()*10)/10)
+	}
+	return jsonResult(map[string]interface{}{
+		"deployment":                  name,
+		"namespace":                   namespace,
+		"current_replicas":            current,
+		"target_replicas":             target,
+		"estimated_duration_seconds":  int(math.Ceil(estimate.Seconds())),
+		"confidence":                  confidence,
+		"average_pod_startup_seconds": math.Round(startup.Seconds()*10) / 10,
+		"startup_samples_seconds":     sampleSeconds,
+		"parallelism":                 parallelism,
+		"waves":                       waves,
+		"notes":                       notes,
+	})
+}
+
+// podStartupTimes returns how long the most recently created pods took from creation to Ready, newest first.
+// Pods that restarted are skipped, as their Ready time marks the last restart rather than the first start.
+func podStartupTimes(pods []corev1.Pod, limit int) []time.Duration {
+	type sample struct {
+		created time.Time
+		startup time.Duration
+	}
+	var samples []sample
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || podRestarts(pod) > 0 {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				startup := condition.LastTransitionTime.Sub(pod.CreationTimestamp.Time)
+				if startup >= 0 {
+					samples = append(samples, sample{created: pod.CreationTimestamp.Time, startup: startup})
+				}
+			}
+		}
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].created.After(samples[j].created) })
+
+	startups := make([]time.Duration, 0, limit)
+	for i := 0; i < len(samples) && i < limit; i++ {
+		startups = append(startups, samples[i].startup)
+	}
+	return startups
+}
+
+// podRestarts sums the restart counts of a pod's containers
+func podRestarts(pod corev1.Pod) int32 {
+	var restarts int32
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return restarts
+}
+
+// startupConfidence rates an estimate by how many startup samples it rests on and how much they vary
+func startupConfidence(startups []time.Duration) string {
+	if len(startups) < 2 {
+		return "low"
+	}
+	if len(startups) < startupSamples {
+		return "medium"
+	}
+	shortest, longest := startups[0], startups[0]
+	var total time.Duration
+	for _, d := range startups {
+		shortest, longest = min(shortest, d), max(longest, d)
+		total += d
+	}
+	// Startups spread over more than half the average make any single figure a guess
+	if longest-shortest > total/time.Duration(len(startups))/2 {
+		return "medium"
+	}
+	return "high"
+}
+
+// rolloutParallelism returns how many new pods a rollout starts at once for a replica count: the surge allowance
+// plus the pods it may take down, as the deployment controller rounds them, or all of them for Recreate
+func rolloutParallelism(strategy appsv1.DeploymentStrategy, replicas int) int {
+	if strategy.Type == appsv1.RecreateDeploymentStrategyType || replicas == 0 {
+		return max(replicas, 1)
+	}
+	// The API server defaults both to 25%
+	surge, unavailable := intstr.FromString("25%"), intstr.FromString("25%")
+	if strategy.RollingUpdate != nil {
+		if strategy.RollingUpdate.MaxSurge != nil {
+			surge = *strategy.RollingUpdate.MaxSurge
+		}
+		if strategy.RollingUpdate.MaxUnavailable != nil {
+			unavailable = *strategy.RollingUpdate.MaxUnavailable
+		}
+	}
+	// The controller rounds surge up and unavailability down
+	surgeCount, err := intstr.GetScaledValueFromIntOrPercent(&surge, replicas, true)
+	if err != nil {
+		surgeCount = 0
+	}
+	unavailableCount, err := intstr.GetScaledValueFromIntOrPercent(&unavailable, replicas, false)
+	if err != nil {
+		unavailableCount = 0
+	}
+	return max(surgeCount+unavailableCount, 1)
+}
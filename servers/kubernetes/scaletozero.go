@@ -0,0 +1,278 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// originalReplicasAnnotation records a deployment's replica count while it is scaled to zero
+const originalReplicasAnnotation = "mcp.servers/original-replicas"
+
+// Automatic scale-down settings
+const (
+	autoScaleDownInterval = time.Minute
+	autoScaleDownIdleCPU  = "5m"
+)
+
+// scaleToZeroTools returns the scale-to-zero and wake-up tool definitions
+func scaleToZeroTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "scale_to_zero",
+			Description: "Pause a deployment by scaling it to zero replicas, remembering its replica count so wake_up_deployment can restore it",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment",
+					},
+				},
+				"required": []string{"name", "namespace"},
+			},
+		},
+		{
+			Name:        "wake_up_deployment",
+			Description: "Restore a deployment paused by scale_to_zero to its recorded replica count",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment",
+					},
+				},
+				"required": []string{"name", "namespace"},
+			},
+		},
+	}
+}
+
+func (s *Server) scaleToZeroTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := s.scaleToZero(context.Background(), namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Scaled deployment '%s' in namespace '%s' to zero (was %d replicas)", name, namespace, original)), nil
+}
+
+func (s *Server) wakeUpDeploymentTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	value, ok := deployment.Annotations[originalReplicasAnnotation]
+	if !ok {
+		return nil, fmt.Errorf("deployment %s/%s has no %s annotation; it was not scaled down with scale_to_zero", namespace, name, originalReplicasAnnotation)
+	}
+	replicas, err := strconv.Atoi(value)
+	if err != nil || replicas < 0 {
+		return nil, fmt.Errorf("invalid %s annotation %q", originalReplicasAnnotation, value)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{originalReplicasAnnotation: nil},
+		},
+		"spec": map[string]interface{}{"replicas": replicas},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Woke up deployment '%s' in namespace '%s' with %d replicas", name, namespace, replicas)), nil
+}
+
+// scaleToZero records the current replica count in an annotation and scales the deployment to zero in one patch
+func (s *Server) scaleToZero(ctx context.Context, namespace, name string) (int32, error) {
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if replicas == 0 {
+		return 0, fmt.Errorf("deployment %s/%s is already scaled to zero", namespace, name)
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{originalReplicasAnnotation: strconv.Itoa(int(replicas))},
+		},
+		"spec": map[string]interface{}{"replicas": 0},
+	})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return 0, err
+	}
+	return replicas, nil
+}
+
+// ValidateAutoScaleDownSelector checks that a selector limits which deployments RunAutoScaleDown may scale;
+// an empty selector would match every deployment in the cluster
+func ValidateAutoScaleDownSelector(selector string) error {
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return fmt.Errorf("invalid auto scale-down selector: %w", err)
+	}
+	if parsed.Empty() {
+		return fmt.Errorf("auto scale-down needs a label selector naming the deployments it may scale")
+	}
+	return nil
+}
+
+// RunAutoScaleDown scales to zero deployments matching selector whose pods have used almost no CPU for idleAfter.
+// Deployments in the kube- system namespaces are never scaled. It needs metrics-server and runs until ctx is cancelled.
+func (s *Server) RunAutoScaleDown(ctx context.Context, idleAfter time.Duration, selector string) {
+	if err := ValidateAutoScaleDownSelector(selector); err != nil {
+		s.logger.Errorf("Auto scale-down disabled: %v", err)
+		return
+	}
+	idleSince := map[string]time.Time{}
+	threshold := resource.MustParse(autoScaleDownIdleCPU)
+
+	ticker := time.NewTicker(autoScaleDownInterval)
+	defer ticker.Stop()
+
+	s.logger.Infof("Scaling down deployments idle for more than %s", idleAfter)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deployments, err := s.clientset.AppsV1().Deployments("").List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			s.logger.Warnf("Auto scale-down: failed to list deployments: %v", err)
+			continue
+		}
+
+		now := time.Now()
+		seen := map[string]bool{}
+		for _, deployment := range deployments.Items {
+			if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+				continue
+			}
+			// Idle cluster add-ons such as coredns must keep running
+			if strings.HasPrefix(deployment.Namespace, "kube-") {
+				continue
+			}
+			key := deployment.Namespace + "/" + deployment.Name
+			podSelector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+			if err != nil {
+				continue
+			}
+
+			idle, err := s.podsIdle(ctx, deployment.Namespace, podSelector.String(), threshold)
+			if err != nil {
+				s.logger.Warnf("Auto scale-down: metrics unavailable for %s: %v", key, err)
+				continue
+			}
+			if !idle {
+				continue
+			}
+			seen[key] = true
+
+			since, ok := idleSince[key]
+			if !ok {
+				idleSince[key] = now
+				continue
+			}
+			if now.Sub(since) < idleAfter {
+				continue
+			}
+
+			replicas, err := s.scaleToZero(ctx, deployment.Namespace, deployment.Name)
+			if err != nil {
+				s.logger.Warnf("Auto scale-down: failed to scale %s to zero: %v", key, err)
+				continue
+			}
+			s.logger.Infof("Auto scale-down: scaled %s to zero after %s idle (was %d replicas)", key, now.Sub(since).Round(time.Second), replicas)
+			delete(seen, key)
+		}
+
+		// Forget deployments that became busy, were scaled down or no longer exist
+		for key := range idleSince {
+			if !seen[key] {
+				delete(idleSince, key)
+			}
+		}
+	}
+}
+
+// podsIdle reports whether every pod matching selector uses less CPU than threshold, according to metrics-server
+func (s *Server) podsIdle(ctx context.Context, namespace, selector string, threshold resource.Quantity) (bool, error) {
+	list, err := s.dynamicClient.Resource(podMetricsGVR).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return false, err
+	}
+	if len(list.Items) == 0 {
+		return false, nil
+	}
+
+	for _, item := range list.Items {
+		cpu := resource.NewQuantity(0, resource.DecimalSI)
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			usage, _, _ := unstructured.NestedStringMap(container, "usage")
+			if q, err := resource.ParseQuantity(usage["cpu"]); err == nil {
+				cpu.Add(q)
+			}
+		}
+		if cpu.Cmp(threshold) >= 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,292 @@
+package kubernetes
+
+// Sealed secrets use envelope encryption with a two-level key hierarchy:
+//
+//   - The key encryption key (KEK) never leaves the KMS. The server only asks
+//     the KMS endpoint to encrypt or decrypt small payloads with it.
+//   - A fresh 256-bit data encryption key (DEK) is generated for every sealed
+//     Secret. Each value is encrypted locally with AES-256-GCM under the DEK,
+//     and the base64 ciphertext (nonce followed by sealed bytes) is stored as
+//     the Secret value.
+//   - The DEK itself is encrypted by the KMS under the KEK and stored base64
+//     encoded in the sealedDataKeyAnnotation. The plaintext DEK is never persisted.
+//
+// Unsealing reverses the process: the KMS decrypts the DEK, which then decrypts each value.
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Annotations recorded on sealed Secrets
+const (
+	sealedDataKeyAnnotation = "mcp-servers.io/encrypted-data-key"
+	sealedKMSAnnotation     = "mcp-servers.io/kms-endpoint"
+)
+
+// sealedSecretTools returns the KMS sealed Secret tool definitions
+func sealedSecretTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "seal_secret",
+			Description: "Create a Secret whose values are envelope-encrypted with a data key protected by the configured KMS",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the Secret",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace for the Secret",
+					},
+					"data": map[string]interface{}{
+						"type":        "object",
+						"description": "Plaintext key-value pairs to encrypt",
+					},
+				},
+				"required": []string{"name", "namespace", "data"},
+			},
+		},
+		{
+			Name:        "unseal_secret",
+			Description: "Decrypt the values of a Secret created by seal_secret",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the Secret",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the Secret",
+					},
+				},
+				"required": []string{"name", "namespace"},
+			},
+		},
+	}
+}
+
+func (s *Server) sealSecretTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	data, ok := args["data"].(map[string]interface{})
+	if !ok || len(data) == 0 {
+		return nil, fmt.Errorf("data must be a non-empty object")
+	}
+
+	kms, err := s.kms()
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := make(map[string][]byte, len(data))
+	for key, value := range data {
+		plaintext, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("data value for %s must be a string", key)
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), []byte(key))
+		sealed[key] = []byte(base64.StdEncoding.EncodeToString(ciphertext))
+	}
+
+	encryptedKey, err := kms.encrypt(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				sealedDataKeyAnnotation: base64.StdEncoding.EncodeToString(encryptedKey),
+				sealedKMSAnnotation:     kms.endpoint,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: sealed,
+	}
+
+	_, err = s.clientset.CoreV1().Secrets(namespace).Create(context.Background(), secret, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Successfully created sealed Secret '%s' in namespace '%s' with %d encrypted key(s)", name, namespace, len(sealed))), nil
+}
+
+func (s *Server) unsealSecretTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := s.clientset.CoreV1().Secrets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	encodedKey, ok := secret.Annotations[sealedDataKeyAnnotation]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s is not sealed: missing %s annotation", namespace, name, sealedDataKeyAnnotation)
+	}
+	encryptedKey, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode data key: %w", err)
+	}
+
+	kms, err := s.kms()
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := kms.decrypt(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	unsealed := make(map[string]string, len(secret.Data))
+	for key, value := range secret.Data {
+		ciphertext, err := base64.StdEncoding.DecodeString(string(value))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode value for %s: %w", key, err)
+		}
+		if len(ciphertext) < gcm.NonceSize() {
+			return nil, fmt.Errorf("value for %s is too short", key)
+		}
+		nonce, sealedValue := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+		plaintext, err := gcm.Open(nil, nonce, sealedValue, []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt value for %s: %w", key, err)
+		}
+		unsealed[key] = string(plaintext)
+	}
+
+	return jsonResult(map[string]interface{}{
+		"name":      name,
+		"namespace": namespace,
+		"data":      unsealed,
+	})
+}
+
+// newGCM creates an AES-GCM cipher for a data key
+func newGCM(dataKey []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+// kmsClient encrypts and decrypts data keys through a KMS HTTP endpoint.
+// The endpoint accepts POST /encrypt with {"plaintext": base64} returning {"ciphertext": base64},
+// and POST /decrypt with {"ciphertext": base64} returning {"plaintext": base64}.
+type kmsClient struct {
+	endpoint string
+	client   *http.Client
+}
+
+// kms returns a client for the configured KMS endpoint
+func (s *Server) kms() (*kmsClient, error) {
+	if s.kmsEndpoint == "" {
+		return nil, fmt.Errorf("no KMS endpoint configured")
+	}
+	return &kmsClient{
+		endpoint: strings.TrimSuffix(s.kmsEndpoint, "/"),
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// encrypt asks the KMS to encrypt a data key with its key encryption key
+func (k *kmsClient) encrypt(plaintext []byte) ([]byte, error) {
+	var resp struct {
+		Ciphertext string `json:"ciphertext"`
+	}
+	if err := k.call("encrypt", map[string]string{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}, &resp); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Ciphertext)
+}
+
+// decrypt asks the KMS to decrypt a data key
+func (k *kmsClient) decrypt(ciphertext []byte) ([]byte, error) {
+	var resp struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := k.call("decrypt", map[string]string{"ciphertext": base64.StdEncoding.EncodeToString(ciphertext)}, &resp); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Plaintext)
+}
+
+// call posts a JSON request to a KMS operation and decodes the response
+func (k *kmsClient) call(operation string, request interface{}, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal KMS request: %w", err)
+	}
+
+	resp, err := k.client.Post(k.endpoint+"/"+operation, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to call KMS %s: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("KMS %s failed with status %d: %s", operation, resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("failed to decode KMS response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// defaultSearchResourceTypes are searched when search_resources is not given resource_types; secrets are left out on purpose
+var defaultSearchResourceTypes = []string{
+	"pods", "deployments", "statefulsets", "daemonsets", "jobs", "cronjobs",
+	"services", "ingresses", "configmaps", "persistentvolumeclaims",
+}
+
+// podSpecPaths are where pods keep their spec: pods in spec, workloads such as deployments in their pod template
+// and cronjobs in the pod template of their job template
+var podSpecPaths = [][]string{{"spec"}, {"spec", "template", "spec"}, {"spec", "jobTemplate", "spec", "template", "spec"}}
+
+// maxSearchHits caps the search_resources result so a broad query does not flood the caller
+const maxSearchHits = 200
+
+// searchTools returns the resource search tool definitions
+func searchTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "search_resources",
+			Description: "Find resources related to a term: a case-insensitive substring search over names, labels, annotations and container images across resource types and namespaces",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Text to search for, e.g. nginx",
+					},
+					"namespaces": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Namespaces to search (omit for all namespaces)",
+					},
+					"resource_types": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": fmt.Sprintf("Resource types to search (defaults to %s)", strings.Join(defaultSearchResourceTypes, ", ")),
+					},
+				},
+				"required": []string{"query"},
+			},
+		},
+	}
+}
+
+// searchHit is one field of a resource that matches the search query
+type searchHit struct {
+	ResourceType string `json:"resource_type"`
+	Name         string `json:"name"`
+	Namespace    string `json:"namespace,omitempty"`
+	MatchField   string `json:"match_field"`
+	MatchValue   string `json:"match_value"`
+}
+
+func (s *Server) searchResourcesTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	query, err := stringArg(args, "query")
+	if err != nil {
+		return nil, err
+	}
+	needle := strings.ToLower(query)
+
+	namespaces := []string{metav1.NamespaceAll}
+	if _, ok := args["namespaces"]; ok {
+		if namespaces, err = stringSliceArg(args, "namespaces"); err != nil {
+			return nil, err
+		}
+	}
+	resourceTypes := defaultSearchResourceTypes
+	if _, ok := args["resource_types"]; ok {
+		if resourceTypes, err = stringSliceArg(args, "resource_types"); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := context.Background()
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(s.clientset.Discovery()))
+
+	hits := []searchHit{}
+	var skipped []string
+	truncated := false
+search:
+	for _, resourceType := range resourceTypes {
+		resource, namespaced, err := searchResource(mapper, resourceType)
+		if err != nil {
+			// An unknown type, e.g. a CRD that is not installed, is skipped like one that cannot be listed
+			skipped = append(skipped, err.Error())
+			continue
+		}
+
+		scopes := namespaces
+		if !namespaced {
+			scopes = []string{metav1.NamespaceAll}
+		}
+		for _, namespace := range scopes {
+			var client dynamic.ResourceInterface = s.dynamicClient.Resource(resource)
+			if namespaced {
+				client = s.dynamicClient.Resource(resource).Namespace(namespace)
+			}
+			list, err := client.List(ctx, metav1.ListOptions{})
+			if err != nil {
+				// A type the server may not list, e.g. for lack of RBAC, does not end the search
+				skipped = append(skipped, fmt.Sprintf("%s: %v", resourceType, err))
+				continue
+			}
+
+			for i := range list.Items {
+				for _, hit := range searchObject(&list.Items[i], resource.Resource, needle) {
+					if len(hits) == maxSearchHits {
+						truncated = true
+						break search
+					}
+					hits = append(hits, hit)
+				}
+			}
+		}
+	}
+
+	result := map[string]interface{}{
+		"query": query,
+		"count": len(hits),
+		"hits":  hits,
+	}
+	if truncated {
+		result["truncated"] = fmt.Sprintf("Only the first %d matches are shown; narrow the query, namespaces or resource_types", maxSearchHits)
+	}
+	if len(skipped) > 0 {
+		result["skipped"] = skipped
+	}
+	return jsonResult(result)
+}
+
+// searchResource resolves a resource type such as "deployment" and reports whether it is namespaced
+func searchResource(mapper meta.RESTMapper, resourceType string) (schema.GroupVersionResource, bool, error) {
+	gvk, err := mapper.KindFor(schema.GroupVersionResource{Resource: strings.ToLower(resourceType)})
+	if err != nil {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("unknown resource type '%s': %w", resourceType, err)
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, false, fmt.Errorf("failed to map %s: %w", gvk.String(), err)
+	}
+	return mapping.Resource, mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+// searchObject returns the hits for needle in the name, labels, annotations and container images of obj
+func searchObject(obj *unstructured.Unstructured, resourceType, needle string) []searchHit {
+	var hits []searchHit
+	add := func(field, value string) {
+		hits = append(hits, searchHit{
+			ResourceType: resourceType,
+			Name:         obj.GetName(),
+			Namespace:    obj.GetNamespace(),
+			MatchField:   field,
+			MatchValue:   value,
+		})
+	}
+	matches := func(value string) bool {
+		return strings.Contains(strings.ToLower(value), needle)
+	}
+
+	if matches(obj.GetName()) {
+		add("name", obj.GetName())
+	}
+	labels := obj.GetLabels()
+	for _, key := range sortedKeys(labels) {
+		if matches(key) || matches(labels[key]) {
+			add("labels."+key, labels[key])
+		}
+	}
+	annotations := obj.GetAnnotations()
+	for _, key := range sortedKeys(annotations) {
+		// The last applied configuration repeats every other field
+		if key == "kubectl.kubernetes.io/last-applied-configuration" {
+			continue
+		}
+		if matches(key) || matches(annotations[key]) {
+			add("annotations."+key, annotations[key])
+		}
+	}
+
+	for _, path := range podSpecPaths {
+		for _, list := range []string{"initContainers", "containers"} {
+			containers, _, _ := unstructured.NestedSlice(obj.Object, append(path, list)...)
+			for _, container := range containers {
+				c, _ := container.(map[string]interface{})
+				image, _ := c["image"].(string)
+				if image != "" && matches(image) {
+					name, _ := c["name"].(string)
+					add(fmt.Sprintf("%s[%s].image", list, name), image)
+				}
+			}
+		}
+	}
+
+	return hits
+}
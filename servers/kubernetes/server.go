@@ -5,25 +5,67 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/mcp-servers/cli/pkg/llm"
 	"github.com/mcp-servers/cli/pkg/mcp"
 	"github.com/sirupsen/logrus"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+// Server identification reported to clients
+const (
+	serverName    = "kubernetes-mcp-server"
+	serverVersion = "1.0.0"
+)
+
+// DefaultDrainTimeout is how long Stop waits for in-flight requests to finish
+const DefaultDrainTimeout = 30 * time.Second
+
+// DefaultSampleInterval is how far apart get_resource_trend takes metrics-server readings when Prometheus is not configured
+const DefaultSampleInterval = 30 * time.Second
+
 // Server represents a Kubernetes MCP server
 type Server struct {
-	clientset *kubernetes.Clientset
-	config    *rest.Config
-	server    *http.Server
-	logger    *logrus.Logger
+	clientset     *kubernetes.Clientset
+	dynamicClient dynamic.Interface
+	config        *rest.Config
+	server        *http.Server
+	logger        *logrus.Logger
+	openAPISpec   []byte
+	drainTimeout  time.Duration
+	inFlight      sync.WaitGroup
+	activeCount   atomic.Int64
+	kmsEndpoint   string
+	kubeconfig    string
+	kubectlPath   string
+
+	prometheusEndpoint   string
+	compressionThreshold int
+	sampleInterval       time.Duration
+	llmProvider          llm.Provider
+	healthWeights        HealthScoreWeights
+
+	plugins   map[string]ToolPlugin
+	pluginsMu sync.RWMutex
+
+	// sessions lets clients that re-initialize shortly after a network blip resume their session
+	sessions *sessionStore
+
+	// eventClients maps /mcp/events client IDs to their chan []byte of pending events
+	eventClients   sync.Map
+	eventClientSeq atomic.Uint64
+	eventsDone     chan struct{}
 }
 
 // NewServer creates a new Kubernetes MCP server
@@ -46,37 +88,137 @@ func NewServer(kubeconfig string) (*Server, error) {
 		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
 	return &Server{
-		clientset: clientset,
-		config:    config,
-		logger:    logrus.New(),
+		clientset:     clientset,
+		dynamicClient: dynamicClient,
+		config:        config,
+		logger:        logrus.New(),
+		drainTimeout:  DefaultDrainTimeout,
+		kubeconfig:    kubeconfig,
+		kubectlPath:   "kubectl",
+		plugins:       make(map[string]ToolPlugin),
+		sessions:      newSessionStore(sessionResumeWindow),
+		eventsDone:    make(chan struct{}),
+
+		compressionThreshold: mcp.DefaultCompressionThreshold,
+		sampleInterval:       DefaultSampleInterval,
+		healthWeights:        DefaultHealthScoreWeights,
 	}, nil
 }
 
+// SetKubectlPath sets the kubectl binary used by exec_kubectl
+func (s *Server) SetKubectlPath(path string) {
+	s.kubectlPath = path
+}
+
+// SetPrometheusEndpoint sets the default Prometheus URL used by the Prometheus tools
+func (s *Server) SetPrometheusEndpoint(endpoint string) {
+	s.prometheusEndpoint = endpoint
+}
+
+// SetKMSEndpoint sets the KMS endpoint used to protect sealed Secret data keys
+func (s *Server) SetKMSEndpoint(endpoint string) {
+	s.kmsEndpoint = endpoint
+}
+
+// SetCompressionThreshold sets the resource content size in bytes above which it is gzip-compressed; zero disables compression
+func (s *Server) SetCompressionThreshold(threshold int) {
+	s.compressionThreshold = threshold
+}
+
+// SetSampleInterval sets the spacing of the metrics-server readings used by get_resource_trend without Prometheus
+func (s *Server) SetSampleInterval(interval time.Duration) {
+	s.sampleInterval = interval
+}
+
+// SetHealthScoreWeights sets the weights health_score_namespace combines its part scores with
+func (s *Server) SetHealthScoreWeights(weights HealthScoreWeights) {
+	s.healthWeights = weights
+}
+
+// SetLLMProvider sets the LLM used by explain_error to diagnose failing pods
+func (s *Server) SetLLMProvider(provider llm.Provider) {
+	s.llmProvider = provider
+}
+
+// SetDrainTimeout sets how long Stop waits for in-flight requests to finish
+func (s *Server) SetDrainTimeout(timeout time.Duration) {
+	s.drainTimeout = timeout
+}
+
 // Start starts the MCP server
 func (s *Server) Start(addr string) error {
+	spec, err := buildOpenAPISpec(s.listTools(), serverName, serverVersion)
+	if err != nil {
+		return fmt.Errorf("failed to build OpenAPI spec: %w", err)
+	}
+	s.openAPISpec = spec
+
 	mux := http.NewServeMux()
 	mux.HandleFunc("/mcp", s.handleMCP)
+	mux.HandleFunc("/mcp/events", s.handleEvents)
+	mux.HandleFunc("/openapi.json", s.handleOpenAPI)
+	mux.HandleFunc("/tools/", s.handleToolREST)
 
 	s.server = &http.Server{
 		Addr:    addr,
 		Handler: mux,
 	}
+	// Event streams never finish on their own, so end them when shutdown begins
+	s.server.RegisterOnShutdown(func() { close(s.eventsDone) })
 
 	s.logger.Infof("Starting Kubernetes MCP server on %s", addr)
-	return s.server.ListenAndServe()
+	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
 }
 
-// Stop stops the MCP server
+// Stop stops the MCP server, waiting up to the drain timeout for in-flight requests
 func (s *Server) Stop() error {
-	if s.server != nil {
-		return s.server.Shutdown(context.Background())
+	if s.server == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.drainTimeout)
+	defer cancel()
+
+	s.logger.Infof("Shutting down, draining %d in-flight request(s)", s.activeCount.Load())
+	shutdownErr := s.server.Shutdown(ctx)
+
+	drained := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		s.logger.Info("All in-flight requests completed")
+	case <-ctx.Done():
+		s.logger.Warnf("Drain timeout of %s exceeded, abandoning %d in-flight request(s)", s.drainTimeout, s.activeCount.Load())
+	}
+
+	if shutdownErr != nil {
+		return fmt.Errorf("failed to shut down server: %w", shutdownErr)
 	}
 	return nil
 }
 
 // handleMCP handles MCP protocol messages
 func (s *Server) handleMCP(w http.ResponseWriter, r *http.Request) {
+	s.inFlight.Add(1)
+	s.activeCount.Add(1)
+	defer func() {
+		s.activeCount.Add(-1)
+		s.inFlight.Done()
+	}()
+
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -113,6 +255,8 @@ func (s *Server) handleMessage(msg *mcp.Message) (*mcp.Message, error) {
 		return s.handleReadResource(msg)
 	case mcp.MessageTypeListTools:
 		return s.handleListTools(msg)
+	case mcp.MessageTypeDescribeTools:
+		return s.handleDescribeTools(msg)
 	case mcp.MessageTypeCallTool:
 		return s.handleCallTool(msg)
 	case mcp.MessageTypePing:
@@ -129,6 +273,11 @@ func (s *Server) handleInitialize(msg *mcp.Message) (*mcp.Message, error) {
 		return nil, fmt.Errorf("failed to unmarshal initialize request: %w", err)
 	}
 
+	if response, ok := s.sessions.resume(req.ClientInfo); ok {
+		s.logger.Debugf("Resumed session for client %s", sessionKey(req.ClientInfo))
+		return mcp.NewMessage(mcp.MessageTypeInitialization, msg.ID, response)
+	}
+
 	response := mcp.InitializationResponse{
 		ProtocolVersion: mcp.ProtocolVersion,
 		Capabilities: mcp.ServerCapabilities{
@@ -136,10 +285,11 @@ func (s *Server) handleInitialize(msg *mcp.Message) (*mcp.Message, error) {
 			Tools:     mcp.ToolCapabilities{Call: true},
 		},
 		ServerInfo: mcp.ServerInfo{
-			Name:    "kubernetes-mcp-server",
-			Version: "1.0.0",
+			Name:    serverName,
+			Version: serverVersion,
 		},
 	}
+	s.sessions.store(req.ClientInfo, response)
 
 	return mcp.NewMessage(mcp.MessageTypeInitialization, msg.ID, response)
 }
@@ -204,7 +354,7 @@ func (s *Server) handleReadResource(msg *mcp.Message) (*mcp.Message, error) {
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get resource %s: %w", req.URI, err)
+		return nil, fmt.Errorf("failed to get resource %s: %w", req.URI, enrichKubernetesError(err, req.URI, ""))
 	}
 
 	contentBytes, err := json.Marshal(content)
@@ -217,12 +367,34 @@ func (s *Server) handleReadResource(msg *mcp.Message) (*mcp.Message, error) {
 		Content:  contentBytes,
 		MimeType: "application/json",
 	}
+	if err := resource.Compress(s.compressionThreshold); err != nil {
+		return nil, err
+	}
 
 	return mcp.NewMessage("readResource", msg.ID, resource)
 }
 
 // handleListTools handles tool listing requests
 func (s *Server) handleListTools(msg *mcp.Message) (*mcp.Message, error) {
+	return mcp.NewMessage("listTools", msg.ID, map[string]interface{}{
+		"tools": s.listTools(),
+	})
+}
+
+// handleDescribeTools handles requests for the detailed, self-documenting tool list
+func (s *Server) handleDescribeTools(msg *mcp.Message) (*mcp.Message, error) {
+	tools := s.listTools()
+	descriptions := make([]mcp.ToolDescription, 0, len(tools))
+	for _, tool := range tools {
+		descriptions = append(descriptions, mcp.DescribeTool(tool))
+	}
+	return mcp.NewMessage(mcp.MessageTypeDescribeTools, msg.ID, map[string]interface{}{
+		"tools": descriptions,
+	})
+}
+
+// listTools returns every tool the server can execute
+func (s *Server) listTools() []mcp.Tool {
 	tools := []mcp.Tool{
 		{
 			Name:        "get_pods",
@@ -237,6 +409,24 @@ func (s *Server) handleListTools(msg *mcp.Message) (*mcp.Message, error) {
 				},
 			},
 		},
+		{
+			Name:        "get_deployment",
+			Description: "Get the current replica status of a deployment",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment",
+					},
+				},
+				"required": []string{"name", "namespace"},
+			},
+		},
 		{
 			Name:        "create_deployment",
 			Description: "Create a new deployment",
@@ -259,6 +449,24 @@ func (s *Server) handleListTools(msg *mcp.Message) (*mcp.Message, error) {
 						"type":        "integer",
 						"description": "Number of replicas",
 					},
+					"labels": map[string]interface{}{
+						"type":                 "object",
+						"description":          "Labels for the deployment and its pods, e.g. {\"owner\":\"team-a\"} (optional)",
+						"additionalProperties": map[string]interface{}{"type": "string"},
+					},
+					"strategy_type": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"Recreate", "RollingUpdate"},
+						"description": "Deployment strategy type (optional)",
+					},
+					"max_surge": map[string]interface{}{
+						"oneOf":       intOrPercentSchema,
+						"description": "Maximum pods above the desired count during a RollingUpdate, as an integer or percentage such as \"25%\"",
+					},
+					"max_unavailable": map[string]interface{}{
+						"oneOf":       intOrPercentSchema,
+						"description": "Maximum unavailable pods during a RollingUpdate, as an integer or percentage such as \"25%\"",
+					},
 				},
 				"required": []string{"name", "namespace", "image"},
 			},
@@ -287,7 +495,7 @@ func (s *Server) handleListTools(msg *mcp.Message) (*mcp.Message, error) {
 		},
 		{
 			Name:        "delete_pod",
-			Description: "Delete a pod",
+			Description: "Delete a pod; force deletes a pod stuck in Terminating immediately",
 			InputSchema: map[string]interface{}{
 				"type": "object",
 				"properties": map[string]interface{}{
@@ -299,15 +507,83 @@ func (s *Server) handleListTools(msg *mcp.Message) (*mcp.Message, error) {
 						"type":        "string",
 						"description": "Namespace of the pod",
 					},
+					"force": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Delete with a grace period of zero, without waiting for the kubelet to confirm the containers stopped",
+					},
+					"force_anyway": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Confirm a force delete of a pod that has been terminating for less than 5 minutes, or is not terminating",
+					},
 				},
 				"required": []string{"name", "namespace"},
 			},
 		},
 	}
-
-	return mcp.NewMessage("listTools", msg.ID, map[string]interface{}{
-		"tools": tools,
-	})
+	tools = append(tools, istioTools()...)
+	tools = append(tools, configMapTools()...)
+	tools = append(tools, sealedSecretTools()...)
+	tools = append(tools, kubectlTools()...)
+	tools = append(tools, strategyTools()...)
+	tools = append(tools, dependencyTools()...)
+	tools = append(tools, manifestTools()...)
+	tools = append(tools, healthTools()...)
+	tools = append(tools, customResourceTools()...)
+	tools = append(tools, copyTools()...)
+	tools = append(tools, drainTools()...)
+	tools = append(tools, labelTools()...)
+	tools = append(tools, prometheusTools()...)
+	tools = append(tools, gitOpsTools()...)
+	tools = append(tools, remediationTools()...)
+	tools = append(tools, capacityTools()...)
+	tools = append(tools, kubeconfigTools()...)
+	tools = append(tools, networkPolicyTools()...)
+	tools = append(tools, discoveryTools()...)
+	tools = append(tools, permissionTools()...)
+	tools = append(tools, limitRangeTools()...)
+	tools = append(tools, scaleToZeroTools()...)
+	tools = append(tools, diffTools()...)
+	tools = append(tools, trendTools()...)
+	tools = append(tools, explainTools()...)
+	tools = append(tools, resourceQuotaTools()...)
+	tools = append(tools, batchTools()...)
+	tools = append(tools, migrateTools()...)
+	tools = append(tools, annotateTools()...)
+	tools = append(tools, topTools()...)
+	tools = append(tools, warningTools()...)
+	tools = append(tools, helmTools()...)
+	tools = append(tools, snapshotTools()...)
+	tools = append(tools, podSecurityTools()...)
+	tools = append(tools, dockerfileTools()...)
+	tools = append(tools, compareTools()...)
+	tools = append(tools, taintTools()...)
+	tools = append(tools, imagePullTools()...)
+	tools = append(tools, recentTools()...)
+	tools = append(tools, validateTools()...)
+	tools = append(tools, resourceTools()...)
+	tools = append(tools, searchTools()...)
+	tools = append(tools, kustomizeTools()...)
+	tools = append(tools, namespaceHealthTools()...)
+	tools = append(tools, orphanTools()...)
+	tools = append(tools, endpointTools()...)
+	tools = append(tools, cronJobTools()...)
+	tools = append(tools, autoscalingTools()...)
+	tools = append(tools, imageInfoTools()...)
+	tools = append(tools, applyURLTools()...)
+	tools = append(tools, policyTools()...)
+	tools = append(tools, topologyTools()...)
+	tools = append(tools, pdbTools()...)
+	tools = append(tools, rbacPolicyTools()...)
+	tools = append(tools, connectivityTools()...)
+	tools = append(tools, rollingUpdateTools()...)
+	tools = append(tools, serviceGraphTools()...)
+	tools = append(tools, jobFromDeploymentTools()...)
+	tools = append(tools, scaleDurationTools()...)
+	tools = append(tools, oomTools()...)
+	tools = append(tools, certificateTools()...)
+	tools = append(tools, s.pluginTools()...)
+
+	return tools
 }
 
 // handleCallTool handles tool execution requests
@@ -317,27 +593,212 @@ func (s *Server) handleCallTool(msg *mcp.Message) (*mcp.Message, error) {
 		return nil, fmt.Errorf("failed to unmarshal tool call request: %w", err)
 	}
 
+	result, err := s.callTool(req.Name, req.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewMessage("callTool", msg.ID, result)
+}
+
+// callTool executes a tool by name
+func (s *Server) callTool(name string, args map[string]interface{}) (*mcp.ToolResult, error) {
 	var result *mcp.ToolResult
 	var err error
 
-	switch req.Name {
+	switch name {
 	case "get_pods":
-		result, err = s.getPodsTool(req.Arguments)
+		result, err = s.getPodsTool(args)
+	case "get_deployment":
+		result, err = s.getDeploymentTool(args)
 	case "create_deployment":
-		result, err = s.createDeploymentTool(req.Arguments)
+		result, err = s.createDeploymentTool(args)
 	case "scale_deployment":
-		result, err = s.scaleDeploymentTool(req.Arguments)
+		result, err = s.scaleDeploymentTool(args)
 	case "delete_pod":
-		result, err = s.deletePodTool(req.Arguments)
+		result, err = s.deletePodTool(args)
+	case "list_virtualservices":
+		result, err = s.listVirtualServicesTool(args)
+	case "create_virtualservice":
+		result, err = s.createVirtualServiceTool(args)
+	case "list_destinationrules":
+		result, err = s.listDestinationRulesTool(args)
+	case "get_service_mesh_status":
+		result, err = s.getServiceMeshStatusTool(args)
+	case "render_configmap_template":
+		result, err = s.renderConfigMapTemplateTool(args)
+	case "seal_secret":
+		result, err = s.sealSecretTool(args)
+	case "unseal_secret":
+		result, err = s.unsealSecretTool(args)
+	case "exec_kubectl":
+		result, err = s.execKubectlTool(args)
+	case "update_rollout_strategy":
+		result, err = s.updateRolloutStrategyTool(args)
+	case "get_resource_dependencies":
+		result, err = s.getResourceDependenciesTool(args)
+	case "apply_manifest":
+		result, err = s.applyManifestTool(args)
+	case "get_deployment_health":
+		result, err = s.getDeploymentHealthTool(args)
+	case "list_custom_resources":
+		result, err = s.listCustomResourcesTool(args)
+	case "get_custom_resource":
+		result, err = s.getCustomResourceTool(args)
+	case "delete_custom_resource":
+		result, err = s.deleteCustomResourceTool(args)
+	case "copy_secret":
+		result, err = s.copySecretTool(args)
+	case "copy_configmap":
+		result, err = s.copyConfigMapTool(args)
+	case "drain_node":
+		result, err = s.drainNodeTool(args)
+	case "sync_labels":
+		result, err = s.syncLabelsTool(args)
+	case "query_prometheus":
+		result, err = s.queryPrometheusTool(args)
+	case "list_prometheus_metrics":
+		result, err = s.listPrometheusMetricsTool(args)
+	case "get_gitops_status":
+		result, err = s.getGitOpsStatusTool(args)
+	case "restart_failed_pods":
+		result, err = s.restartFailedPodsTool(args)
+	case "get_cluster_capacity":
+		result, err = s.getClusterCapacityTool(args)
+	case "generate_kubeconfig":
+		result, err = s.generateKubeconfigTool(args)
+	case "analyze_and_generate_networkpolicy":
+		result, err = s.analyzeAndGenerateNetworkPolicyTool(args)
+	case "simulate_networkpolicy":
+		result, err = s.simulateNetworkPolicyTool(args)
+	case "get_cluster_version":
+		result, err = s.getClusterVersionTool(args)
+	case "list_api_resources":
+		result, err = s.listAPIResourcesTool(args)
+	case "verify_permissions":
+		result, err = s.verifyPermissionsTool(args)
+	case "list_limitranges":
+		result, err = s.listLimitRangesTool(args)
+	case "create_limitrange":
+		result, err = s.createLimitRangeTool(args)
+	case "scale_to_zero":
+		result, err = s.scaleToZeroTool(args)
+	case "wake_up_deployment":
+		result, err = s.wakeUpDeploymentTool(args)
+	case "diff_config":
+		result, err = s.diffConfigTool(args)
+	case "get_resource_trend":
+		result, err = s.resourceTrendTool(args)
+	case "explain_error":
+		result, err = s.explainErrorTool(args)
+	case "list_resource_quotas":
+		result, err = s.listResourceQuotasTool(args)
+	case "create_resource_quota":
+		result, err = s.createResourceQuotaTool(args)
+	case "delete_resource_quota":
+		result, err = s.deleteResourceQuotaTool(args)
+	case "get_quota_usage":
+		result, err = s.getQuotaUsageTool(args)
+	case "batch_apply_manifests":
+		result, err = s.batchApplyManifestsTool(args)
+	case "migrate_deployment":
+		result, err = s.migrateDeploymentTool(args)
+	case "bulk_annotate":
+		result, err = s.bulkAnnotateTool(args)
+	case "get_top_pods":
+		result, err = s.getTopPodsTool(args)
+	case "get_top_nodes":
+		result, err = s.getTopNodesTool(args)
+	case "list_warning_events":
+		result, err = s.listWarningEventsTool(args)
+	case "generate_helm_values":
+		result, err = s.generateHelmValuesTool(args)
+	case "take_snapshot":
+		result, err = s.takeSnapshotTool(args)
+	case "restore_snapshot":
+		result, err = s.restoreSnapshotTool(args)
+	case "analyze_pod_security":
+		result, err = s.analyzePodSecurityTool(args)
+	case "generate_dockerfile":
+		result, err = s.generateDockerfileTool(args)
+	case "compare_deployments":
+		result, err = s.compareDeploymentsTool(args)
+	case "taint_node":
+		result, err = s.taintNodeTool(args)
+	case "untaint_node":
+		result, err = s.untaintNodeTool(args)
+	case "add_toleration_to_deployment":
+		result, err = s.addTolerationToDeploymentTool(args)
+	case "list_image_pull_errors":
+		result, err = s.listImagePullErrorsTool(args)
+	case "get_recent_deployments":
+		result, err = s.getRecentDeploymentsTool(args)
+	case "validate_manifest":
+		result, err = s.validateManifestTool(args)
+	case "get_resource":
+		result, err = s.getResourceTool(args)
+	case "search_resources":
+		result, err = s.searchResourcesTool(args)
+	case "apply_kustomization":
+		result, err = s.applyKustomizationTool(args)
+	case "health_score_namespace":
+		result, err = s.healthScoreNamespaceTool(args)
+	case "list_orphaned_resources":
+		result, err = s.listOrphanedResourcesTool(args)
+	case "cleanup_orphaned_resources":
+		result, err = s.cleanupOrphanedResourcesTool(args)
+	case "get_service_endpoints":
+		result, err = s.getServiceEndpointsTool(args)
+	case "list_jobs_by_cronjob":
+		result, err = s.listJobsByCronJobTool(args)
+	case "get_last_job_logs":
+		result, err = s.getLastJobLogsTool(args)
+	case "simulate_autoscaling":
+		result, err = s.simulateAutoscalingTool(args)
+	case "get_container_image_info":
+		result, err = s.getContainerImageInfoTool(args)
+	case "compare_image_versions":
+		result, err = s.compareImageVersionsTool(args)
+	case "apply_manifest_from_url":
+		result, err = s.applyManifestFromURLTool(args)
+	case "apply_namespace_policy":
+		result, err = s.applyNamespacePolicyTool(args)
+	case "get_deployment_topology":
+		result, err = s.getDeploymentTopologyTool(args)
+	case "list_pod_disruptions":
+		result, err = s.listPodDisruptionsTool(args)
+	case "generate_rbac_policy":
+		result, err = s.generateRBACPolicyTool(args)
+	case "test_connectivity":
+		result, err = s.testConnectivityTool(args)
+	case "rolling_update_image":
+		result, err = s.rollingUpdateImageTool(args)
+	case "get_service_graph":
+		result, err = s.getServiceGraphTool(args)
+	case "create_job_from_deployment":
+		result, err = s.createJobFromDeploymentTool(args)
+	case "estimate_scale_duration":
+		result, err = s.estimateScaleDurationTool(args)
+	case "diagnose_oom_killed_pods":
+		result, err = s.diagnoseOOMKilledPodsTool(args)
+	case "get_certificate_expiry":
+		result, err = s.getCertificateExpiryTool(args)
 	default:
-		return nil, fmt.Errorf("unknown tool: %s", req.Name)
+		plugin, ok := s.plugin(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown tool: %s", name)
+		}
+		result, err = plugin.Execute(context.Background(), args)
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("tool execution failed: %w", err)
+		err = enrichKubernetesError(err, optionalStringArg(args, "name", ""), optionalStringArg(args, "namespace", ""))
+		s.logger.Errorf("Tool %s failed: %v", name, err)
+		// Report the failure in the result so callers see the recovery suggestion
+		return mcp.NewToolError(fmt.Errorf("tool execution failed: %w", err), failureReason(err)), nil
 	}
 
-	return mcp.NewMessage("callTool", msg.ID, result)
+	return result, nil
 }
 
 // handlePing handles ping requests
@@ -414,23 +875,15 @@ func (s *Server) getDeployments() (interface{}, error) {
 }
 
 func (s *Server) getNodes() (interface{}, error) {
-	nodes, err := s.clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	nodes, metricsAvailable, err := s.nodeCapacities(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
-	var simplifiedNodes []map[string]interface{}
-	for _, node := range nodes.Items {
-		simplifiedNodes = append(simplifiedNodes, map[string]interface{}{
-			"name":   node.Name,
-			"status": node.Status.Conditions[len(node.Status.Conditions)-1].Type,
-			"age":    time.Since(node.CreationTimestamp.Time).String(),
-		})
-	}
-
 	return map[string]interface{}{
-		"nodes": simplifiedNodes,
-		"total": len(simplifiedNodes),
+		"nodes":             nodes,
+		"total":             len(nodes),
+		"metrics_available": metricsAvailable,
 	}, nil
 }
 
@@ -469,6 +922,42 @@ func (s *Server) getPodsTool(args map[string]interface{}) (*mcp.ToolResult, erro
 	}, nil
 }
 
+func (s *Server) getDeploymentTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name := args["name"].(string)
+	namespace := args["namespace"].(string)
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	status, err := json.Marshal(map[string]interface{}{
+		"name":      deployment.Name,
+		"namespace": deployment.Namespace,
+		"replicas":  replicas,
+		"available": deployment.Status.AvailableReplicas,
+		"ready":     deployment.Status.ReadyReplicas,
+		"updated":   deployment.Status.UpdatedReplicas,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal deployment status: %w", err)
+	}
+
+	return &mcp.ToolResult{
+		Content: []mcp.ToolResultContent{
+			{
+				Type: "text",
+				Text: string(status),
+			},
+		},
+	}, nil
+}
+
 func (s *Server) createDeploymentTool(args map[string]interface{}) (*mcp.ToolResult, error) {
 	name := args["name"].(string)
 	namespace := args["namespace"].(string)
@@ -477,11 +966,32 @@ func (s *Server) createDeploymentTool(args map[string]interface{}) (*mcp.ToolRes
 	if r, ok := args["replicas"].(float64); ok {
 		replicas = int32(r)
 	}
+	strategy, err := buildDeploymentStrategy(args)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{}
+	if rawLabels, ok := args["labels"].(map[string]interface{}); ok {
+		for key, value := range rawLabels {
+			text, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("labels.%s must be a string", key)
+			}
+			labels[key] = text
+		}
+	}
+	podLabels := map[string]string{}
+	for key, value := range labels {
+		podLabels[key] = value
+	}
+	podLabels["app"] = name
 
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
+			Labels:    labels,
 		},
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &replicas,
@@ -490,7 +1000,7 @@ func (s *Server) createDeploymentTool(args map[string]interface{}) (*mcp.ToolRes
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{"app": name},
+					Labels: podLabels,
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
@@ -504,16 +1014,37 @@ func (s *Server) createDeploymentTool(args map[string]interface{}) (*mcp.ToolRes
 		},
 	}
 
-	_, err := s.clientset.AppsV1().Deployments(namespace).Create(context.Background(), deployment, metav1.CreateOptions{})
+	if strategy != nil {
+		deployment.Spec.Strategy = *strategy
+	}
+
+	ctx := context.Background()
+	policy, err := s.namespacePolicy(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	var defaulted []string
+	if policy != nil {
+		defaulted = policy.defaultLimits(&deployment.Spec.Template.Spec)
+		if err := policy.check(deployment); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = s.clientset.AppsV1().Deployments(namespace).Create(ctx, deployment, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
 
+	text := fmt.Sprintf("Successfully created deployment '%s' in namespace '%s' with %d replicas", name, namespace, replicas)
+	if len(defaulted) > 0 {
+		text += fmt.Sprintf("; the namespace policy set pod limits of %s", strings.Join(defaulted, " and "))
+	}
 	return &mcp.ToolResult{
 		Content: []mcp.ToolResultContent{
 			{
 				Type: "text",
-				Text: fmt.Sprintf("Successfully created deployment '%s' in namespace '%s' with %d replicas", name, namespace, replicas),
+				Text: text,
 			},
 		},
 	}, nil
@@ -524,13 +1055,26 @@ func (s *Server) scaleDeploymentTool(args map[string]interface{}) (*mcp.ToolResu
 	namespace := args["namespace"].(string)
 	replicas := int32(args["replicas"].(float64))
 
-	scale, err := s.clientset.AppsV1().Deployments(namespace).GetScale(context.Background(), name, metav1.GetOptions{})
+	ctx := context.Background()
+	scale, err := s.clientset.AppsV1().Deployments(namespace).GetScale(ctx, name, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
 
+	// Scaling down is always allowed, so a policy never keeps a namespace from shedding load
+	if replicas > scale.Spec.Replicas {
+		deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		deployment.Spec.Replicas = &replicas
+		if err := s.enforceNamespacePolicy(ctx, deployment); err != nil {
+			return nil, err
+		}
+	}
+
 	scale.Spec.Replicas = replicas
-	_, err = s.clientset.AppsV1().Deployments(namespace).UpdateScale(context.Background(), name, scale, metav1.UpdateOptions{})
+	_, err = s.clientset.AppsV1().Deployments(namespace).UpdateScale(ctx, name, scale, metav1.UpdateOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -549,6 +1093,10 @@ func (s *Server) deletePodTool(args map[string]interface{}) (*mcp.ToolResult, er
 	name := args["name"].(string)
 	namespace := args["namespace"].(string)
 
+	if boolArg(args, "force") {
+		return s.forceDeletePod(name, namespace, boolArg(args, "force_anyway"))
+	}
+
 	err := s.clientset.CoreV1().Pods(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
 	if err != nil {
 		return nil, err
@@ -563,3 +1111,76 @@ func (s *Server) deletePodTool(args map[string]interface{}) (*mcp.ToolResult, er
 		},
 	}, nil
 }
+
+// Tool argument and result helpers
+
+// stringArg returns a required, non-empty string argument
+func stringArg(args map[string]interface{}, key string) (string, error) {
+	value, ok := args[key].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("%s is required", key)
+	}
+	return value, nil
+}
+
+// optionalStringArg returns a string argument or the default when it is missing
+func optionalStringArg(args map[string]interface{}, key, defaultValue string) string {
+	if value, ok := args[key].(string); ok && value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// intArg returns a numeric argument, accepting JSON numbers and numeric strings
+func intArg(args map[string]interface{}, key string, defaultValue int) (int, error) {
+	switch value := args[key].(type) {
+	case nil:
+		return defaultValue, nil
+	case float64:
+		return int(value), nil
+	case int:
+		return value, nil
+	case string:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, fmt.Errorf("%s must be an integer: %w", key, err)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("%s must be an integer", key)
+	}
+}
+
+// boolArg returns a boolean argument, treating missing values as false
+func boolArg(args map[string]interface{}, key string) bool {
+	switch value := args[key].(type) {
+	case bool:
+		return value
+	case string:
+		b, _ := strconv.ParseBool(value)
+		return b
+	default:
+		return false
+	}
+}
+
+// textResult wraps text in a tool result
+func textResult(text string) *mcp.ToolResult {
+	return &mcp.ToolResult{
+		Content: []mcp.ToolResultContent{
+			{
+				Type: "text",
+				Text: text,
+			},
+		},
+	}
+}
+
+// jsonResult marshals a value into an indented JSON tool result
+func jsonResult(v interface{}) (*mcp.ToolResult, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool result: %w", err)
+	}
+	return textResult(string(data)), nil
+}
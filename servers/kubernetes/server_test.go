@@ -0,0 +1,121 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"github.com/sirupsen/logrus"
+)
+
+// slowPlugin is a tool that reports when it starts and then blocks until release is closed
+type slowPlugin struct {
+	started  chan struct{}
+	release  chan struct{}
+	finished atomic.Bool
+}
+
+func (p *slowPlugin) Name() string        { return "slow" }
+func (p *slowPlugin) Description() string { return "Blocks until released" }
+func (p *slowPlugin) InputSchema() map[string]interface{} {
+	return map[string]interface{}{"type": "object"}
+}
+func (p *slowPlugin) Execute(ctx context.Context, args map[string]interface{}) (*mcp.ToolResult, error) {
+	close(p.started)
+	<-p.release
+	p.finished.Store(true)
+	return textResult("done"), nil
+}
+
+// startDrainServer serves /mcp on a local port with a slow plugin tool and returns its URL
+func startDrainServer(t *testing.T, drainTimeout time.Duration) (*Server, *slowPlugin, string) {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	plugin := &slowPlugin{started: make(chan struct{}), release: make(chan struct{})}
+	s := &Server{
+		logger:       logger,
+		drainTimeout: drainTimeout,
+		plugins:      map[string]ToolPlugin{plugin.Name(): plugin},
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s.server = &http.Server{Handler: http.HandlerFunc(s.handleMCP)}
+	go s.server.Serve(listener)
+	return s, plugin, "http://" + listener.Addr().String()
+}
+
+// callSlowTool calls the slow tool and sends the response status, or 0 when the request failed, on the returned channel
+func callSlowTool(t *testing.T, url string) <-chan int {
+	t.Helper()
+	msg, err := mcp.NewMessage(mcp.MessageTypeCallTool, "1", mcp.ToolCall{Name: "slow"})
+	if err != nil {
+		t.Fatalf("failed to build message: %v", err)
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("failed to marshal message: %v", err)
+	}
+
+	status := make(chan int, 1)
+	go func() {
+		resp, err := http.Post(url+"/mcp", "application/json", bytes.NewReader(data))
+		if err != nil {
+			status <- 0
+			return
+		}
+		resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+	return status
+}
+
+func TestStopWaitsForInFlightRequests(t *testing.T) {
+	s, plugin, url := startDrainServer(t, 5*time.Second)
+	status := callSlowTool(t, url)
+	<-plugin.started
+
+	time.AfterFunc(200*time.Millisecond, func() { close(plugin.release) })
+	if err := s.Stop(); err != nil {
+		t.Fatalf("Stop returned %v, want nil", err)
+	}
+	if !plugin.finished.Load() {
+		t.Fatal("Stop returned before the in-flight request finished")
+	}
+	if code := <-status; code != http.StatusOK {
+		t.Errorf("in-flight request got status %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestStopAbandonsRequestsAfterDrainTimeout(t *testing.T) {
+	s, plugin, url := startDrainServer(t, 100*time.Millisecond)
+	defer close(plugin.release)
+
+	status := callSlowTool(t, url)
+	<-plugin.started
+	start := time.Now()
+	if err := s.Stop(); err == nil {
+		t.Error("Stop returned nil, want the drain timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Stop took %s, want it to give up after the 100ms drain timeout", elapsed)
+	}
+	if plugin.finished.Load() {
+		t.Error("the blocked request finished, want it still running")
+	}
+	select {
+	case <-status:
+		t.Error("the blocked request got a response before it was released")
+	default:
+	}
+}
@@ -0,0 +1,338 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Service graph node types
+const (
+	graphNodeService  = "service"
+	graphNodeWorkload = "workload"
+	graphNodeGateway  = "gateway"
+	graphNodeExternal = "external"
+)
+
+// serviceGraphTools returns the service dependency graph tool definitions
+func serviceGraphTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_service_graph",
+			Description: "Map which services of a namespace call which: follows each Service to the pods behind its endpoints and finds references to other services in their environment, arguments and ConfigMaps, adding Istio VirtualService routes when Istio is installed",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to map (defaults to default)",
+					},
+				},
+			},
+		},
+	}
+}
+
+// serviceGraphNode is a service, a workload without a service, an Istio gateway or an external host
+type serviceGraphNode struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Pods int    `json:"pods,omitempty"`
+}
+
+// serviceGraphEdge is a connection from one node to another, identified as type/name
+type serviceGraphEdge struct {
+	From           string `json:"from"`
+	To             string `json:"to"`
+	ConnectionType string `json:"connection_type"`
+	Detail         string `json:"detail,omitempty"`
+}
+
+// serviceGraph collects nodes and edges without duplicates
+type serviceGraph struct {
+	nodes map[string]*serviceGraphNode
+	edges map[string]serviceGraphEdge
+}
+
+// addNode adds a node if not present and returns its type/name identifier
+func (g *serviceGraph) addNode(nodeType, name string) string {
+	id := nodeType + "/" + name
+	if g.nodes[id] == nil {
+		g.nodes[id] = &serviceGraphNode{ID: id, Name: name, Type: nodeType}
+	}
+	return id
+}
+
+// addEdge adds an edge, keeping the first detail seen for each connection type between two nodes
+func (g *serviceGraph) addEdge(from, to, connectionType, detail string) {
+	key := from + "|" + to + "|" + connectionType
+	if _, ok := g.edges[key]; !ok {
+		g.edges[key] = serviceGraphEdge{From: from, To: to, ConnectionType: connectionType, Detail: detail}
+	}
+}
+
+func (s *Server) getServiceGraphTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "default")
+	ctx := context.Background()
+
+	services, err := s.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	endpoints, err := s.clientset.CoreV1().Endpoints(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	configMaps, err := s.clientset.CoreV1().ConfigMaps(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	graph := &serviceGraph{nodes: map[string]*serviceGraphNode{}, edges: map[string]serviceGraphEdge{}}
+	servicesByName := map[string]bool{}
+	endpointsByName := map[string]corev1.Endpoints{}
+	for _, endpoint := range endpoints.Items {
+		endpointsByName[endpoint.Name] = endpoint
+	}
+	configMapData := map[string]map[string]string{}
+	for _, configMap := range configMaps.Items {
+		configMapData[configMap.Name] = configMap.Data
+	}
+
+	// servedBy maps each pod to the services whose endpoints point at it
+	servedBy := map[string][]string{}
+	for _, service := range services.Items {
+		servicesByName[service.Name] = true
+		id := graph.addNode(graphNodeService, service.Name)
+		backing := serviceBackingPods(service, endpointsByName[service.Name], pods.Items)
+		graph.nodes[id].Pods = len(backing)
+		for _, pod := range backing {
+			servedBy[pod] = append(servedBy[pod], id)
+		}
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		references := podServiceReferences(pod, services.Items, configMapData)
+		if len(references) == 0 {
+			continue
+		}
+		sources := servedBy[pod.Name]
+		if len(sources) == 0 {
+			// Clients without a service of their own, such as workers and jobs, appear as their workload
+			sources = []string{graph.addNode(graphNodeWorkload, podWorkload(pod))}
+		}
+		for _, source := range sources {
+			for _, reference := range references {
+				target := graphNodeService + "/" + reference.service
+				if target != source {
+					graph.addEdge(source, target, reference.connectionType, reference.detail)
+				}
+			}
+		}
+	}
+
+	istio, notes := true, []string{"Edges are inferred from service references in pod environment variables, arguments and ConfigMaps; traffic itself is not observed."}
+	virtualServices, err := s.dynamicClient.Resource(virtualServiceGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	switch {
+	case apierrors.IsNotFound(err) || meta.IsNoMatchError(err):
+		istio = false
+	case err != nil:
+		istio = false
+		notes = append(notes, fmt.Sprintf("Istio VirtualServices could not be listed: %v", err))
+	default:
+		addVirtualServiceEdges(graph, virtualServices.Items, namespace, servicesByName)
+	}
+
+	nodes := make([]*serviceGraphNode, 0, len(graph.nodes))
+	for _, node := range graph.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	edges := make([]serviceGraphEdge, 0, len(graph.edges))
+	for _, edge := range graph.edges {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].ConnectionType < edges[j].ConnectionType
+	})
+
+	return jsonResult(map[string]interface{}{
+		"namespace": namespace,
+		"nodes":     nodes,
+		"edges":     edges,
+		"istio":     istio,
+		"notes":     notes,
+	})
+}
+
+// serviceBackingPods returns the pods a service's endpoints point at, falling back to its selector when it has no
+// Endpoints object yet
+func serviceBackingPods(service corev1.Service, endpoints corev1.Endpoints, pods []corev1.Pod) []string {
+	var backing []string
+	if endpoints.Name != "" {
+		for _, subset := range endpoints.Subsets {
+			for _, address := range append(append([]corev1.EndpointAddress{}, subset.Addresses...), subset.NotReadyAddresses...) {
+				if address.TargetRef != nil && address.TargetRef.Kind == "Pod" {
+					backing = append(backing, address.TargetRef.Name)
+				}
+			}
+		}
+		return backing
+	}
+	if len(service.Spec.Selector) == 0 {
+		return nil
+	}
+	selector := labels.SelectorFromSet(service.Spec.Selector)
+	for _, pod := range pods {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			backing = append(backing, pod.Name)
+		}
+	}
+	return backing
+}
+
+// serviceReference is a reference from a pod to a service
+type serviceReference struct {
+	service        string
+	connectionType string
+	detail         string
+}
+
+// podServiceReferences finds the services a pod refers to in its environment and arguments, including declared
+// NAME_SERVICE_HOST variables, and in the data of the ConfigMaps it mounts or reads
+func podServiceReferences(pod corev1.Pod, services []corev1.Service, configMapData map[string]map[string]string) []serviceReference {
+	var references []serviceReference
+	for _, service := range services {
+		if where := podReferencesService(pod, service.Name, pod.Namespace); where != "" {
+			connectionType := "env"
+			if strings.HasPrefix(where, "arguments") {
+				connectionType = "args"
+			}
+			references = append(references, serviceReference{service: service.Name, connectionType: connectionType, detail: where})
+			continue
+		}
+
+		found := ""
+		visitConfigReferences(&pod.Spec, func(kind, name, use string) {
+			if found != "" || kind != "ConfigMap" {
+				return
+			}
+			for _, key := range sortedKeys(configMapData[name]) {
+				if mentionsService(configMapData[name][key], service.Name, pod.Namespace) {
+					found = fmt.Sprintf("key %s of ConfigMap %s (%s)", key, name, use)
+					return
+				}
+			}
+		})
+		if found != "" {
+			references = append(references, serviceReference{service: service.Name, connectionType: "configmap", detail: found})
+		}
+	}
+	return references
+}
+
+// podWorkload names the workload a pod belongs to: its controller, with the pod-template-hash of a ReplicaSet
+// trimmed so Deployments show under their own name
+func podWorkload(pod corev1.Pod) string {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return pod.Name
+	}
+	if hash := pod.Labels["pod-template-hash"]; owner.Kind == "ReplicaSet" && hash != "" {
+		return strings.TrimSuffix(owner.Name, "-"+hash)
+	}
+	return owner.Name
+}
+
+// addVirtualServiceEdges adds Istio routing: gateways to the services they route to, and callers of a VirtualService
+// host to the services it sends their traffic to
+func addVirtualServiceEdges(graph *serviceGraph, virtualServices []unstructured.Unstructured, namespace string, services map[string]bool) {
+	// Snapshot the reference edges first; routing edges added below must not be rerouted again
+	callers := map[string][]string{}
+	for _, edge := range graph.edges {
+		callers[edge.To] = append(callers[edge.To], edge.From)
+	}
+
+	for _, virtualService := range virtualServices {
+		hosts, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "hosts")
+		gateways, _, _ := unstructured.NestedStringSlice(virtualService.Object, "spec", "gateways")
+		// Without gateways a VirtualService applies to sidecars only, which the reserved gateway name mesh also selects
+		mesh := len(gateways) == 0
+		for _, gateway := range gateways {
+			mesh = mesh || gateway == "mesh"
+		}
+		for _, routeType := range []string{"http", "tcp", "tls"} {
+			routes, _, _ := unstructured.NestedSlice(virtualService.Object, "spec", routeType)
+			for _, route := range routes {
+				routeMap, _ := route.(map[string]interface{})
+				destinations, _, _ := unstructured.NestedSlice(routeMap, "route")
+				for _, destination := range destinations {
+					destinationMap, _ := destination.(map[string]interface{})
+					host, _, _ := unstructured.NestedString(destinationMap, "destination", "host")
+					if host == "" {
+						continue
+					}
+					target := meshHostNode(graph, host, namespace, services)
+					detail := "VirtualService " + virtualService.GetName()
+					if subset, _, _ := unstructured.NestedString(destinationMap, "destination", "subset"); subset != "" {
+						detail += ", subset " + subset
+					}
+					if weight, found, _ := unstructured.NestedInt64(destinationMap, "weight"); found {
+						detail += fmt.Sprintf(", weight %d", weight)
+					}
+
+					for _, gateway := range gateways {
+						if gateway != "mesh" {
+							graph.addEdge(graph.addNode(graphNodeGateway, gateway), target, "istio_gateway", detail)
+						}
+					}
+					if !mesh {
+						continue
+					}
+					for _, vsHost := range hosts {
+						source := meshHostNode(graph, vsHost, namespace, services)
+						if source == target {
+							continue
+						}
+						for _, caller := range callers[source] {
+							graph.addEdge(caller, target, "istio_route", detail+", via host "+vsHost)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// meshHostNode returns the node of an Istio host: the service it names in the namespace, or an external host
+func meshHostNode(graph *serviceGraph, host, namespace string, services map[string]bool) string {
+	name := strings.TrimSuffix(strings.TrimSuffix(host, ".cluster.local"), ".svc")
+	name = strings.TrimSuffix(name, "."+namespace)
+	if services[name] {
+		return graph.addNode(graphNodeService, name)
+	}
+	return graph.addNode(graphNodeExternal, host)
+}
@@ -0,0 +1,64 @@
+package kubernetes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+)
+
+// sessionResumeWindow is how long after initializing a client can re-initialize and get its session back
+const sessionResumeWindow = 30 * time.Second
+
+// sessionStore remembers recent initializations by client name and version, so a client that
+// re-initializes after a network blip gets the same capabilities without being set up again
+type sessionStore struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	sessions map[string]clientSession
+}
+
+// clientSession is the initialization response given to a client and when it can no longer be resumed
+type clientSession struct {
+	response mcp.InitializationResponse
+	expires  time.Time
+}
+
+// newSessionStore creates a session store whose sessions can be resumed for ttl
+func newSessionStore(ttl time.Duration) *sessionStore {
+	return &sessionStore{ttl: ttl, sessions: make(map[string]clientSession)}
+}
+
+// sessionKey identifies a client by the name and version it initializes with
+func sessionKey(info mcp.ClientInfo) string {
+	return info.Name + "/" + info.Version
+}
+
+// resume returns the response of a client's unexpired session, extending the session
+func (st *sessionStore) resume(info mcp.ClientInfo) (mcp.InitializationResponse, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	key := sessionKey(info)
+	session, ok := st.sessions[key]
+	if !ok || time.Now().After(session.expires) {
+		return mcp.InitializationResponse{}, false
+	}
+	session.expires = time.Now().Add(st.ttl)
+	st.sessions[key] = session
+	return session.response, true
+}
+
+// store records a client's initialization response and drops expired sessions
+func (st *sessionStore) store(info mcp.ClientInfo, response mcp.InitializationResponse) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	now := time.Now()
+	for key, session := range st.sessions {
+		if now.After(session.expires) {
+			delete(st.sessions, key)
+		}
+	}
+	st.sessions[sessionKey(info)] = clientSession{response: response, expires: now.Add(st.ttl)}
+}
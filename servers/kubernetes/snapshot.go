@@ -0,0 +1,346 @@
+package kubernetes
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+const (
+	// snapshotLabel marks snapshot Secrets with the namespace they hold
+	snapshotLabel = "mcp-servers.io/snapshot-of"
+
+	// snapshotDataKey is the Secret data key holding the gzipped snapshot
+	snapshotDataKey = "snapshot.json.gz"
+
+	// maxSnapshotSize keeps the compressed snapshot inside the 1MiB Secret limit, with room for metadata
+	maxSnapshotSize = 1000 * 1024
+)
+
+// snapshotSkippedResources are recreated by the cluster itself and are left out of snapshots
+var snapshotSkippedResources = map[string]bool{
+	"events":         true,
+	"endpoints":      true,
+	"endpointslices": true,
+	"leases":         true,
+}
+
+// restoreOrder ranks kinds so that dependencies are applied before the resources that use them; unlisted kinds go last
+var restoreOrder = map[string]int{
+	"Namespace":             0,
+	"ServiceAccount":        1,
+	"ResourceQuota":         1,
+	"LimitRange":            1,
+	"ConfigMap":             2,
+	"Secret":                2,
+	"PersistentVolumeClaim": 3,
+	"Role":                  3,
+	"RoleBinding":           4,
+	"Service":               5,
+	"Deployment":            6,
+	"StatefulSet":           6,
+	"DaemonSet":             6,
+	"Job":                   6,
+	"CronJob":               6,
+}
+
+// snapshotTools returns the namespace snapshot tool definitions
+func snapshotTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "take_snapshot",
+			Description: "Back up every resource in a namespace, Secrets included, to a gzipped JSON archive stored in a Secret; returns the Secret name",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to snapshot",
+					},
+					"storage_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to store the snapshot Secret in; use another namespace than the snapshotted one so the snapshot survives deleting it, and one whose Secrets only administrators can read",
+					},
+				},
+				"required": []string{"namespace", "storage_namespace"},
+			},
+		},
+		{
+			Name:        "restore_snapshot",
+			Description: "Re-apply every resource in a take_snapshot archive, namespaces first, then config and secrets, then workloads",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the snapshot Secret returned by take_snapshot",
+					},
+					"storage_namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace the snapshot Secret is stored in",
+					},
+				},
+				"required": []string{"name", "storage_namespace"},
+			},
+		},
+	}
+}
+
+// namespaceSnapshot is the archive stored by take_snapshot
+type namespaceSnapshot struct {
+	Namespace string                   `json:"namespace"`
+	TakenAt   time.Time                `json:"taken_at"`
+	Items     []map[string]interface{} `json:"items"`
+}
+
+func (s *Server) takeSnapshotTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	storageNamespace, err := stringArg(args, "storage_namespace")
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+
+	ns, err := s.dynamicClient.Resource(corev1.SchemeGroupVersion.WithResource("namespaces")).Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := s.clientset.Discovery().ServerPreferredNamespacedResources()
+	var warnings []string
+	if err != nil {
+		// Unavailable aggregated APIs fail discovery for their group only; snapshot the rest
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, err
+		}
+		warnings = append(warnings, err.Error())
+	}
+
+	snapshot := namespaceSnapshot{Namespace: namespace, TakenAt: time.Now().UTC()}
+	snapshot.Items = append(snapshot.Items, snapshotObject(ns))
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("skipping %s: %v", list.GroupVersion, err))
+			continue
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") || snapshotSkippedResources[resource.Name] || !hasVerbs(resource.Verbs, "list", "create") {
+				continue
+			}
+
+			objects, err := s.dynamicClient.Resource(gv.WithResource(resource.Name)).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				warnings = append(warnings, fmt.Sprintf("skipping %s: %v", resource.Name, err))
+				continue
+			}
+			for i := range objects.Items {
+				obj := &objects.Items[i]
+				if skipSnapshotObject(obj) {
+					continue
+				}
+				snapshot.Items = append(snapshot.Items, snapshotObject(obj))
+			}
+		}
+	}
+
+	data, err := compressSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxSnapshotSize {
+		return nil, fmt.Errorf("snapshot of %s is %d bytes compressed, too large for a Secret", namespace, len(data))
+	}
+
+	// The archive holds the namespace's Secrets, so it is stored as one to keep the same access control
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("snapshot-%s-%s", namespace, snapshot.TakenAt.Format("20060102-150405")),
+			Namespace: storageNamespace,
+			Labels:    map[string]string{snapshotLabel: namespace},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{snapshotDataKey: data},
+	}
+	created, err := s.clientset.CoreV1().Secrets(storageNamespace).Create(ctx, secret, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if storageNamespace == namespace {
+		warnings = append(warnings, fmt.Sprintf("the snapshot is stored in %s itself, so deleting the namespace also deletes it", namespace))
+	}
+
+	return jsonResult(map[string]interface{}{
+		"secret":            created.Name,
+		"storage_namespace": storageNamespace,
+		"resources":         len(snapshot.Items),
+		"bytes":             len(data),
+		"warnings":          warnings,
+	})
+}
+
+func (s *Server) restoreSnapshotTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	storageNamespace, err := stringArg(args, "storage_namespace")
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+
+	secret, err := s.clientset.CoreV1().Secrets(storageNamespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[snapshotDataKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s is not a snapshot: missing %s", storageNamespace, name, snapshotDataKey)
+	}
+
+	snapshot, err := decompressSnapshot(data)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]*unstructured.Unstructured, 0, len(snapshot.Items))
+	for _, item := range snapshot.Items {
+		objects = append(objects, &unstructured.Unstructured{Object: item})
+	}
+	sortForRestore(objects)
+
+	applied, err := s.applyObjects(ctx, objects, snapshot.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("restored %d of %d resource(s) before failing: %w", len(applied), len(objects), err)
+	}
+
+	return textResult(fmt.Sprintf("Restored %d resource(s) from snapshot %s taken at %s:\n%s",
+		len(applied), name, snapshot.TakenAt.Format(time.RFC3339), strings.Join(applied, "\n"))), nil
+}
+
+// hasVerbs reports whether a resource supports every given verb
+func hasVerbs(verbs metav1.Verbs, required ...string) bool {
+	for _, verb := range required {
+		found := false
+		for _, v := range verbs {
+			if v == verb {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// skipSnapshotObject reports whether an object is recreated by its owner or the cluster, so restoring it would conflict
+func skipSnapshotObject(obj *unstructured.Unstructured) bool {
+	if len(obj.GetOwnerReferences()) > 0 {
+		return true
+	}
+
+	switch obj.GetKind() {
+	case "ConfigMap":
+		return obj.GetName() == "kube-root-ca.crt"
+	case "Secret":
+		// Earlier snapshots stored in the namespace are not part of it
+		secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+		return secretType == string(corev1.SecretTypeServiceAccountToken) || obj.GetLabels()[snapshotLabel] != ""
+	case "ServiceAccount":
+		return obj.GetName() == "default"
+	}
+	return false
+}
+
+// snapshotObject strips the server-populated fields from an object so it can be re-applied
+func snapshotObject(obj *unstructured.Unstructured) map[string]interface{} {
+	clean := obj.DeepCopy()
+	for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp", "managedFields", "selfLink"} {
+		unstructured.RemoveNestedField(clean.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(clean.Object, "metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration")
+	unstructured.RemoveNestedField(clean.Object, "status")
+
+	switch clean.GetKind() {
+	case "Service":
+		// Cluster IPs are allocated again on restore, as the old ones may be taken. A headless Service keeps its
+		// clusterIP None, which StatefulSet pod DNS depends on
+		if clusterIP, _, _ := unstructured.NestedString(clean.Object, "spec", "clusterIP"); clusterIP != corev1.ClusterIPNone {
+			unstructured.RemoveNestedField(clean.Object, "spec", "clusterIP")
+			unstructured.RemoveNestedField(clean.Object, "spec", "clusterIPs")
+		}
+	case "PersistentVolumeClaim":
+		// The bound volume may be gone; let the claim provision a new one
+		unstructured.RemoveNestedField(clean.Object, "spec", "volumeName")
+	case "Pod":
+		// Let the scheduler place a restored bare pod
+		unstructured.RemoveNestedField(clean.Object, "spec", "nodeName")
+	case "Namespace":
+		unstructured.RemoveNestedField(clean.Object, "spec", "finalizers")
+	}
+	return clean.Object
+}
+
+// sortForRestore orders objects by restoreOrder, keeping the snapshot order within a rank
+func sortForRestore(objects []*unstructured.Unstructured) {
+	rank := func(kind string) int {
+		if r, ok := restoreOrder[kind]; ok {
+			return r
+		}
+		return len(restoreOrder)
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		return rank(objects[i].GetKind()) < rank(objects[j].GetKind())
+	})
+}
+
+// compressSnapshot encodes a snapshot as gzipped JSON
+func compressSnapshot(snapshot namespaceSnapshot) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(writer).Encode(snapshot); err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress snapshot: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressSnapshot decodes a gzipped JSON snapshot
+func decompressSnapshot(data []byte) (*namespaceSnapshot, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress snapshot: %w", err)
+	}
+	defer reader.Close()
+
+	raw, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress snapshot: %w", err)
+	}
+	var snapshot namespaceSnapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
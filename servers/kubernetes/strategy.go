@@ -0,0 +1,184 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// intOrPercentSchema accepts either an integer or a percentage string
+var intOrPercentSchema = []interface{}{
+	map[string]interface{}{"type": "integer"},
+	map[string]interface{}{"type": "string"},
+}
+
+// strategyTools returns the rollout strategy tool definitions
+func strategyTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "update_rollout_strategy",
+			Description: "Change the rollout strategy of an existing deployment",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment",
+					},
+					"strategy_type": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"Recreate", "RollingUpdate"},
+						"description": "Deployment strategy type",
+					},
+					"max_surge": map[string]interface{}{
+						"oneOf":       intOrPercentSchema,
+						"description": "Maximum pods above the desired count during a RollingUpdate, as an integer or percentage such as \"25%\"",
+					},
+					"max_unavailable": map[string]interface{}{
+						"oneOf":       intOrPercentSchema,
+						"description": "Maximum unavailable pods during a RollingUpdate, as an integer or percentage such as \"25%\"",
+					},
+				},
+				"required": []string{"name", "namespace", "strategy_type"},
+			},
+		},
+	}
+}
+
+// buildDeploymentStrategy builds a strategy from the strategy arguments, returning nil when strategy_type is absent
+func buildDeploymentStrategy(args map[string]interface{}) (*appsv1.DeploymentStrategy, error) {
+	strategyType := optionalStringArg(args, "strategy_type", "")
+	if strategyType == "" {
+		if args["max_surge"] != nil || args["max_unavailable"] != nil {
+			return nil, fmt.Errorf("max_surge and max_unavailable require strategy_type RollingUpdate")
+		}
+		return nil, nil
+	}
+
+	switch appsv1.DeploymentStrategyType(strategyType) {
+	case appsv1.RecreateDeploymentStrategyType:
+		if args["max_surge"] != nil || args["max_unavailable"] != nil {
+			return nil, fmt.Errorf("max_surge and max_unavailable are only valid for RollingUpdate")
+		}
+		return &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}, nil
+	case appsv1.RollingUpdateDeploymentStrategyType:
+		maxSurge, err := parseIntOrPercent(args["max_surge"], "max_surge")
+		if err != nil {
+			return nil, err
+		}
+		maxUnavailable, err := parseIntOrPercent(args["max_unavailable"], "max_unavailable")
+		if err != nil {
+			return nil, err
+		}
+
+		strategy := &appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
+		if maxSurge != nil || maxUnavailable != nil {
+			strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+				MaxSurge:       maxSurge,
+				MaxUnavailable: maxUnavailable,
+			}
+		}
+		return strategy, nil
+	default:
+		return nil, fmt.Errorf("strategy_type must be Recreate or RollingUpdate, got %s", strategyType)
+	}
+}
+
+// parseIntOrPercent converts an integer or a percentage string such as "25%" into an IntOrString
+func parseIntOrPercent(raw interface{}, field string) (*intstr.IntOrString, error) {
+	switch v := raw.(type) {
+	case nil:
+		return nil, nil
+	case float64:
+		if v < 0 || v != float64(int(v)) {
+			return nil, fmt.Errorf("%s must be a non-negative integer", field)
+		}
+		value := intstr.FromInt(int(v))
+		return &value, nil
+	case string:
+		v = strings.TrimSpace(v)
+		if strings.HasSuffix(v, "%") {
+			percent, err := strconv.Atoi(strings.TrimSuffix(v, "%"))
+			if err != nil || percent < 0 || percent > 100 {
+				return nil, fmt.Errorf("%s must be a percentage between 0%% and 100%%, got %q", field, v)
+			}
+			value := intstr.FromString(v)
+			return &value, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("%s must be a non-negative integer or percentage, got %q", field, v)
+		}
+		value := intstr.FromInt(n)
+		return &value, nil
+	default:
+		return nil, fmt.Errorf("%s must be an integer or percentage string", field)
+	}
+}
+
+// describeStrategy formats a strategy for tool output
+func describeStrategy(strategy *appsv1.DeploymentStrategy) string {
+	if strategy.RollingUpdate == nil {
+		return string(strategy.Type)
+	}
+
+	var parts []string
+	if strategy.RollingUpdate.MaxSurge != nil {
+		parts = append(parts, "maxSurge="+strategy.RollingUpdate.MaxSurge.String())
+	}
+	if strategy.RollingUpdate.MaxUnavailable != nil {
+		parts = append(parts, "maxUnavailable="+strategy.RollingUpdate.MaxUnavailable.String())
+	}
+	return fmt.Sprintf("%s (%s)", strategy.Type, strings.Join(parts, ", "))
+}
+
+func (s *Server) updateRolloutStrategyTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	strategy, err := buildDeploymentStrategy(args)
+	if err != nil {
+		return nil, err
+	}
+	if strategy == nil {
+		return nil, fmt.Errorf("strategy_type is required")
+	}
+
+	// rollingUpdate is explicitly nulled when switching to Recreate, which the API server requires
+	strategyPatch := map[string]interface{}{
+		"type":          strategy.Type,
+		"rollingUpdate": strategy.RollingUpdate,
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"strategy": strategyPatch,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	_, err = s.clientset.AppsV1().Deployments(namespace).Patch(context.Background(), name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Successfully updated rollout strategy of deployment '%s' in namespace '%s' to %s", name, namespace, describeStrategy(strategy))), nil
+}
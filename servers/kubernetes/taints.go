@@ -0,0 +1,273 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// taintEffects are the valid taint and toleration effects
+var taintEffects = []string{string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)}
+
+// taintTools returns the node taint and toleration tool definitions
+func taintTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "taint_node",
+			Description: "Add or update a taint on a node, e.g. to keep pods without a matching toleration off it",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"node_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the node",
+					},
+					"key": map[string]interface{}{
+						"type":        "string",
+						"description": "Taint key, e.g. nvidia.com/gpu",
+					},
+					"value": map[string]interface{}{
+						"type":        "string",
+						"description": "Taint value (optional)",
+					},
+					"effect": map[string]interface{}{
+						"type":        "string",
+						"enum":        taintEffects,
+						"description": "Taint effect",
+					},
+				},
+				"required": []string{"node_name", "key", "effect"},
+			},
+		},
+		{
+			Name:        "untaint_node",
+			Description: "Remove a taint from a node",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"node_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the node",
+					},
+					"key": map[string]interface{}{
+						"type":        "string",
+						"description": "Key of the taint to remove",
+					},
+					"effect": map[string]interface{}{
+						"type":        "string",
+						"enum":        taintEffects,
+						"description": "Only remove the taint with this effect (omit to remove every taint with the key)",
+					},
+				},
+				"required": []string{"node_name", "key"},
+			},
+		},
+		{
+			Name:        "add_toleration_to_deployment",
+			Description: "Add a toleration to a deployment's pod template so its pods can schedule onto matching tainted nodes",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment (defaults to default)",
+					},
+					"key": map[string]interface{}{
+						"type":        "string",
+						"description": "Taint key to tolerate",
+					},
+					"operator": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{string(corev1.TolerationOpEqual), string(corev1.TolerationOpExists)},
+						"description": "Equal (default) to match the value, Exists to match any value",
+					},
+					"value": map[string]interface{}{
+						"type":        "string",
+						"description": "Taint value to tolerate; ignored with Exists",
+					},
+					"effect": map[string]interface{}{
+						"type":        "string",
+						"enum":        taintEffects,
+						"description": "Taint effect to tolerate (omit to tolerate every effect)",
+					},
+				},
+				"required": []string{"name", "key"},
+			},
+		},
+	}
+}
+
+// effectArg returns an optional taint effect argument, validated against taintEffects
+func effectArg(args map[string]interface{}) (corev1.TaintEffect, error) {
+	effect := optionalStringArg(args, "effect", "")
+	if effect == "" {
+		return "", nil
+	}
+	for _, valid := range taintEffects {
+		if effect == valid {
+			return corev1.TaintEffect(effect), nil
+		}
+	}
+	return "", fmt.Errorf("effect must be one of NoSchedule, PreferNoSchedule or NoExecute")
+}
+
+func (s *Server) taintNodeTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	nodeName, err := stringArg(args, "node_name")
+	if err != nil {
+		return nil, err
+	}
+	key, err := stringArg(args, "key")
+	if err != nil {
+		return nil, err
+	}
+	effect, err := effectArg(args)
+	if err != nil {
+		return nil, err
+	}
+	if effect == "" {
+		return nil, fmt.Errorf("effect is required")
+	}
+	taint := corev1.Taint{Key: key, Value: optionalStringArg(args, "value", ""), Effect: effect}
+	ctx := context.Background()
+
+	node, err := s.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// A taint is identified by key and effect; adding one that exists updates its value
+	taints := []corev1.Taint{}
+	for _, existing := range node.Spec.Taints {
+		if existing.Key != taint.Key || existing.Effect != taint.Effect {
+			taints = append(taints, existing)
+		}
+	}
+	taints = append(taints, taint)
+
+	if err := s.patchNodeTaints(ctx, node, taints); err != nil {
+		return nil, err
+	}
+	return textResult(fmt.Sprintf("Tainted node '%s' with %s", nodeName, taint.ToString())), nil
+}
+
+func (s *Server) untaintNodeTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	nodeName, err := stringArg(args, "node_name")
+	if err != nil {
+		return nil, err
+	}
+	key, err := stringArg(args, "key")
+	if err != nil {
+		return nil, err
+	}
+	effect, err := effectArg(args)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+
+	node, err := s.clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	taints := []corev1.Taint{}
+	var removed []string
+	for _, existing := range node.Spec.Taints {
+		if existing.Key == key && (effect == "" || existing.Effect == effect) {
+			removed = append(removed, existing.ToString())
+			continue
+		}
+		taints = append(taints, existing)
+	}
+	if len(removed) == 0 {
+		return nil, fmt.Errorf("node %s has no taint with key %s", nodeName, key)
+	}
+
+	if err := s.patchNodeTaints(ctx, node, taints); err != nil {
+		return nil, err
+	}
+	return textResult(fmt.Sprintf("Removed taint(s) from node '%s': %v", nodeName, removed)), nil
+}
+
+// patchNodeTaints replaces a node's taints. Taints have no merge key, so the strategic merge patch carries
+// the whole list, and the resource version makes the patch fail rather than drop a concurrent change.
+func (s *Server) patchNodeTaints(ctx context.Context, node *corev1.Node, taints []corev1.Taint) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": node.ResourceVersion},
+		"spec":     map[string]interface{}{"taints": taints},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := s.clientset.CoreV1().Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to update taints: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) addTolerationToDeploymentTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	key, err := stringArg(args, "key")
+	if err != nil {
+		return nil, err
+	}
+	effect, err := effectArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	toleration := corev1.Toleration{Key: key, Effect: effect}
+	switch operator := optionalStringArg(args, "operator", string(corev1.TolerationOpEqual)); operator {
+	case string(corev1.TolerationOpEqual):
+		toleration.Operator = corev1.TolerationOpEqual
+		toleration.Value = optionalStringArg(args, "value", "")
+	case string(corev1.TolerationOpExists):
+		toleration.Operator = corev1.TolerationOpExists
+	default:
+		return nil, fmt.Errorf("operator must be Equal or Exists")
+	}
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	tolerations := deployment.Spec.Template.Spec.Tolerations
+	for _, existing := range tolerations {
+		if existing.MatchToleration(&toleration) {
+			return textResult(fmt.Sprintf("Deployment '%s' in namespace '%s' already tolerates %s", name, namespace, key)), nil
+		}
+	}
+	tolerations = append(tolerations, toleration)
+
+	// Tolerations have no merge key either, so the patch replaces the whole list
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": deployment.ResourceVersion},
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{"tolerations": tolerations},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.clientset.AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, err
+	}
+
+	return textResult(fmt.Sprintf("Deployment '%s' in namespace '%s' now tolerates %s; its pods will be rolled out again", name, namespace, key)), nil
+}
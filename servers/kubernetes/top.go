@@ -0,0 +1,176 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// defaultTopN is how many entries the top tools return when top_n is omitted
+const defaultTopN = 10
+
+// topTools returns the sorted resource usage tool definitions
+func topTools() []mcp.Tool {
+	sortProperties := map[string]interface{}{
+		"sort_by": map[string]interface{}{
+			"type":        "string",
+			"enum":        []string{"cpu", "memory"},
+			"description": "Metric to sort by, highest usage first (defaults to cpu)",
+		},
+		"top_n": map[string]interface{}{
+			"type":        "integer",
+			"description": fmt.Sprintf("Number of entries to return (defaults to %d)", defaultTopN),
+		},
+	}
+
+	return []mcp.Tool{
+		{
+			Name:        "get_top_pods",
+			Description: "List the pods using the most CPU or memory according to metrics-server",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to inspect (omit for all namespaces)",
+					},
+					"sort_by": sortProperties["sort_by"],
+					"top_n":   sortProperties["top_n"],
+				},
+			},
+		},
+		{
+			Name:        "get_top_nodes",
+			Description: "List the nodes using the most CPU or memory according to metrics-server",
+			InputSchema: map[string]interface{}{
+				"type":       "object",
+				"properties": sortProperties,
+			},
+		},
+	}
+}
+
+// resourceUsage is the live CPU and memory usage of a pod or node
+type resourceUsage struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	CPU       string `json:"cpu"`
+	Memory    string `json:"memory"`
+
+	cpu    resource.Quantity
+	memory resource.Quantity
+}
+
+// topArgs parses the sort_by and top_n arguments shared by the top tools
+func topArgs(args map[string]interface{}) (string, int, error) {
+	sortBy := optionalStringArg(args, "sort_by", "cpu")
+	if sortBy != "cpu" && sortBy != "memory" {
+		return "", 0, fmt.Errorf("sort_by must be cpu or memory")
+	}
+	topN, err := intArg(args, "top_n", defaultTopN)
+	if err != nil {
+		return "", 0, err
+	}
+	if topN <= 0 {
+		return "", 0, fmt.Errorf("top_n must be positive")
+	}
+	return sortBy, topN, nil
+}
+
+// sortUsage orders usage by the sortBy metric, highest first, and keeps the first topN entries
+func sortUsage(usage []resourceUsage, sortBy string, topN int) []resourceUsage {
+	sort.SliceStable(usage, func(i, j int) bool {
+		if sortBy == "memory" {
+			return usage[i].memory.Cmp(usage[j].memory) > 0
+		}
+		return usage[i].cpu.Cmp(usage[j].cpu) > 0
+	})
+	if len(usage) > topN {
+		usage = usage[:topN]
+	}
+	for i := range usage {
+		usage[i].CPU = usage[i].cpu.String()
+		usage[i].Memory = usage[i].memory.String()
+	}
+	return usage
+}
+
+func (s *Server) getTopPodsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	sortBy, topN, err := topArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "")
+
+	list, err := s.dynamicClient.Resource(podMetricsGVR).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("pod metrics are unavailable, is metrics-server installed? %w", err)
+	}
+
+	usage := make([]resourceUsage, 0, len(list.Items))
+	for _, item := range list.Items {
+		pod := resourceUsage{
+			Name:      item.GetName(),
+			Namespace: item.GetNamespace(),
+			cpu:       *resource.NewQuantity(0, resource.DecimalSI),
+			memory:    *resource.NewQuantity(0, resource.BinarySI),
+		}
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			values, _, _ := unstructured.NestedStringMap(container, "usage")
+			if q, err := resource.ParseQuantity(values["cpu"]); err == nil {
+				pod.cpu.Add(q)
+			}
+			if q, err := resource.ParseQuantity(values["memory"]); err == nil {
+				pod.memory.Add(q)
+			}
+		}
+		usage = append(usage, pod)
+	}
+
+	return jsonResult(map[string]interface{}{
+		"sort_by": sortBy,
+		"total":   len(usage),
+		"pods":    sortUsage(usage, sortBy, topN),
+	})
+}
+
+func (s *Server) getTopNodesTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	sortBy, topN, err := topArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.dynamicClient.Resource(nodeMetricsGVR).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("node metrics are unavailable, is metrics-server installed? %w", err)
+	}
+
+	usage := make([]resourceUsage, 0, len(list.Items))
+	for _, item := range list.Items {
+		node := resourceUsage{Name: item.GetName()}
+		values, _, _ := unstructured.NestedStringMap(item.Object, "usage")
+		if q, err := resource.ParseQuantity(values["cpu"]); err == nil {
+			node.cpu = q
+		}
+		if q, err := resource.ParseQuantity(values["memory"]); err == nil {
+			node.memory = q
+		}
+		usage = append(usage, node)
+	}
+
+	return jsonResult(map[string]interface{}{
+		"sort_by": sortBy,
+		"total":   len(usage),
+		"nodes":   sortUsage(usage, sortBy, topN),
+	})
+}
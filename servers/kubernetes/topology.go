@@ -0,0 +1,161 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// unknownZone stands for the zone of pods whose node has no zone label
+const unknownZone = "unknown"
+
+// topologyTools returns the deployment topology tool definitions
+func topologyTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_deployment_topology",
+			Description: "Show which nodes, zones and regions a deployment's pods run in, with the pod count per zone, warning when every pod shares one zone or node",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment (defaults to default)",
+					},
+				},
+				"required": []string{"name"},
+			},
+		},
+	}
+}
+
+// podPlacement is where one pod runs
+type podPlacement struct {
+	PodName  string `json:"pod_name"`
+	NodeName string `json:"node_name,omitempty"`
+	Zone     string `json:"zone,omitempty"`
+	Region   string `json:"region,omitempty"`
+}
+
+func (s *Server) getDeploymentTopologyTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment selector: %w", err)
+	}
+	pods, err := s.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	nodeList, err := s.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	nodes := make(map[string]corev1.Node, len(nodeList.Items))
+	clusterZones := map[string]bool{}
+	for _, node := range nodeList.Items {
+		nodes[node.Name] = node
+		if zone := nodeZone(node); zone != "" {
+			clusterZones[zone] = true
+		}
+	}
+
+	placements := []podPlacement{}
+	zones := map[string]int{}
+	usedNodes := map[string]bool{}
+	unscheduled := 0
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			// Terminating pods are leaving their zone; count only the pods the deployment keeps
+			continue
+		}
+		placement := podPlacement{PodName: pod.Name, NodeName: pod.Spec.NodeName}
+		if pod.Spec.NodeName == "" {
+			unscheduled++
+			placements = append(placements, placement)
+			continue
+		}
+		usedNodes[pod.Spec.NodeName] = true
+		node := nodes[pod.Spec.NodeName]
+		placement.Zone = nodeZone(node)
+		placement.Region = nodeRegion(node)
+		if placement.Zone == "" {
+			zones[unknownZone]++
+		} else {
+			zones[placement.Zone]++
+		}
+		placements = append(placements, placement)
+	}
+	sort.Slice(placements, func(i, j int) bool { return placements[i].PodName < placements[j].PodName })
+
+	scheduled := len(placements) - unscheduled
+	var warnings []string
+	switch {
+	case scheduled == 0:
+		warnings = append(warnings, "No pod of the deployment is scheduled on a node")
+	case zones[unknownZone] == scheduled:
+		warnings = append(warnings, fmt.Sprintf("The nodes running the pods have no %s label, so their zones are unknown", corev1.LabelTopologyZone))
+	case len(zones) == 1:
+		warning := fmt.Sprintf("All %d scheduled pod(s) run in zone %s, a single point of failure: an outage of that zone takes the deployment down", scheduled, sortedCountKeys(zones)[0])
+		if len(clusterZones) > 1 {
+			warning += fmt.Sprintf("; the cluster has %d zones, so add a topologySpreadConstraint on %s to spread the pods", len(clusterZones), corev1.LabelTopologyZone)
+		}
+		warnings = append(warnings, warning)
+	}
+	if scheduled > 1 && len(usedNodes) == 1 {
+		warnings = append(warnings, fmt.Sprintf("All %d scheduled pods run on node %s", scheduled, sortedKeys(usedNodes)[0]))
+	}
+	if unscheduled > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d pod(s) are not scheduled yet", unscheduled))
+	}
+
+	result := map[string]interface{}{
+		"deployment":     name,
+		"namespace":      namespace,
+		"pods":           placements,
+		"zones":          zones,
+		"nodes_used":     len(usedNodes),
+		"cluster_zones":  sortedKeys(clusterZones),
+		"single_zone":    scheduled > 0 && len(zones) == 1 && zones[unknownZone] == 0,
+		"unscheduled":    unscheduled,
+		"scheduled_pods": scheduled,
+	}
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+	return jsonResult(result)
+}
+
+// nodeZone returns a node's zone label, falling back to the deprecated beta label older clusters still set
+func nodeZone(node corev1.Node) string {
+	if zone := node.Labels[corev1.LabelTopologyZone]; zone != "" {
+		return zone
+	}
+	return node.Labels[corev1.LabelFailureDomainBetaZone]
+}
+
+// nodeRegion returns a node's region label, falling back to the deprecated beta label older clusters still set
+func nodeRegion(node corev1.Node) string {
+	if region := node.Labels[corev1.LabelTopologyRegion]; region != "" {
+		return region
+	}
+	return node.Labels[corev1.LabelFailureDomainBetaRegion]
+}
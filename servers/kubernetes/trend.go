@@ -0,0 +1,227 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Resource trend settings
+const (
+	defaultTrendDuration = 24 * time.Hour
+	trendPoints          = 120
+	minTrendStep         = time.Minute
+
+	// trendStableThreshold is the change over the window, relative to the average, below which usage counts as stable
+	trendStableThreshold = 0.1
+)
+
+// trendTools returns the resource trend tool definitions
+func trendTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "get_resource_trend",
+			Description: "Report whether a deployment's CPU and memory usage is increasing, decreasing or stable, using Prometheus history or, without Prometheus, two metrics-server readings",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"deployment_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the deployment",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace of the deployment",
+					},
+					"duration": map[string]interface{}{
+						"type":        "string",
+						"description": "How far back to look in Prometheus, e.g. 1h or 24h (defaults to 24h)",
+					},
+				},
+				"required": []string{"deployment_name", "namespace"},
+			},
+		},
+	}
+}
+
+// trendPoint is a single usage reading
+type trendPoint struct {
+	at    time.Time
+	value float64
+}
+
+// trendSummary describes the direction and spread of one usage series
+type trendSummary struct {
+	Trend        string  `json:"trend"`
+	Unit         string  `json:"unit"`
+	Min          float64 `json:"min"`
+	Max          float64 `json:"max"`
+	Avg          float64 `json:"avg"`
+	SlopePerHour float64 `json:"slope_per_hour"`
+	Samples      int     `json:"samples"`
+}
+
+func (s *Server) resourceTrendTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	name, err := stringArg(args, "deployment_name")
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := stringArg(args, "namespace")
+	if err != nil {
+		return nil, err
+	}
+	duration, err := time.ParseDuration(optionalStringArg(args, "duration", defaultTrendDuration.String()))
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("duration must be a positive duration such as 1h or 24h")
+	}
+	ctx := context.Background()
+
+	deployment, err := s.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.prometheusEndpoint != "" {
+		cpu, memory, err := s.prometheusTrend(namespace, name, duration)
+		if err != nil {
+			return nil, err
+		}
+		return jsonResult(map[string]interface{}{
+			"deployment": name,
+			"namespace":  namespace,
+			"source":     "prometheus",
+			"window":     duration.String(),
+			"cpu":        cpu,
+			"memory":     memory,
+		})
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %w", err)
+	}
+	cpu, memory, err := s.metricsServerTrend(ctx, namespace, selector.String())
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(map[string]interface{}{
+		"deployment": name,
+		"namespace":  namespace,
+		"source":     "metrics-server",
+		"window":     s.sampleInterval.String(),
+		"cpu":        cpu,
+		"memory":     memory,
+	})
+}
+
+// prometheusTrend summarises the deployment's CPU and memory usage over duration from cAdvisor metrics
+func (s *Server) prometheusTrend(namespace, name string, duration time.Duration) (*trendSummary, *trendSummary, error) {
+	endpoint, err := s.prometheusEndpointArg(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Deployment pods are named <deployment>-<pod-template-hash>-<suffix>
+	matcher := fmt.Sprintf(`namespace=%q,pod=~%q,container!=""`, namespace, name+"-[a-z0-9]+-[a-z0-9]+")
+	step := duration / trendPoints
+	if step < minTrendStep {
+		step = minTrendStep
+	}
+	end := time.Now()
+	start := end.Add(-duration)
+
+	queries := map[string]string{
+		"cpu":    fmt.Sprintf("sum(rate(container_cpu_usage_seconds_total{%s}[5m]))", matcher),
+		"memory": fmt.Sprintf("sum(container_memory_working_set_bytes{%s})", matcher),
+	}
+	points := map[string][]trendPoint{}
+	for metric, promql := range queries {
+		params := url.Values{}
+		params.Set("query", promql)
+		params.Set("start", start.UTC().Format(time.RFC3339))
+		params.Set("end", end.UTC().Format(time.RFC3339))
+		params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+
+		series, err := prometheusQueryRange(endpoint, params)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(series) == 0 || len(series[0].Values) == 0 {
+			return nil, nil, fmt.Errorf("Prometheus has no %s data for deployment %s/%s in the last %s", metric, namespace, name, duration)
+		}
+		for _, sample := range series[0].Values {
+			points[metric] = append(points[metric], trendPoint{at: sample.at, value: sample.Value})
+		}
+	}
+
+	return summarizeTrend(points["cpu"], "cores"), summarizeTrend(points["memory"], "bytes"), nil
+}
+
+// metricsServerTrend compares two metrics-server readings taken the configured sample interval apart
+func (s *Server) metricsServerTrend(ctx context.Context, namespace, selector string) (*trendSummary, *trendSummary, error) {
+	var cpu, memory []trendPoint
+	for i := 0; i < 2; i++ {
+		if i > 0 {
+			time.Sleep(s.sampleInterval)
+		}
+		cores, bytes, _, err := s.podUsageTotals(ctx, namespace, selector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("metrics-server not available and no Prometheus endpoint is configured: %w", err)
+		}
+		now := time.Now()
+		cpu = append(cpu, trendPoint{at: now, value: cores.AsApproximateFloat64()})
+		memory = append(memory, trendPoint{at: now, value: bytes.AsApproximateFloat64()})
+	}
+	return summarizeTrend(cpu, "cores"), summarizeTrend(memory, "bytes"), nil
+}
+
+// summarizeTrend fits a least-squares line through points and classifies its slope relative to the average
+func summarizeTrend(points []trendPoint, unit string) *trendSummary {
+	summary := &trendSummary{Trend: "stable", Unit: unit, Samples: len(points)}
+	if len(points) == 0 {
+		return summary
+	}
+
+	origin := points[0].at
+	summary.Min, summary.Max = points[0].value, points[0].value
+	var sumX, sumY float64
+	for _, p := range points {
+		summary.Min = math.Min(summary.Min, p.value)
+		summary.Max = math.Max(summary.Max, p.value)
+		sumX += p.at.Sub(origin).Hours()
+		sumY += p.value
+	}
+	n := float64(len(points))
+	meanX, meanY := sumX/n, sumY/n
+	summary.Avg = meanY
+
+	var covariance, variance float64
+	for _, p := range points {
+		dx := p.at.Sub(origin).Hours() - meanX
+		covariance += dx * (p.value - meanY)
+		variance += dx * dx
+	}
+	if variance == 0 {
+		return summary
+	}
+	summary.SlopePerHour = covariance / variance
+
+	span := points[len(points)-1].at.Sub(origin).Hours()
+	if meanY == 0 {
+		return summary
+	}
+	change := summary.SlopePerHour * span / meanY
+	switch {
+	case change > trendStableThreshold:
+		summary.Trend = "increasing"
+	case change < -trendStableThreshold:
+		summary.Trend = "decreasing"
+	}
+	return summary
+}
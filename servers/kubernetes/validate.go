@@ -0,0 +1,634 @@
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// maxSchemaDepth bounds how deep schema validation recurses, since some definitions such as JSONSchemaProps refer to themselves
+const maxSchemaDepth = 64
+
+// documentSeparator splits a multi-document YAML manifest
+var documentSeparator = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
+// validateTools returns the manifest validation tool definitions
+func validateTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "validate_manifest",
+			Description: "Check a manifest before applying it: validates each resource against the cluster's OpenAPI schema, then dry-run applies the valid ones on the server, returning each error with its field path and a suggested correction",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"manifest": map[string]interface{}{
+						"type":        "string",
+						"description": "YAML manifest to validate; multiple documents are separated by ---",
+					},
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace for namespaced resources that do not set one (defaults to default)",
+					},
+				},
+				"required": []string{"manifest"},
+			},
+		},
+	}
+}
+
+// validationError is one problem found in a manifest
+type validationError struct {
+	Document   int    `json:"document"`
+	Resource   string `json:"resource,omitempty"`
+	Field      string `json:"field,omitempty"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Source     string `json:"source"`
+}
+
+// Sources of validation errors
+const (
+	sourceYAML   = "yaml"
+	sourceSchema = "schema"
+	sourceDryRun = "dry-run"
+)
+
+func (s *Server) validateManifestTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	manifest, err := stringArg(args, "manifest")
+	if err != nil {
+		return nil, err
+	}
+	namespace := optionalStringArg(args, "namespace", "default")
+
+	documents, errs, warnings, err := s.validateManifest(context.Background(), manifest, namespace, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResult(map[string]interface{}{
+		"valid":     len(errs) == 0,
+		"documents": documents,
+		"errors":    errs,
+		"warnings":  warnings,
+	})
+}
+
+// validateManifest validates each document of a manifest against the cluster's OpenAPI schema, then dry-run applies
+// the documents the schema accepts. Documents for which skipDryRun returns true are only checked against the schema.
+// It returns the number of documents, the problems found and warnings about checks that could not run.
+func (s *Server) validateManifest(ctx context.Context, manifest, namespace string, skipDryRun func(*unstructured.Unstructured) bool) (int, []validationError, []string, error) {
+	errs := []validationError{}
+	var warnings []string
+
+	documents, parseErrs := parseManifestDocuments(manifest)
+	errs = append(errs, parseErrs...)
+	if len(documents) == 0 && len(parseErrs) == 0 {
+		return 0, nil, nil, fmt.Errorf("manifest contains no resources")
+	}
+
+	definitions, err := s.openAPIDefinitions(ctx)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("Schema validation skipped: %v", err))
+	}
+	byGVK := definitionsByGVK(definitions)
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(s.clientset.Discovery()))
+	for _, doc := range documents {
+		resource := doc.object.GetKind() + "/" + doc.object.GetName()
+
+		// Kinds without a published schema, such as CRDs without one, are left to the dry-run
+		if name, ok := byGVK[doc.object.GroupVersionKind()]; ok {
+			v := &schemaValidator{definitions: definitions, document: doc.index, resource: resource}
+			v.validate("", doc.object.Object, map[string]interface{}{"$ref": "#/definitions/" + name}, 0)
+			if len(v.errs) > 0 {
+				// The server would reject the same problems; dry-run only the documents the schema accepts
+				errs = append(errs, v.errs...)
+				continue
+			}
+		}
+
+		if skipDryRun != nil && skipDryRun(doc.object) {
+			continue
+		}
+		errs = append(errs, s.dryRunValidate(ctx, mapper, doc, resource, namespace, byGVK)...)
+	}
+
+	return len(documents) + len(parseErrs), errs, warnings, nil
+}
+
+// manifestDocument is one decoded document of a manifest with its 1-based position
+type manifestDocument struct {
+	index  int
+	object *unstructured.Unstructured
+}
+
+// parseManifestDocuments splits a manifest into documents and decodes each, reporting the documents that do not parse
+func parseManifestDocuments(manifest string) ([]manifestDocument, []validationError) {
+	var documents []manifestDocument
+	var errs []validationError
+
+	index := 0
+	for _, text := range documentSeparator.Split(manifest, -1) {
+		data, err := yaml.YAMLToJSON([]byte(text))
+		if err == nil && (len(data) == 0 || string(data) == "null") {
+			// Empty documents, such as a leading ---, are not counted
+			continue
+		}
+		index++
+		if err != nil {
+			errs = append(errs, validationError{
+				Document:   index,
+				Message:    err.Error(),
+				Suggestion: "Fix the YAML syntax: check indentation, and that list items and key: value pairs line up",
+				Source:     sourceYAML,
+			})
+			continue
+		}
+
+		var raw map[string]interface{}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			errs = append(errs, validationError{
+				Document:   index,
+				Message:    "document is not a mapping of fields",
+				Suggestion: "Each document must be a single resource starting with apiVersion and kind",
+				Source:     sourceYAML,
+			})
+			continue
+		}
+		missing := false
+		for _, field := range []string{"apiVersion", "kind"} {
+			if value, _ := raw[field].(string); value == "" {
+				errs = append(errs, validationError{
+					Document:   index,
+					Field:      field,
+					Message:    fmt.Sprintf("missing required field %s", field),
+					Suggestion: fmt.Sprintf("Add %s to the document", field),
+					Source:     sourceYAML,
+				})
+				missing = true
+			}
+		}
+		if missing {
+			continue
+		}
+
+		// Decode again as unstructured so integers stay int64
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(data); err != nil {
+			errs = append(errs, validationError{Document: index, Message: err.Error(), Source: sourceYAML})
+			continue
+		}
+		documents = append(documents, manifestDocument{index: index, object: obj})
+	}
+
+	return documents, errs
+}
+
+// openAPIDefinitions fetches the definitions of the cluster's OpenAPI v2 schema
+func (s *Server) openAPIDefinitions(ctx context.Context) (map[string]interface{}, error) {
+	data, err := s.clientset.Discovery().RESTClient().Get().
+		AbsPath("/openapi/v2").
+		SetHeader("Accept", "application/json").
+		Do(ctx).
+		Raw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch /openapi/v2: %w", err)
+	}
+
+	var spec struct {
+		Definitions map[string]interface{} `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode /openapi/v2: %w", err)
+	}
+	if len(spec.Definitions) == 0 {
+		return nil, fmt.Errorf("/openapi/v2 has no definitions")
+	}
+	return spec.Definitions, nil
+}
+
+// definitionsByGVK indexes the definitions by their x-kubernetes-group-version-kind extension
+func definitionsByGVK(definitions map[string]interface{}) map[schema.GroupVersionKind]string {
+	byGVK := map[schema.GroupVersionKind]string{}
+	for name, definition := range definitions {
+		def, _ := definition.(map[string]interface{})
+		gvks, _ := def["x-kubernetes-group-version-kind"].([]interface{})
+		for _, entry := range gvks {
+			gvk, _ := entry.(map[string]interface{})
+			group, _ := gvk["group"].(string)
+			version, _ := gvk["version"].(string)
+			kind, _ := gvk["kind"].(string)
+			byGVK[schema.GroupVersionKind{Group: group, Version: version, Kind: kind}] = name
+		}
+	}
+	return byGVK
+}
+
+// apiVersionSuggestion names the apiVersions the cluster serves a kind under, or suggests a kind with a similar name
+func apiVersionSuggestion(byGVK map[schema.GroupVersionKind]string, gvk schema.GroupVersionKind) string {
+	versions := map[string]bool{}
+	kinds := map[string]bool{}
+	for known := range byGVK {
+		if known.Kind == gvk.Kind {
+			versions[known.GroupVersion().String()] = true
+		}
+		kinds[known.Kind] = true
+	}
+	if len(versions) > 0 {
+		return fmt.Sprintf("Use a served apiVersion for %s: %s", gvk.Kind, strings.Join(sortedKeys(versions), ", "))
+	}
+	if kind := closestMatch(gvk.Kind, sortedKeys(kinds)); kind != "" {
+		return fmt.Sprintf("Did you mean kind %s?", kind)
+	}
+	return "Check the kind and apiVersion against kubectl api-resources; custom resources need their CRD installed first"
+}
+
+// dryRunValidate runs a dry-run server-side apply of a document and turns a rejection into validation errors
+// byGVK, the schema's kinds, is used to suggest a served apiVersion when the kind cannot be mapped.
+func (s *Server) dryRunValidate(ctx context.Context, mapper meta.RESTMapper, doc manifestDocument, resource, defaultNamespace string, byGVK map[schema.GroupVersionKind]string) []validationError {
+	obj := doc.object
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		return []validationError{{
+			Document:   doc.index,
+			Resource:   resource,
+			Field:      "apiVersion",
+			Message:    fmt.Sprintf("the cluster does not serve %s in %s", gvk.Kind, obj.GetAPIVersion()),
+			Suggestion: apiVersionSuggestion(byGVK, gvk),
+			Source:     sourceDryRun,
+		}}
+	}
+	if err != nil {
+		return []validationError{{Document: doc.index, Resource: resource, Message: err.Error(), Source: sourceDryRun}}
+	}
+
+	var client dynamic.ResourceInterface = s.dynamicClient.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(defaultNamespace)
+		}
+		client = s.dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+	}
+
+	data, err := json.Marshal(obj.Object)
+	if err != nil {
+		return []validationError{{Document: doc.index, Resource: resource, Message: err.Error(), Source: sourceDryRun}}
+	}
+	_, err = client.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager:    fieldManager,
+		Force:           boolPtr(true),
+		DryRun:          []string{metav1.DryRunAll},
+		FieldValidation: metav1.FieldValidationStrict,
+	})
+	if err == nil {
+		return nil
+	}
+
+	var status apierrors.APIStatus
+	if errors.As(err, &status) {
+		if details := status.Status().Details; details != nil && len(details.Causes) > 0 {
+			errs := make([]validationError, 0, len(details.Causes))
+			for _, cause := range details.Causes {
+				errs = append(errs, validationError{
+					Document:   doc.index,
+					Resource:   resource,
+					Field:      cause.Field,
+					Message:    cause.Message,
+					Suggestion: causeSuggestion(cause),
+					Source:     sourceDryRun,
+				})
+			}
+			return errs
+		}
+	}
+
+	suggestion := ""
+	switch {
+	case apierrors.IsNotFound(err):
+		suggestion = fmt.Sprintf("Create namespace %s, or anything else the resource refers to, before applying it", obj.GetNamespace())
+	case apierrors.IsForbidden(err):
+		suggestion = "The server's credentials may not create this resource; check RBAC, or whether an admission policy rejected it"
+	case apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err):
+		suggestion = "Another manager owns the conflicting fields; rename the resource or reconcile it with the live object"
+	}
+	return []validationError{{
+		Document:   doc.index,
+		Resource:   resource,
+		Message:    err.Error(),
+		Suggestion: suggestion,
+		Source:     sourceDryRun,
+	}}
+}
+
+// Cause types the API server reports from field validation that metav1 has no constants for
+const (
+	causeTypeFieldValueTooLong   metav1.CauseType = "FieldValueTooLong"
+	causeTypeFieldValueForbidden metav1.CauseType = "FieldValueForbidden"
+)
+
+// causeSuggestion suggests a correction for one cause of an Invalid response
+func causeSuggestion(cause metav1.StatusCause) string {
+	switch cause.Type {
+	case metav1.CauseTypeFieldValueRequired:
+		return fmt.Sprintf("Set %s", cause.Field)
+	case metav1.CauseTypeFieldValueNotSupported:
+		return "Use one of the supported values listed in the message"
+	case metav1.CauseTypeFieldValueDuplicate:
+		return "Remove the duplicate entry or give it a unique name"
+	case causeTypeFieldValueTooLong, metav1.CauseTypeTooMany:
+		return "Shorten the value or reduce the number of entries"
+	case causeTypeFieldValueForbidden:
+		return "Remove the field or change the value; it is not allowed here"
+	case metav1.CauseTypeFieldValueNotFound:
+		return "Create what the field refers to first, or correct the reference"
+	case metav1.CauseTypeFieldValueInvalid:
+		if strings.Contains(cause.Message, "immutable") {
+			return "The field cannot be changed on an existing resource; delete and recreate it, or keep the live value"
+		}
+		if strings.Contains(cause.Message, "RFC 1123") {
+			return "Use lowercase letters, digits and '-', starting and ending with a letter or digit"
+		}
+		return "Correct the value as described in the message"
+	}
+	if strings.Contains(cause.Message, "unknown field") {
+		return "Remove the field or fix its spelling"
+	}
+	return ""
+}
+
+// schemaValidator checks an object against OpenAPI v2 definitions, collecting errors like kubeval does
+type schemaValidator struct {
+	definitions map[string]interface{}
+	document    int
+	resource    string
+	errs        []validationError
+}
+
+// fail records an error at path
+func (v *schemaValidator) fail(path, message, suggestion string) {
+	v.errs = append(v.errs, validationError{
+		Document:   v.document,
+		Resource:   v.resource,
+		Field:      path,
+		Message:    message,
+		Suggestion: suggestion,
+		Source:     sourceSchema,
+	})
+}
+
+// resolve follows $ref and single-entry allOf wrappers, returning the schema and the name of the last definition followed
+func (v *schemaValidator) resolve(schema map[string]interface{}) (map[string]interface{}, string) {
+	name := ""
+	for i := 0; i < maxSchemaDepth; i++ {
+		if allOf, ok := schema["allOf"].([]interface{}); ok && len(allOf) == 1 {
+			if inner, ok := allOf[0].(map[string]interface{}); ok {
+				schema = inner
+				continue
+			}
+		}
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			break
+		}
+		name = strings.TrimPrefix(ref, "#/definitions/")
+		definition, _ := v.definitions[name].(map[string]interface{})
+		if definition == nil {
+			return nil, name
+		}
+		schema = definition
+	}
+	return schema, name
+}
+
+// validate checks value at path against schema
+func (v *schemaValidator) validate(path string, value interface{}, schema map[string]interface{}, depth int) {
+	if value == nil || depth > maxSchemaDepth {
+		return
+	}
+	schema, name := v.resolve(schema)
+	if schema == nil {
+		return
+	}
+	if preserve, _ := schema["x-kubernetes-preserve-unknown-fields"].(bool); preserve {
+		return
+	}
+	// Quantities are strings in the schema but manifests routinely write them as numbers, such as cpu: 1
+	if strings.HasSuffix(name, ".api.resource.Quantity") {
+		return
+	}
+
+	kind, _ := schema["type"].(string)
+	properties, _ := schema["properties"].(map[string]interface{})
+	if kind == "" && properties != nil {
+		kind = "object"
+	}
+	format, _ := schema["format"].(string)
+
+	switch kind {
+	case "object":
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			v.fail(displayPath(path), fmt.Sprintf("expected an object, got %s", jsonType(value)), "Replace the value with a mapping of fields")
+			return
+		}
+		v.validateObject(path, fields, schema, properties, depth)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			v.fail(displayPath(path), fmt.Sprintf("expected a list, got %s", jsonType(value)), "Write the value as a list, with each entry on a line starting with -")
+			return
+		}
+		itemSchema, _ := schema["items"].(map[string]interface{})
+		if itemSchema == nil {
+			return
+		}
+		for i, item := range items {
+			v.validate(fmt.Sprintf("%s[%d]", path, i), item, itemSchema, depth+1)
+		}
+	case "string":
+		if format == "int-or-string" {
+			if _, ok := value.(string); !ok && !isInteger(value) {
+				v.fail(displayPath(path), fmt.Sprintf("expected an integer or string, got %s", jsonType(value)), "Use a port number or name, or a count or percentage such as 25%")
+			}
+			return
+		}
+		if _, ok := value.(string); !ok {
+			v.fail(displayPath(path), fmt.Sprintf("expected a string, got %s", jsonType(value)), fmt.Sprintf("Quote the value, e.g. \"%v\"", value))
+			return
+		}
+	case "integer":
+		if !isInteger(value) {
+			suggestion := "Use a whole number"
+			if _, ok := value.(string); ok {
+				suggestion = "Remove the quotes so the value is a number"
+			}
+			v.fail(displayPath(path), fmt.Sprintf("expected an integer, got %s", jsonType(value)), suggestion)
+			return
+		}
+	case "number":
+		switch value.(type) {
+		case int64, float64:
+		default:
+			v.fail(displayPath(path), fmt.Sprintf("expected a number, got %s", jsonType(value)), "Remove the quotes so the value is a number")
+			return
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.fail(displayPath(path), fmt.Sprintf("expected a boolean, got %s", jsonType(value)), "Use true or false without quotes")
+			return
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		allowed := make([]string, 0, len(enum))
+		for _, option := range enum {
+			if fmt.Sprint(option) == fmt.Sprint(value) {
+				return
+			}
+			allowed = append(allowed, fmt.Sprint(option))
+		}
+		suggestion := "Use one of: " + strings.Join(allowed, ", ")
+		if match := closestMatch(fmt.Sprint(value), allowed); match != "" {
+			suggestion = fmt.Sprintf("Did you mean %s?", match)
+		}
+		v.fail(displayPath(path), fmt.Sprintf("unsupported value %v", value), suggestion)
+	}
+}
+
+// validateObject checks the required and unknown fields of an object and validates each field
+func (v *schemaValidator) validateObject(path string, fields, schema, properties map[string]interface{}, depth int) {
+	required, _ := schema["required"].([]interface{})
+	for _, entry := range required {
+		field, _ := entry.(string)
+		if _, ok := fields[field]; field != "" && !ok {
+			v.fail(joinPath(path, field), fmt.Sprintf("missing required field %s", field), fmt.Sprintf("Add %s", joinPath(path, field)))
+		}
+	}
+
+	additional, _ := schema["additionalProperties"].(map[string]interface{})
+	known := make([]string, 0, len(properties))
+	for field := range properties {
+		known = append(known, field)
+	}
+	sort.Strings(known)
+
+	// Sorted so errors are reported deterministically
+	for _, field := range sortedKeys(fields) {
+		fieldPath := joinPath(path, field)
+		if propertySchema, ok := properties[field].(map[string]interface{}); ok {
+			v.validate(fieldPath, fields[field], propertySchema, depth+1)
+			continue
+		}
+		if additional != nil {
+			v.validate(fieldPath, fields[field], additional, depth+1)
+			continue
+		}
+		if properties == nil {
+			// A free-form object
+			continue
+		}
+
+		suggestion := fmt.Sprintf("Remove the field; valid fields are: %s", strings.Join(known, ", "))
+		if match := closestMatch(field, known); match != "" {
+			suggestion = fmt.Sprintf("Did you mean %s?", joinPath(path, match))
+		}
+		v.fail(fieldPath, fmt.Sprintf("unknown field %s", field), suggestion)
+	}
+}
+
+// joinPath appends a field to a dotted field path
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+// displayPath names the document root for errors about the whole object
+func displayPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
+
+// isInteger reports whether a decoded JSON value is a whole number
+func isInteger(value interface{}) bool {
+	switch n := value.(type) {
+	case int64:
+		return true
+	case float64:
+		return n == math.Trunc(n)
+	}
+	return false
+}
+
+// jsonType names the JSON type of a decoded value for error messages
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case int64, float64:
+		return "a number"
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// closestMatch returns the candidate closest to word by edit distance, ignoring case, or "" when none is close
+func closestMatch(word string, candidates []string) string {
+	best := ""
+	bestDistance := max(2, len(word)/3) + 1
+	for _, candidate := range candidates {
+		if distance := editDistance(strings.ToLower(word), strings.ToLower(candidate)); distance < bestDistance {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(br)]
+}
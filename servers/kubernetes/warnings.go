@@ -0,0 +1,100 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mcp-servers/cli/pkg/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultWarningEventLimit is how many warning events list_warning_events returns by default
+const defaultWarningEventLimit = 50
+
+// warningTools returns the warning event tool definitions
+func warningTools() []mcp.Tool {
+	return []mcp.Tool{
+		{
+			Name:        "list_warning_events",
+			Description: "List the most recent Warning events, newest first; a quick first look at what is wrong in a cluster",
+			InputSchema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"namespace": map[string]interface{}{
+						"type":        "string",
+						"description": "Namespace to list (omit for all namespaces)",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Maximum number of events to return (defaults to %d)", defaultWarningEventLimit),
+					},
+				},
+			},
+		},
+	}
+}
+
+// warningEvent is one Warning event in the list_warning_events result
+type warningEvent struct {
+	Namespace       string `json:"namespace"`
+	InvolvingObject string `json:"involving_object"`
+	Reason          string `json:"reason"`
+	Message         string `json:"message"`
+	Count           int32  `json:"count"`
+	LastSeen        string `json:"last_seen"`
+
+	lastSeen time.Time
+}
+
+func (s *Server) listWarningEventsTool(args map[string]interface{}) (*mcp.ToolResult, error) {
+	namespace := optionalStringArg(args, "namespace", "")
+	limit, err := intArg(args, "limit", defaultWarningEventLimit)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	list, err := s.clientset.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{FieldSelector: "type=Warning"})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]warningEvent, 0, len(list.Items))
+	for _, event := range list.Items {
+		count := event.Count
+		if count == 0 && event.Series != nil {
+			count = event.Series.Count
+		}
+		if count == 0 {
+			count = 1
+		}
+		events = append(events, warningEvent{
+			Namespace:       event.Namespace,
+			InvolvingObject: fmt.Sprintf("%s/%s", event.InvolvedObject.Kind, event.InvolvedObject.Name),
+			Reason:          event.Reason,
+			Message:         event.Message,
+			Count:           count,
+			lastSeen:        eventTime(event),
+		})
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].lastSeen.After(events[j].lastSeen)
+	})
+	total := len(events)
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	for i := range events {
+		events[i].LastSeen = events[i].lastSeen.Format(time.RFC3339)
+	}
+
+	return jsonResult(map[string]interface{}{
+		"total":  total,
+		"events": events,
+	})
+}